@@ -17,6 +17,7 @@ import (
 // @version 1.0
 // @description REST API для хранения и обработки информации об онлайн-подписках пользователей.
 // @description Сервис позволяет добавлять, изменять, удалять и просматривать записи о подписках, а также рассчитывать суммарную стоимость подписок за выбранный период.
+// @description Ошибки возвращаются в формате application/problem+json на русском или английском языке в зависимости от заголовка Accept-Language.
 
 // @license.name MIT
 // @license.url https://opensource.org/licenses/MIT
@@ -24,9 +25,26 @@ import (
 // @host localhost:8080
 // @BasePath /
 
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT в формате "Bearer <token>". Требуется, когда включена аутентификация (auth.enabled).
+// @description Пользователи без роли admin видят и изменяют только подписки со своим user_id.
+
+// @securityDefinitions.apikey APIKeyAuth
+// @in header
+// @name X-API-Key
+// @description API-ключ межсервисного клиента. Разрешает только действия из scopes ключа.
+
 // @tag.name subscriptions
 // @tag.description Операции с подписками пользователей
 
+// @tag.name health
+// @tag.description Проверки жизнеспособности и готовности сервиса
+
+// @tag.name api-keys
+// @tag.description Управление API-ключами межсервисных клиентов (только admin)
+
 func main() {
 	err := godotenv.Load()
 	if err != nil {
@@ -0,0 +1,2853 @@
+// Package v1 Code generated by swaggo/swag. DO NOT EDIT
+package v1
+
+import "github.com/swaggo/swag"
+
+const docTemplatev1 = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "contact": {},
+        "license": {
+            "name": "MIT",
+            "url": "https://opensource.org/licenses/MIT"
+        },
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/admin/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает все ключи, включая отозванные и истёкшие, новые первыми. Сами ключи не возвращаются.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Список API-ключей",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIKeyListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Требуется роль admin",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышен лимит запросов",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выпускает ключ для межсервисного клиента. Ключ передаётся в заголовке X-API-Key\nи разрешает только действия из scopes: subscriptions:read — чтение подписок,\nsubscriptions:write — их изменение, reports:read — расчёт суммарной стоимости.\nСам ключ возвращается только в этом ответе, сервис хранит лишь его хэш.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Выпустить API-ключ",
+                "parameters": [
+                    {
+                        "description": "Параметры ключа",
+                        "name": "key",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.IssueAPIKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Выпущенный ключ",
+                        "schema": {
+                            "$ref": "#/definitions/dto.IssuedAPIKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Требуется роль admin",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышен лимит запросов",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/api-keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отзывает ключ по UUID. Отозванный ключ перестаёт приниматься сразу.",
+                "tags": [
+                    "api-keys"
+                ],
+                "summary": "Отозвать API-ключ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Ключ отозван"
+                    },
+                    "400": {
+                        "description": "Неверный UUID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Требуется роль admin",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Ключ не найден",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышен лимит запросов",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Отвечает 200, пока процесс работает. Зависимости не проверяются,\nчтобы недоступность базы данных не приводила к перезапуску сервиса.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Проверка жизнеспособности",
+                "responses": {
+                    "200": {
+                        "description": "Сервис работает",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Проверяет подключение к базе данных и применение всех миграций,\nсостояние каждого компонента возвращается в components.\nВо время остановки сервиса отвечает 503, чтобы балансировщик перестал направлять запросы.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Проверка готовности",
+                "responses": {
+                    "200": {
+                        "description": "Сервис готов принимать запросы",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Сервис не готов",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/subscriptions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает страницу подписок с фильтрацией по параметрам.\nВсе заданные фильтры объединяются через AND:\nservice_name_in — список точных названий через запятую, service_name_prefix — начало названия без учёта регистра,\nprice_min и price_max — границы цены включительно, active_at — подписки, действующие в указанном месяце (или в день, если он указан),\nexpiring_before — подписки, заканчивающиеся раньше указанного месяца,\nopen_ended=true — бессрочные подписки (false — только подписки с датой окончания).\nПо умолчанию подписки упорядочены по (start_date, id); порядок меняется параметром sort,\nнапример sort=price,-start_date (допустимые поля: service_name, price, start_date, end_date).\nДля постраничного обхода передайте next_cursor предыдущей страницы в параметре cursor;\npage и page_size остаются доступны как запасной вариант. Курсор не совместим с sort.\npage_size — от 1, по умолчанию 20.\nОбщее количество (total) не считается при include_total=false.\nСсылки на первую, предыдущую, следующую и последнюю страницы передаются в заголовке Link.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Список подписок",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "08-2025",
+                        "description": "ActiveAt matches subscriptions active during the month, or on the day\nwhen it is spelled with one.",
+                        "name": "active_at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "eyJkIjoiMjAyNS0wOC0wMSIsImlkIjoiMTIzZTQ1NjctZTg5Yi0xMmQzLWE0NTYtNDI2NjE0MTc0MDAwIn0",
+                        "description": "Cursor is the next_cursor of a previous page. When set, Page is ignored.",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "09-2025",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "12-2025",
+                        "description": "ExpiringBefore matches subscriptions ending before the month.",
+                        "name": "expiring_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "IncludeTotal=false skips counting the matching subscriptions.",
+                        "name": "include_total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "example": true,
+                        "description": "OpenEnded=true matches subscriptions without end date, false ones with it.",
+                        "name": "open_ended",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 1,
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 20,
+                        "description": "PageSize is bounded by PageSizeLimit since the second API version.",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "example": 1000,
+                        "name": "price_max",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "example": 100,
+                        "name": "price_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Netflix",
+                        "name": "service_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "Netflix,Spotify",
+                        "description": "ServiceNameIn is a comma separated list of exact service names.",
+                        "name": "service_name_in",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "net",
+                        "description": "ServiceNamePrefix matches service names starting with it, ignoring case.",
+                        "name": "service_name_prefix",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "price,-start_date",
+                        "description": "Sort is a comma separated list of service_name, price, start_date and end_date.\nA leading \"-\" sorts in descending order. Cannot be combined with Cursor.",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "08-2025",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "123e4567-e89b-12d3-a456-426614174000",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "legacy",
+                            "iso"
+                        ],
+                        "type": "string",
+                        "description": "Формат дат в ответе: legacy — MM-YYYY (по умолчанию), iso — YYYY-MM",
+                        "name": "date_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.SubscriptionListResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Link": {
+                                "type": "string",
+                                "description": "Ссылки на страницы (RFC 8288): first, prev, next, last"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации параметров запроса",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ к подписке запрещён политикой",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccessDeniedProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Превышен лимит запросов",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Создает новую подписку с данными из JSON. Повтор запроса с тем же заголовком Idempotency-Key\nвозвращает ответ первого запроса (с заголовком Idempotent-Replayed) и не создаёт подписку повторно.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Создать подписку",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности, до 255 символов",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Данные новой подписки",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "ID созданной подписки",
+                        "schema": {
+                            "$ref": "#/definitions/dto.IDResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ к подписке запрещён политикой",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccessDeniedProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Подписка дублирует существующие (если включена проверка) или запрос с этим ключом идемпотентности ещё выполняется",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DuplicateProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации или ключ идемпотентности использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Превышен лимит запросов",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/subscriptions/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Server-Sent Events: событие created, updated или deleted на каждое изменение подписки.\nДанные события — объект SubscriptionEventResponse, id события передаётся в поле id.\nПри переподключении клиент передаёт заголовок Last-Event-ID (или параметр last_event_id)\nи получает пропущенные события из журнала последних изменений. Если нужные события\nуже вытеснены из журнала, приходит событие reset: подписки нужно загрузить заново.\nПустые комментарии периодически отправляются, чтобы прокси не закрывали соединение.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Поток изменений подписок",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Только подписки пользователя",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Только подписки сервиса, без учёта регистра",
+                        "name": "service_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID последнего полученного события",
+                        "name": "last_event_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID последнего полученного события, важнее параметра",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "enum": [
+                            "legacy",
+                            "iso"
+                        ],
+                        "type": "string",
+                        "description": "Формат дат в ответе: legacy — MM-YYYY (по умолчанию), iso — YYYY-MM",
+                        "name": "date_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Поток событий",
+                        "schema": {
+                            "$ref": "#/definitions/v1.SubscriptionEventResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ к подпискам запрещён политикой",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccessDeniedProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Превышен лимит запросов",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/subscriptions/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Объединяет исходные подписки с целевой и удаляет их. У целевой подписки сохраняются\nназвание и цена, период расширяется до объединения периодов, заметки дописываются.\nУдалённые подписки сохраняются в журнале объединений вместе с автором и временем.\nОбъединять можно только подписки одного пользователя и одного сервиса.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Объединить подписки",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности, до 255 символов",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Целевая и исходные подписки",
+                        "name": "merge",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MergeSubscriptionsRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "legacy",
+                            "iso"
+                        ],
+                        "type": "string",
+                        "description": "Формат дат в ответе: legacy — MM-YYYY (по умолчанию), iso — YYYY-MM",
+                        "name": "date_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результат объединения",
+                        "schema": {
+                            "$ref": "#/definitions/v1.MergeSubscriptionsResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос или подписки нельзя объединить",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ к подписке запрещён политикой",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccessDeniedProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Подписка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Запрос с этим ключом идемпотентности ещё выполняется",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации или ключ идемпотентности использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Превышен лимит запросов",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/subscriptions/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Ищет подписки по названию сервиса и заметкам. Поиск нечёткий: находит слова в любом регистре\nи названия с опечатками. Результаты упорядочены по релевантности; совпадения в названии\nважнее совпадений в заметках. Нечёткий поиск доступен только на PostgreSQL и в памяти,\nостальные драйверы ищут подстроку без учёта регистра.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Поиск подписок",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "example": 20,
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "netflix",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "123e4567-e89b-12d3-a456-426614174000",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "legacy",
+                            "iso"
+                        ],
+                        "type": "string",
+                        "description": "Формат дат в ответе: legacy — MM-YYYY (по умолчанию), iso — YYYY-MM",
+                        "name": "date_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.SubscriptionSearchResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации параметров запроса",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ к подписке запрещён политикой",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccessDeniedProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Превышен лимит запросов",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/subscriptions/total": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает общую стоимость подписок по фильтру",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Рассчитать общую стоимость подписок",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "09-2025",
+                        "name": "period_end",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "08-2025",
+                        "name": "period_start",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "Netflix",
+                        "name": "service_name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "123e4567-e89b-12d3-a456-426614174000",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результат расчета стоимости в валюте организации",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TotalCostResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации параметров запроса",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ к подписке запрещён политикой",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccessDeniedProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Превышен лимит запросов",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/subscriptions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Возвращает подписку по заданному UUID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Получить подписку по ID",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "legacy",
+                            "iso"
+                        ],
+                        "type": "string",
+                        "description": "Формат дат в ответе: legacy — MM-YYYY (по умолчанию), iso — YYYY-MM",
+                        "name": "date_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Подписка найдена",
+                        "schema": {
+                            "$ref": "#/definitions/v1.SubscriptionResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный UUID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ к подписке запрещён политикой",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccessDeniedProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Подписка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Превышен лимит запросов",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Обновляет подписку по UUID с данными из JSON. Если notes нет в теле, заметки не меняются.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Обновить подписку",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные обновления подписки",
+                        "name": "subscription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Подписка обновлена",
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный UUID или данные запроса",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ к подписке запрещён политикой",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccessDeniedProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Подписка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Превышен лимит запросов",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Удаляет подписку по UUID",
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Удалить подписку по ID",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Подписка удалена",
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный UUID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ к подписке запрещён политикой",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccessDeniedProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Подписка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Превышен лимит запросов",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Применяет JSON Merge Patch (RFC 7396): отсутствующие поля не меняются,\nnull в end_date делает подписку бессрочной, null в notes удаляет заметки.\nЦена 0 допустима. Возвращает обновлённую подписку.",
+                "consumes": [
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Частично обновить подписку",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ключ идемпотентности, до 255 символов",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Изменяемые поля подписки",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PatchSubscriptionRequest"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "legacy",
+                            "iso"
+                        ],
+                        "type": "string",
+                        "description": "Формат дат в ответе: legacy — MM-YYYY (по умолчанию), iso — YYYY-MM",
+                        "name": "date_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Подписка обновлена",
+                        "schema": {
+                            "$ref": "#/definitions/v1.SubscriptionResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный UUID, данные запроса или период",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ к подписке запрещён политикой",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccessDeniedProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Подписка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Запрос с этим ключом идемпотентности ещё выполняется",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "415": {
+                        "description": "Тело не в формате application/merge-patch+json",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации или ключ идемпотентности использован с другим запросом",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Превышен лимит запросов",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/{id}/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Группирует подписки пользователя, которые, вероятно, внесены дважды: один сервис\n(название сравнивается без учёта регистра, пробелов и знаков препинания) и пересекающиеся периоды.\nПодписки без даты окончания считаются бессрочными. Подписки без дубликатов не возвращаются.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "subscriptions"
+                ],
+                "summary": "Найти дубликаты подписок пользователя",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "legacy",
+                            "iso"
+                        ],
+                        "type": "string",
+                        "description": "Формат дат в ответе: legacy — MM-YYYY (по умолчанию), iso — YYYY-MM",
+                        "name": "date_format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.DuplicateGroupsResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный UUID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Требуется аутентификация",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Доступ к подписке запрещён политикой",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AccessDeniedProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Превышен лимит запросов",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ProblemResponse"
+                        },
+                        "headers": {
+                            "Deprecation": {
+                                "type": "string",
+                                "description": "Время, с которого версия устарела (RFC 9745)"
+                            },
+                            "Sunset": {
+                                "type": "string",
+                                "description": "Время отключения версии (RFC 8594)"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.APIKeyListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.APIKeyResponse"
+                    }
+                }
+            }
+        },
+        "dto.APIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-01T12:00:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2025-08-02T09:30:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "billing-export"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "sk_Xk3v9QmA"
+                },
+                "revoked_at": {
+                    "type": "string",
+                    "example": "2025-09-01T00:00:00Z"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "subscriptions:read",
+                        "reports:read"
+                    ]
+                }
+            }
+        },
+        "dto.AccessDeniedProblemResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "subscriptions:update"
+                },
+                "code": {
+                    "description": "Code is the stable identifier of the error, listed in the error catalogue.",
+                    "type": "string",
+                    "example": "subscription.not_found"
+                },
+                "detail": {
+                    "description": "Detail is left out for internal errors outside the local environment.",
+                    "type": "string",
+                    "example": "not found"
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/subscriptions/123e4567-e89b-12d3-a456-426614174000"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "no role grants the action"
+                },
+                "resource": {
+                    "type": "string",
+                    "example": "subscriptions/123e4567-e89b-12d3-a456-426614174000"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "finance"
+                    ]
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Subscription not found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "urn:problem:subscription.not_found"
+                }
+            }
+        },
+        "dto.ComponentHealthResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "failed to ping DB: connection refused"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "up",
+                        "down"
+                    ],
+                    "example": "up"
+                }
+            }
+        },
+        "dto.CreateSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "price",
+                "service_name",
+                "start_date",
+                "user_id"
+            ],
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "09-2025"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Семейный тариф"
+                },
+                "price": {
+                    "description": "Price is a pointer, so that required accepts free subscriptions.",
+                    "type": "integer",
+                    "example": 999
+                },
+                "service_name": {
+                    "type": "string",
+                    "example": "Netflix"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "08-2025"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "dto.DuplicateProblemResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is the stable identifier of the error, listed in the error catalogue.",
+                    "type": "string",
+                    "example": "subscription.not_found"
+                },
+                "detail": {
+                    "description": "Detail is left out for internal errors outside the local environment.",
+                    "type": "string",
+                    "example": "not found"
+                },
+                "duplicate_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "123e4567-e89b-12d3-a456-426614174000"
+                    ]
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/subscriptions/123e4567-e89b-12d3-a456-426614174000"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Subscription not found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "urn:problem:subscription.not_found"
+                }
+            }
+        },
+        "dto.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "components": {
+                    "description": "Components are keyed by name, e.g. database or migrations.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/dto.ComponentHealthResponse"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "up",
+                        "down"
+                    ],
+                    "example": "up"
+                }
+            }
+        },
+        "dto.IDResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "dto.IssueAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "description": "ExpiresAt is optional; keys without it never expire.",
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "billing-export"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "subscriptions:read",
+                        "reports:read"
+                    ]
+                }
+            }
+        },
+        "dto.IssuedAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-08-01T12:00:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "key": {
+                    "description": "Key is shown only once and can't be recovered later.",
+                    "type": "string",
+                    "example": "sk_Xk3v9QmA5bT0cW2yZ8rN1pL7hD4fG6jE9sQ3uV0aB2c"
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2025-08-02T09:30:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "billing-export"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "sk_Xk3v9QmA"
+                },
+                "revoked_at": {
+                    "type": "string",
+                    "example": "2025-09-01T00:00:00Z"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "subscriptions:read",
+                        "reports:read"
+                    ]
+                }
+            }
+        },
+        "dto.MergeSubscriptionsRequest": {
+            "type": "object",
+            "required": [
+                "source_ids",
+                "target_id"
+            ],
+            "properties": {
+                "source_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "223e4567-e89b-12d3-a456-426614174000"
+                    ]
+                },
+                "target_id": {
+                    "description": "TargetID is the subscription that remains; its name and price are kept.",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "dto.PatchSubscriptionRequest": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "09-2025"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Семейный тариф"
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "service_name": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "Netflix"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "08-2025"
+                }
+            }
+        },
+        "dto.ProblemResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is the stable identifier of the error, listed in the error catalogue.",
+                    "type": "string",
+                    "example": "subscription.not_found"
+                },
+                "detail": {
+                    "description": "Detail is left out for internal errors outside the local environment.",
+                    "type": "string",
+                    "example": "not found"
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/subscriptions/123e4567-e89b-12d3-a456-426614174000"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Subscription not found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "urn:problem:subscription.not_found"
+                }
+            }
+        },
+        "dto.TotalCostResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "Currency is the ISO 4217 code of the tenant's prices.",
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "value": {
+                    "type": "integer",
+                    "example": 999
+                }
+            }
+        },
+        "dto.UpdateSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "price",
+                "service_name",
+                "start_date"
+            ],
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "09-2025"
+                },
+                "notes": {
+                    "description": "Notes are kept when absent, so that clients unaware of them don't erase them.",
+                    "type": "string",
+                    "maxLength": 1000,
+                    "example": "Семейный тариф"
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 999
+                },
+                "service_name": {
+                    "type": "string",
+                    "example": "Netflix"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "08-2025"
+                }
+            }
+        },
+        "dto.ValidationProblemResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is the stable identifier of the error, listed in the error catalogue.",
+                    "type": "string",
+                    "example": "subscription.not_found"
+                },
+                "detail": {
+                    "description": "Detail is left out for internal errors outside the local environment.",
+                    "type": "string",
+                    "example": "not found"
+                },
+                "errors": {
+                    "description": "Errors map invalid fields to what is wrong with them.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/subscriptions/123e4567-e89b-12d3-a456-426614174000"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Subscription not found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "urn:problem:subscription.not_found"
+                }
+            }
+        },
+        "v1.DuplicateGroupResponse": {
+            "type": "object",
+            "properties": {
+                "service_name": {
+                    "type": "string",
+                    "example": "Netflix"
+                },
+                "subscriptions": {
+                    "description": "Subscriptions are ordered by start date.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.SubscriptionResponse"
+                    }
+                }
+            }
+        },
+        "v1.DuplicateGroupsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.DuplicateGroupResponse"
+                    }
+                }
+            }
+        },
+        "v1.MergeSubscriptionsResponse": {
+            "type": "object",
+            "properties": {
+                "merged_at": {
+                    "type": "string",
+                    "example": "2025-08-01T12:00:00Z"
+                },
+                "merged_by": {
+                    "description": "MergedBy is the subject of the caller, empty without authentication.",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "merged_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "223e4567-e89b-12d3-a456-426614174000"
+                    ]
+                },
+                "subscription": {
+                    "$ref": "#/definitions/v1.SubscriptionResponse"
+                }
+            }
+        },
+        "v1.SubscriptionEventResponse": {
+            "type": "object",
+            "properties": {
+                "occurred_at": {
+                    "type": "string",
+                    "example": "2025-08-01T12:00:00Z"
+                },
+                "subscription": {
+                    "description": "Subscription is the changed one, deleted ones have their last values.\nIt is absent from reset events.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/v1.SubscriptionResponse"
+                        }
+                    ]
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "updated",
+                        "deleted",
+                        "reset"
+                    ],
+                    "example": "updated"
+                }
+            }
+        },
+        "v1.SubscriptionListResponse": {
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.SubscriptionResponse"
+                    }
+                },
+                "next_cursor": {
+                    "description": "NextCursor is passed as the cursor query parameter to fetch the next page.",
+                    "type": "string",
+                    "example": "eyJkIjoiMjAyNS0wOC0wMSIsImlkIjoiMTIzZTQ1NjctZTg5Yi0xMmQzLWE0NTYtNDI2NjE0MTc0MDAwIn0"
+                },
+                "page": {
+                    "description": "Page is null when the page was requested by cursor.",
+                    "type": "integer",
+                    "example": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "total": {
+                    "description": "Total is null when include_total=false.",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "v1.SubscriptionResponse": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "09-2025"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Семейный тариф"
+                },
+                "price": {
+                    "type": "integer",
+                    "example": 999
+                },
+                "service_name": {
+                    "type": "string",
+                    "example": "Netflix"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "08-2025"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "v1.SubscriptionSearchResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "Items are ordered by relevance, most relevant first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.SubscriptionResponse"
+                    }
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "APIKeyAuth": {
+            "description": "API-ключ межсервисного клиента. Разрешает только действия из scopes ключа.",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "JWT в формате \"Bearer \u003ctoken\u003e\". Требуется, когда включена аутентификация (auth.enabled).\nПользователи без роли admin видят и изменяют только подписки со своим user_id.",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
+    },
+    "tags": [
+        {
+            "description": "Операции с подписками пользователей",
+            "name": "subscriptions"
+        },
+        {
+            "description": "Проверки жизнеспособности и готовности сервиса",
+            "name": "health"
+        },
+        {
+            "description": "Управление API-ключами межсервисных клиентов (только admin)",
+            "name": "api-keys"
+        }
+    ]
+}`
+
+// SwaggerInfov1 holds exported Swagger Info so clients can modify it
+var SwaggerInfov1 = &swag.Spec{
+	Version:          "1.0",
+	Host:             "localhost:8080",
+	BasePath:         "/",
+	Schemes:          []string{},
+	Title:            "Effective Mobile GO - Subscription Service API",
+	Description:      "REST API для хранения и обработки информации об онлайн-подписках пользователей.\nСервис позволяет добавлять, изменять, удалять и просматривать записи о подписках, а также рассчитывать суммарную стоимость подписок за выбранный период.\nОшибки возвращаются в формате application/problem+json на русском или английском языке в зависимости от заголовка Accept-Language.",
+	InfoInstanceName: "v1",
+	SwaggerTemplate:  docTemplatev1,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfov1.InstanceName(), SwaggerInfov1)
+}
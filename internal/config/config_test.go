@@ -0,0 +1,45 @@
+package config_test
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/MDx3R/ef-test/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenancyConfig_Settings(t *testing.T) {
+	cfg := config.TenancyConfig{
+		Defaults: config.TenantSettings{
+			Currency:  "RUB",
+			RateLimit: config.RateLimitSettings{RequestsPerSecond: 10, Burst: 20},
+		},
+		Tenants: map[string]config.TenantSettings{
+			"retail": {Currency: "EUR", RateLimit: config.RateLimitSettings{Burst: 100}},
+		},
+	}
+
+	assert.Equal(t, cfg.Defaults, cfg.Settings("unknown"))
+	assert.Equal(t, config.TenantSettings{
+		Currency:  "EUR",
+		RateLimit: config.RateLimitSettings{RequestsPerSecond: 10, Burst: 100},
+	}, cfg.Settings("retail"))
+}
+
+func TestGetPolicyFromPath(t *testing.T) {
+	cfg, err := config.GetPolicyFromPath("../../configs/policy.yaml")
+
+	require.NoError(t, err)
+	assert.Equal(t, "editor", cfg.DefaultRole)
+	assert.ElementsMatch(t, []string{"viewer", "editor", "finance", "admin"}, slices.Collect(maps.Keys(cfg.Roles)))
+	assert.Contains(t, cfg.Roles["finance"], config.PermissionConfig{Action: "reports:total", Resource: "any"})
+}
+
+func TestGetPolicyFromPath_Missing(t *testing.T) {
+	_, err := config.GetPolicyFromPath(filepath.Join(t.TempDir(), "policy.yaml"))
+
+	assert.Error(t, err)
+}
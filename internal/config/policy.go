@@ -0,0 +1,31 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/ilyakaznacheev/cleanenv"
+)
+
+// PolicyConfig grants permissions to roles.
+type PolicyConfig struct {
+	// DefaultRole applies to callers whose credentials carry no roles.
+	DefaultRole string                        `yaml:"default_role"`
+	Roles       map[string][]PermissionConfig `yaml:"roles"`
+}
+
+// PermissionConfig allows an action on either the caller's own
+// subscriptions ("own") or on everyone's ("any").
+type PermissionConfig struct {
+	Action   string `yaml:"action"`
+	Resource string `yaml:"resource"`
+}
+
+func GetPolicyFromPath(path string) (*PolicyConfig, error) {
+	var cfg PolicyConfig
+
+	if err := cleanenv.ReadConfig(path, &cfg); err != nil {
+		return nil, fmt.Errorf("cannot read policy %s: %w", path, err)
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,149 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	ScopeSubscriptionsRead  = "subscriptions:read"
+	ScopeSubscriptionsWrite = "subscriptions:write"
+	ScopeReportsRead        = "reports:read"
+)
+
+var scopes = []string{ScopeSubscriptionsRead, ScopeSubscriptionsWrite, ScopeReportsRead}
+
+func IsValidScope(scope string) bool {
+	return slices.Contains(scopes, scope)
+}
+
+// APIKey is a long-lived credential of a service-to-service client of a tenant.
+// Only a hash of the key is kept; the key itself is shown once when issued.
+type APIKey struct {
+	id         uuid.UUID
+	tenantID   string
+	name       string
+	hash       string
+	prefix     string
+	scopes     []string
+	createdAt  time.Time
+	expiresAt  *time.Time
+	lastUsedAt *time.Time
+	revokedAt  *time.Time
+}
+
+func (k *APIKey) ID() uuid.UUID {
+	return k.id
+}
+
+func (k *APIKey) TenantID() string {
+	return k.tenantID
+}
+
+func (k *APIKey) Name() string {
+	return k.name
+}
+
+func (k *APIKey) Hash() string {
+	return k.hash
+}
+
+// Prefix is the beginning of the key, which helps to tell keys apart.
+func (k *APIKey) Prefix() string {
+	return k.prefix
+}
+
+func (k *APIKey) Scopes() []string {
+	return slices.Clone(k.scopes)
+}
+
+func (k *APIKey) CreatedAt() time.Time {
+	return k.createdAt
+}
+
+func (k *APIKey) ExpiresAt() *time.Time {
+	return k.expiresAt
+}
+
+func (k *APIKey) LastUsedAt() *time.Time {
+	return k.lastUsedAt
+}
+
+func (k *APIKey) RevokedAt() *time.Time {
+	return k.revokedAt
+}
+
+// IsActive reports whether the key is neither revoked nor expired at now.
+func (k *APIKey) IsActive(now time.Time) bool {
+	if k.revokedAt != nil {
+		return false
+	}
+	return k.expiresAt == nil || now.Before(*k.expiresAt)
+}
+
+func (k *APIKey) Touch(at time.Time) {
+	k.lastUsedAt = &at
+}
+
+// Revoke disables the key. Revoking a revoked key keeps the first revocation time.
+func (k *APIKey) Revoke(at time.Time) {
+	if k.revokedAt == nil {
+		k.revokedAt = &at
+	}
+}
+
+func NewAPIKey(
+	tenantID string,
+	name string,
+	hash string,
+	prefix string,
+	scopes []string,
+	createdAt time.Time,
+	expiresAt *time.Time,
+) (*APIKey, error) {
+	return NewAPIKeyWithID(uuid.New(), tenantID, name, hash, prefix, scopes, createdAt, expiresAt)
+}
+
+func NewAPIKeyWithID(
+	id uuid.UUID,
+	tenantID string,
+	name string,
+	hash string,
+	prefix string,
+	scopes []string,
+	createdAt time.Time,
+	expiresAt *time.Time,
+) (*APIKey, error) {
+	if err := validateScopes(scopes); err != nil {
+		return nil, err
+	}
+	if expiresAt != nil && !expiresAt.After(createdAt) {
+		return nil, domain.ErrInvalidExpiry
+	}
+
+	return &APIKey{
+		id:        id,
+		tenantID:  tenantID,
+		name:      name,
+		hash:      hash,
+		prefix:    prefix,
+		scopes:    slices.Clone(scopes),
+		createdAt: createdAt,
+		expiresAt: expiresAt,
+	}, nil
+}
+
+func validateScopes(scopes []string) error {
+	if len(scopes) == 0 {
+		return domain.ErrInvalidScope
+	}
+	for _, scope := range scopes {
+		if !IsValidScope(scope) {
+			return domain.ErrInvalidScope
+		}
+	}
+	return nil
+}
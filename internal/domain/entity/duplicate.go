@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/MDx3R/ef-test/internal/domain"
+)
+
+// NormalizeServiceName folds service names that differ only in case,
+// spacing or punctuation, such as "Yandex Plus" and "yandex-plus".
+func NormalizeServiceName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Overlaps reports whether the periods of s and other share a month.
+// Subscriptions without end date last indefinitely.
+func (s *Subscription) Overlaps(other *Subscription) bool {
+	startsBeforeOtherEnds := other.endDate == nil || !s.startDate.After(*other.endDate)
+	otherStartsBeforeEnd := s.endDate == nil || !other.startDate.After(*s.endDate)
+	return startsBeforeOtherEnds && otherStartsBeforeEnd
+}
+
+// IsDuplicateOf reports whether s and other are likely the same subscription
+// entered twice: one user, one service and overlapping periods.
+func (s *Subscription) IsDuplicateOf(other *Subscription) bool {
+	return s.id != other.id &&
+		s.userID == other.userID &&
+		NormalizeServiceName(s.serviceName) == NormalizeServiceName(other.serviceName) &&
+		s.Overlaps(other)
+}
+
+// Absorb merges other into s: the period grows to cover both and the notes
+// of other are appended. Name and price of s are kept. Only duplicates can be
+// merged, since merging periods apart would charge for the months between them.
+// It returns ErrNotesTooLong, leaving s unchanged, if the notes don't fit.
+func (s *Subscription) Absorb(other *Subscription) error {
+	if !s.IsDuplicateOf(other) {
+		return domain.ErrInvalidMerge
+	}
+
+	notes := s.notes
+	if other.notes != "" && !strings.Contains(notes, other.notes) {
+		if notes != "" {
+			notes += "\n"
+		}
+		notes += other.notes
+	}
+	if utf8.RuneCountInString(notes) > MaxNotesLength {
+		return domain.ErrNotesTooLong
+	}
+	s.notes = notes
+
+	if other.startDate.Before(s.startDate) {
+		s.startDate = other.startDate
+	}
+	if s.endDate != nil && (other.endDate == nil || other.endDate.After(*s.endDate)) {
+		s.endDate = other.endDate
+	}
+	return nil
+}
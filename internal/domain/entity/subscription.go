@@ -7,6 +7,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// MaxNotesLength is how many characters the notes of a subscription may have.
+const MaxNotesLength = 1000
+
 type Subscription struct {
 	id          uuid.UUID
 	serviceName string
@@ -14,6 +17,7 @@ type Subscription struct {
 	userID      uuid.UUID
 	startDate   time.Time
 	endDate     *time.Time
+	notes       string
 }
 
 func (s *Subscription) ID() uuid.UUID {
@@ -40,6 +44,10 @@ func (s *Subscription) EndDate() *time.Time {
 	return s.endDate
 }
 
+func (s *Subscription) Notes() string {
+	return s.notes
+}
+
 func (s *Subscription) SetServiceName(serviceName string) {
 	s.serviceName = serviceName
 }
@@ -48,6 +56,10 @@ func (s *Subscription) SetPrice(price int) {
 	s.price = price
 }
 
+func (s *Subscription) SetNotes(notes string) {
+	s.notes = notes
+}
+
 func (s *Subscription) SetStartDate(startDate time.Time) error {
 	if err := validateTime(startDate, s.endDate); err != nil {
 		return err
@@ -5,4 +5,8 @@ import "fmt"
 var (
 	ErrInvariant     = fmt.Errorf("invariant violation")
 	ErrInvalidPeriod = fmt.Errorf("%w: invalid period", ErrInvariant)
+	ErrInvalidScope  = fmt.Errorf("%w: invalid scope", ErrInvariant)
+	ErrInvalidExpiry = fmt.Errorf("%w: expiry must be after creation", ErrInvariant)
+	ErrInvalidMerge  = fmt.Errorf("%w: only distinct overlapping subscriptions of one user and service can be merged", ErrInvariant)
+	ErrNotesTooLong  = fmt.Errorf("%w: notes too long", ErrInvariant)
 )
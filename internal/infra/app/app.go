@@ -2,59 +2,359 @@ package app
 
 import (
 	"context"
+	"net/http"
+	"slices"
 	"time"
 
 	"github.com/MDx3R/ef-test/internal/config"
+	"github.com/MDx3R/ef-test/internal/infra/cache"
 	"github.com/MDx3R/ef-test/internal/infra/database/gorm"
+	"github.com/MDx3R/ef-test/internal/infra/database/memory"
+	"github.com/MDx3R/ef-test/internal/infra/database/migrate"
+	"github.com/MDx3R/ef-test/internal/infra/metrics"
+	"github.com/MDx3R/ef-test/internal/infra/ratelimit"
 	ginserver "github.com/MDx3R/ef-test/internal/infra/server/gin"
 	ginware "github.com/MDx3R/ef-test/internal/infra/server/gin/middleware"
+	"github.com/MDx3R/ef-test/internal/infra/tracing"
 	handlers "github.com/MDx3R/ef-test/internal/transport/http/gin"
+	handlersv1 "github.com/MDx3R/ef-test/internal/transport/http/gin/v1"
+	handlersv2 "github.com/MDx3R/ef-test/internal/transport/http/gin/v2"
+	"github.com/MDx3R/ef-test/internal/transport/http/problem"
 	"github.com/MDx3R/ef-test/internal/usecase"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	otelgorm "gorm.io/plugin/opentelemetry/tracing"
 
 	"github.com/sirupsen/logrus"
 )
 
 type App struct {
-	Config   *config.Config
-	Server   *ginserver.GinServer
+	Config *config.Config
+	Server *ginserver.GinServer
+	// Database is nil when the in-memory storage is used.
 	Database *gorm.GormDatabase
-	Logger   *logrus.Logger
+	// Redis is nil unless a feature is configured to use it.
+	Redis  *redis.Client
+	Tracer *sdktrace.TracerProvider
+	Logger *logrus.Logger
+
+	idempotency usecase.IdempotencyService
+	health      usecase.HealthService
+	events      usecase.SubscriptionEventService
+	// background is cancelled on shutdown to stop periodic jobs.
+	background context.Context
+	stop       context.CancelFunc
+}
+
+type repositories struct {
+	subscriptions usecase.SubscriptionRepository
+	apiKeys       usecase.APIKeyRepository
+	idempotency   usecase.IdempotencyRepository
 }
 
 func NewApp(cfg *config.Config, logger *logrus.Logger) *App {
-	logger.Info("establishing database connection")
+	usecase.SetLogger(logger)
+	tracer := newTracerProvider(&cfg.Tracing, logger)
+	gormDB, repos := newRepositories(&cfg.Database, logger)
+	m := newMetrics(&cfg.Metrics, gormDB, logger)
+	redisClient := newSharedRedisClient(cfg, logger)
 
-	gormDB, err := gorm.NewGormDatabase(&cfg.Database)
-	if err != nil {
-		logger.Fatalf("failed to create database: %v", err)
+	subService := usecase.NewSubscriptionService(repos.subscriptions)
+	if cfg.Subscriptions.RejectDuplicates {
+		subService = usecase.NewDuplicateGuardSubscriptionService(subService, repos.subscriptions)
 	}
+	// Events are kept in memory, so every instance only streams the changes made through it.
+	eventLog := memory.NewMemorySubscriptionEventLog(cfg.Subscriptions.Events.LogSize)
+	subService = usecase.NewPublishingSubscriptionService(subService, repos.subscriptions, eventLog)
+	eventService := usecase.NewSubscriptionEventService(eventLog)
+	if cfg.Cache.Enabled {
+		var recorder usecase.CacheRecorder
+		if m != nil {
+			recorder = m
+		}
+		subService = usecase.NewCachingSubscriptionService(subService, newCache(cfg, redisClient, logger), cfg.Cache.TTL, recorder)
+	}
+	if m != nil {
+		subService = usecase.NewInstrumentedSubscriptionService(subService, m)
+	}
+	// The policy is checked first, so callers can't probe subscriptions they may not see.
+	if cfg.Auth.Enabled {
+		policy := newPolicy(&cfg.Auth, logger)
+		subService = usecase.NewPolicySubscriptionService(subService, policy)
+		eventService = usecase.NewPolicySubscriptionEventService(eventService, policy)
+	}
+	keyService := usecase.NewAPIKeyService(repos.apiKeys)
+	idempotencyService := usecase.NewIdempotencyService(repos.idempotency, cfg.Idempotency.TTL, cfg.Idempotency.Lease)
+	healthService := newHealthService(cfg, gormDB, logger)
 
-	logger.Info("database connected")
-
-	subRepository := gorm.NewGormSubscriptionRepository(gormDB.GetDB())
-
-	subService := usecase.NewSubscriptionService(subRepository)
-
-	subHandler := handlers.NewSubscriptionHandler(subService, logger)
+	subHandlerV1 := handlersv1.NewSubscriptionHandler(subService, logger)
+	subHandlerV2 := handlersv2.NewSubscriptionHandler(subService, logger)
+	eventHandlerV1 := handlersv1.NewSubscriptionEventHandler(eventService, cfg.Subscriptions.Events.Heartbeat, logger)
+	eventHandlerV2 := handlersv2.NewSubscriptionEventHandler(eventService, cfg.Subscriptions.Events.Heartbeat, logger)
+	keyHandler := handlers.NewAPIKeyHandler(keyService, logger)
+	healthHandler := handlers.NewHealthHandler(healthService, logger)
 
 	logger.Info("initializing http server")
 
 	ginserver.SetMode(cfg)
-	server := ginserver.New(&cfg.Server)
+	server, err := ginserver.New(&cfg.Server)
+	if err != nil {
+		logger.Fatalf("failed to create http server: %v", err)
+	}
 
 	server.UseMiddleware(
 		ginware.NewCORSMiddleware(&cfg.Server.CORS),
-		gin.Recovery(),
+		// Scrapes and probes come every few seconds and would drown the traces.
+		otelgin.Middleware(cfg.Tracing.ServiceName, otelgin.WithFilter(func(r *http.Request) bool {
+			switch r.URL.Path {
+			case ginserver.LivenessPath, ginserver.ReadinessPath:
+				return false
+			}
+			return !cfg.Metrics.Enabled || r.URL.Path != cfg.Metrics.Path
+		})),
+	)
+	if m != nil {
+		// Registered before Recovery, so that panics are counted as 500 responses.
+		server.UseMiddleware(ginware.NewMetricsMiddleware(m))
+	}
+	server.UseMiddleware(
+		gin.CustomRecovery(func(c *gin.Context, _ any) {
+			problem.Respond(c, problem.CodeInternal, "")
+		}),
+		ginware.NewRequestIDMiddleware(logger),
 		ginware.LoggerMiddleware(logger),
+		newTranslator(logger).Localize,
 	)
+	if cfg.Env == "local" {
+		server.UseMiddleware(problem.ExposeInternal)
+	}
 
 	server.RegisterSwagger()
-	server.RegisterSubscriptionHandler(subHandler)
+	server.RegisterHealthHandler(healthHandler)
+	if m != nil {
+		server.RegisterMetrics(cfg.Metrics.Path, m.Handler())
+	}
+	auth := newAuthMiddleware(&cfg.Auth, keyService, logger)
+	// The tenant is resolved after authentication, since credentials may name it.
+	// Middleware lists are extended into new slices, so that route groups
+	// never share a backing array another group could overwrite.
+	scoped := slices.Concat(auth, []gin.HandlerFunc{ginware.NewTenantMiddleware(&cfg.Tenancy)})
+
+	if cfg.RateLimit.Enabled {
+		store := newRateLimitStore(cfg, redisClient, logger)
+		// Clients are limited once they are known, so after authentication and tenancy.
+		scoped = slices.Concat(scoped, []gin.HandlerFunc{ginware.NewRateLimitMiddleware(&cfg.RateLimit, &cfg.Tenancy, store, logger)})
+	}
+
+	idempotent := slices.Concat(scoped, []gin.HandlerFunc{ginware.NewIdempotencyMiddleware(&cfg.Idempotency, idempotencyService, logger)})
+	server.RegisterSubscriptionHandler(subHandlerV1, subHandlerV2, idempotent...)
+	server.RegisterSubscriptionEventHandler(eventHandlerV1, eventHandlerV2, scoped...)
+	// Without authentication nobody could be an admin, so keys can't be managed.
+	// Issued keys are shown once, so their responses are never stored for replays.
+	if auth != nil {
+		server.RegisterAPIKeyHandler(keyHandler, scoped...)
+	}
 
 	logger.Info("http server initialized")
 
-	return &App{Config: cfg, Server: server, Database: gormDB, Logger: logger}
+	background, stop := context.WithCancel(context.Background())
+
+	return &App{
+		Config:      cfg,
+		Server:      server,
+		Database:    gormDB,
+		Redis:       redisClient,
+		Tracer:      tracer,
+		Logger:      logger,
+		idempotency: idempotencyService,
+		health:      healthService,
+		events:      eventService,
+		background:  background,
+		stop:        stop,
+	}
+}
+
+// newAuthMiddleware accepts either an API key or a bearer JWT.
+func newAuthMiddleware(cfg *config.AuthConfig, keyService usecase.APIKeyService, logger *logrus.Logger) []gin.HandlerFunc {
+	if !cfg.Enabled {
+		logger.Warn("authentication is disabled, the api is open to everyone")
+		return nil
+	}
+
+	auth, err := ginware.NewJWTMiddleware(cfg)
+	if err != nil {
+		logger.Fatalf("failed to create auth middleware: %v", err)
+	}
+	return []gin.HandlerFunc{ginware.NewAPIKeyMiddleware(keyService), auth}
+}
+
+func newPolicy(cfg *config.AuthConfig, logger *logrus.Logger) *usecase.Policy {
+	policyCfg, err := config.GetPolicyFromPath(cfg.PolicyFile)
+	if err != nil {
+		logger.Fatalf("failed to load access policy: %v", err)
+	}
+
+	roles := make(map[string][]usecase.Permission, len(policyCfg.Roles))
+	for role, permissions := range policyCfg.Roles {
+		for _, permission := range permissions {
+			roles[role] = append(roles[role], usecase.Permission{
+				Action:   usecase.Action(permission.Action),
+				Resource: usecase.Resource(permission.Resource),
+			})
+		}
+	}
+
+	policy, err := usecase.NewPolicy(policyCfg.DefaultRole, roles)
+	if err != nil {
+		logger.Fatalf("invalid access policy %s: %v", cfg.PolicyFile, err)
+	}
+	return policy
+}
+
+// newTranslator translates the validation errors of request binding.
+func newTranslator(logger *logrus.Logger) *problem.Translator {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		logger.Fatal("request binding does not use go-playground validator")
+	}
+	translator, err := problem.NewTranslator(v)
+	if err != nil {
+		logger.Fatalf("failed to load error messages: %v", err)
+	}
+	return translator
+}
+
+// newTracerProvider also adds the trace IDs to the log entries with a traced context.
+func newTracerProvider(cfg *config.TracingConfig, logger *logrus.Logger) *sdktrace.TracerProvider {
+	provider, err := tracing.NewTracerProvider(context.Background(), cfg)
+	if err != nil {
+		logger.Fatalf("failed to set up tracing: %v", err)
+	}
+	logger.AddHook(tracing.NewLogrusHook())
+	return provider
+}
+
+// newMetrics returns nil when metrics are disabled.
+func newMetrics(cfg *config.MetricsConfig, gormDB *gorm.GormDatabase, logger *logrus.Logger) *metrics.Metrics {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	m := metrics.NewMetrics()
+	if gormDB == nil {
+		return m
+	}
+
+	db := gormDB.GetDB()
+	if err := db.Use(metrics.NewGormPlugin(m)); err != nil {
+		logger.Fatalf("failed to register database metrics: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		logger.Fatalf("failed to obtain database: %v", err)
+	}
+	m.RegisterDBStats(sqlDB, gormDB.Name())
+	return m
+}
+
+// newHealthService checks the database and its schema, if the service uses one.
+func newHealthService(cfg *config.Config, gormDB *gorm.GormDatabase, logger *logrus.Logger) usecase.HealthService {
+	if gormDB == nil {
+		return usecase.NewHealthService(cfg.Health.CheckTimeout, cfg.Env == "local")
+	}
+
+	latest, err := migrate.LatestVersion(&cfg.Database)
+	if err != nil {
+		logger.Fatalf("failed to find the latest migration: %v", err)
+	}
+	return usecase.NewHealthService(
+		cfg.Health.CheckTimeout,
+		cfg.Env == "local",
+		gorm.NewDatabaseHealthCheck(gormDB),
+		gorm.NewMigrationHealthCheck(gormDB, latest),
+	)
+}
+
+func newRateLimitStore(cfg *config.Config, client *redis.Client, logger *logrus.Logger) ratelimit.Store {
+	switch cfg.RateLimit.Backend {
+	case "memory":
+		return ratelimit.NewMemoryStore()
+	case "redis":
+		return ratelimit.NewRedisStore(client)
+	default:
+		logger.Fatalf("unknown rate limit backend: %s", cfg.RateLimit.Backend)
+		return nil
+	}
+}
+
+func newCache(cfg *config.Config, client *redis.Client, logger *logrus.Logger) usecase.Cache {
+	switch cfg.Cache.Backend {
+	case "memory":
+		return cache.NewLRUCache(cfg.Cache.Size)
+	case "redis":
+		return cache.NewRedisCache(client)
+	default:
+		logger.Fatalf("unknown cache backend: %s", cfg.Cache.Backend)
+		return nil
+	}
+}
+
+// newSharedRedisClient returns the client shared by the features using Redis,
+// or nil if none of them does.
+func newSharedRedisClient(cfg *config.Config, logger *logrus.Logger) *redis.Client {
+	rateLimit := cfg.RateLimit.Enabled && cfg.RateLimit.Backend == "redis"
+	responses := cfg.Cache.Enabled && cfg.Cache.Backend == "redis"
+	if !rateLimit && !responses {
+		return nil
+	}
+	return newRedisClient(&cfg.Redis, logger)
+}
+
+func newRedisClient(cfg *config.RedisConfig, logger *logrus.Logger) *redis.Client {
+	client := redis.NewClient(&redis.Options{Addr: cfg.Addr, Password: cfg.Password, DB: cfg.DB})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	// Users of Redis degrade gracefully, so an unavailable server doesn't prevent the start.
+	if err := client.Ping(ctx).Err(); err != nil {
+		logger.Warnf("redis is unavailable at %s: %v", cfg.Addr, err)
+	}
+	return client
+}
+
+func newRepositories(cfg *config.DatabaseConfig, logger *logrus.Logger) (*gorm.GormDatabase, repositories) {
+	if cfg.IsInMemory() {
+		logger.Warn("using in-memory storage, data will be lost on shutdown")
+		return nil, repositories{
+			subscriptions: memory.NewMemorySubscriptionRepository(),
+			apiKeys:       memory.NewMemoryAPIKeyRepository(),
+			idempotency:   memory.NewMemoryIdempotencyRepository(),
+		}
+	}
+
+	logger.Info("establishing database connection")
+
+	gormDB, err := gorm.NewGormDatabase(cfg)
+	if err != nil {
+		logger.Fatalf("failed to create database: %v", err)
+	}
+
+	logger.Info("database connected")
+
+	db := gormDB.GetDB()
+	// Queries become child spans of the request, as repositories pass the context on.
+	if err := db.Use(otelgorm.NewPlugin(otelgorm.WithoutMetrics(), otelgorm.WithoutQueryVariables())); err != nil {
+		logger.Fatalf("failed to register database tracing: %v", err)
+	}
+	return gormDB, repositories{
+		subscriptions: gorm.NewGormSubscriptionRepository(db),
+		apiKeys:       gorm.NewGormAPIKeyRepository(db),
+		idempotency:   gorm.NewGormIdempotencyRepository(db),
+	}
 }
 
 func (a *App) MustRun() {
@@ -64,6 +364,8 @@ func (a *App) MustRun() {
 }
 
 func (a *App) Run() error {
+	go a.purgeIdempotencyKeys()
+
 	a.Logger.Infof("starting server on port %s", a.Config.Server.Port)
 	if err := a.Server.Run(); err != nil {
 		a.Logger.Errorf("server failed to start: %v", err)
@@ -73,19 +375,68 @@ func (a *App) Run() error {
 }
 
 func (a *App) Shutdown() error {
+	a.stop()
+
+	// Requests keep being served until load balancers see the service isn't ready.
+	a.health.Drain()
+	a.Logger.Infof("reporting not ready, draining for %s...", a.Config.Health.ShutdownDelay)
+	time.Sleep(a.Config.Health.ShutdownDelay)
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	// Event streams never end on their own and would hold up the server shutdown.
+	a.Logger.Info("closing event streams...")
+	a.events.Close()
+
 	a.Logger.Info("shutting down server...")
 	if err := a.Server.Shutdown(ctx); err != nil {
 		a.Logger.Errorf("failed to shutdown server: %v", err)
 	}
 
-	a.Logger.Info("closing database connection...")
-	if err := a.Database.Dispose(); err != nil {
-		a.Logger.Errorf("failed to shutdown database: %v", err)
+	if a.Database != nil {
+		a.Logger.Info("closing database connection...")
+		if err := a.Database.Dispose(); err != nil {
+			a.Logger.Errorf("failed to shutdown database: %v", err)
+		}
+	}
+
+	a.Logger.Info("flushing traces...")
+	if err := a.Tracer.Shutdown(ctx); err != nil {
+		a.Logger.Errorf("failed to flush traces: %v", err)
+	}
+
+	if a.Redis != nil {
+		a.Logger.Info("closing redis connection...")
+		if err := a.Redis.Close(); err != nil {
+			a.Logger.Errorf("failed to close redis: %v", err)
+		}
 	}
 
 	a.Logger.Info("shutdown complete")
 	return nil
 }
+
+// purgeIdempotencyKeys deletes expired idempotency keys until shutdown.
+func (a *App) purgeIdempotencyKeys() {
+	if a.Config.Idempotency.PurgeInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(a.Config.Idempotency.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-a.background.Done():
+			return
+		case <-ticker.C:
+			deleted, err := a.idempotency.PurgeExpired(a.background)
+			if err != nil {
+				a.Logger.Errorf("failed to purge idempotency keys: %v", err)
+				continue
+			}
+			a.Logger.Debugf("purged %d expired idempotency keys", deleted)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package cache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/infra/cache"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// caches runs test against every backend, Redis being stood in by miniredis.
+func caches(t *testing.T, test func(t *testing.T, c usecase.Cache)) {
+	t.Run("memory", func(t *testing.T) {
+		test(t, cache.NewLRUCache(10))
+	})
+
+	t.Run("redis", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		t.Cleanup(func() { _ = client.Close() })
+
+		test(t, cache.NewRedisCache(client))
+	})
+}
+
+func TestCache_SetGet(t *testing.T) {
+	caches(t, func(t *testing.T, c usecase.Cache) {
+		require.NoError(t, c.Set(t.Context(), "key", []byte("value"), time.Minute))
+
+		value, ok, err := c.Get(t.Context(), "key")
+
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []byte("value"), value)
+	})
+}
+
+func TestCache_Get_Missing(t *testing.T) {
+	caches(t, func(t *testing.T, c usecase.Cache) {
+		_, ok, err := c.Get(t.Context(), "key")
+
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
+
+func TestCache_Set_Replaces(t *testing.T) {
+	caches(t, func(t *testing.T, c usecase.Cache) {
+		require.NoError(t, c.Set(t.Context(), "key", []byte("old"), time.Minute))
+		require.NoError(t, c.Set(t.Context(), "key", []byte("new"), time.Minute))
+
+		value, ok, err := c.Get(t.Context(), "key")
+
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, []byte("new"), value)
+	})
+}
+
+func TestLRUCache_Expires(t *testing.T) {
+	c := cache.NewLRUCache(10)
+	require.NoError(t, c.Set(t.Context(), "key", []byte("value"), 10*time.Millisecond))
+
+	time.Sleep(20 * time.Millisecond)
+	_, ok, err := c.Get(t.Context(), "key")
+
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestLRUCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := cache.NewLRUCache(2)
+	require.NoError(t, c.Set(t.Context(), "a", []byte("a"), time.Minute))
+	require.NoError(t, c.Set(t.Context(), "b", []byte("b"), time.Minute))
+
+	// a becomes the most recently used, so b is evicted by c.
+	_, ok, _ := c.Get(t.Context(), "a")
+	require.True(t, ok)
+	require.NoError(t, c.Set(t.Context(), "c", []byte("c"), time.Minute))
+
+	_, ok, _ = c.Get(t.Context(), "b")
+	assert.False(t, ok)
+	_, ok, _ = c.Get(t.Context(), "a")
+	assert.True(t, ok)
+	_, ok, _ = c.Get(t.Context(), "c")
+	assert.True(t, ok)
+}
+
+func TestRedisCache_Expires(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	c := cache.NewRedisCache(client)
+
+	require.NoError(t, c.Set(t.Context(), "key", []byte("value"), time.Minute))
+	assert.True(t, server.Exists("cache:key"))
+
+	server.FastForward(time.Minute)
+	_, ok, err := c.Get(t.Context(), "key")
+
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestRedisCache_Unavailable(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+	c := cache.NewRedisCache(client)
+	server.Close()
+
+	_, ok, err := c.Get(t.Context(), "key")
+
+	assert.Error(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/redis/go-redis/v9"
+)
+
+type redisCache struct {
+	client redis.Cmdable
+	prefix string
+}
+
+// NewRedisCache keeps the values in Redis, so that instances of the service
+// share them. Redis evicts values by its own policy once it is full.
+func NewRedisCache(client redis.Cmdable) usecase.Cache {
+	return &redisCache{client: client, prefix: "cache:"}
+}
+
+func (c *redisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("redis cache: %w", err)
+	}
+	return value, true, nil
+}
+
+func (c *redisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := c.client.Set(ctx, c.prefix+key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("redis cache: %w", err)
+	}
+	return nil
+}
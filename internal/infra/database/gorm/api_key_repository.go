@@ -0,0 +1,111 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	gormmodel "github.com/MDx3R/ef-test/internal/infra/database/gorm/model"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type gormAPIKeyRepository struct {
+	tx *gorm.DB
+}
+
+func NewGormAPIKeyRepository(db *gorm.DB) usecase.APIKeyRepository {
+	return &gormAPIKeyRepository{db}
+}
+
+func (r *gormAPIKeyRepository) Get(ctx context.Context, id uuid.UUID) (*entity.APIKey, error) {
+	return r.first(r.scoped(ctx), "id = ?", id)
+}
+
+func (r *gormAPIKeyRepository) GetByHash(ctx context.Context, hash string) (*entity.APIKey, error) {
+	return r.first(r.tx.WithContext(ctx), "key_hash = ?", hash)
+}
+
+func (r *gormAPIKeyRepository) List(ctx context.Context) ([]*entity.APIKey, error) {
+	var models []gormmodel.APIKeyModel
+
+	err := r.scoped(ctx).Order("created_at DESC").Order("id").Find(&models).Error
+	if err != nil {
+		return nil, wrap(usecase.ErrRepository, err)
+	}
+
+	result := make([]*entity.APIKey, len(models))
+	for i, model := range models {
+		key, err := model.ToEntity()
+		if err != nil {
+			return nil, wrap(usecase.ErrRepository, err)
+		}
+		result[i] = key
+	}
+	return result, nil
+}
+
+func (r *gormAPIKeyRepository) Add(ctx context.Context, key *entity.APIKey) error {
+	model := gormmodel.FromAPIKey(key)
+
+	err := r.tx.WithContext(ctx).Create(&model).Error
+	if err != nil {
+		return wrap(usecase.ErrRepository, err)
+	}
+	return nil
+}
+
+// Update keeps a key in the tenant it was issued for. Authentication updates
+// keys before the tenant of the request is known, so ctx is not consulted.
+func (r *gormAPIKeyRepository) Update(ctx context.Context, key *entity.APIKey) error {
+	model := gormmodel.FromAPIKey(key)
+
+	exists, err := updateRow(r.tx.WithContext(ctx).
+		Model(&gormmodel.APIKeyModel{}).
+		Where("id = ? AND tenant_id = ?", model.ID, model.TenantID), &model)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return usecase.ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormAPIKeyRepository) TouchLastUsed(ctx context.Context, tenantID string, id uuid.UUID, at time.Time) error {
+	result := r.tx.WithContext(ctx).
+		Model(&gormmodel.APIKeyModel{}).
+		Where("id = ? AND tenant_id = ? AND revoked_at IS NULL", id, tenantID).
+		Update("last_used_at", at)
+	if result.Error != nil {
+		return wrap(usecase.ErrRepository, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return usecase.ErrNotFound
+	}
+	return nil
+}
+
+// scoped starts a statement limited to keys of the tenant in ctx.
+func (r *gormAPIKeyRepository) scoped(ctx context.Context) *gorm.DB {
+	return r.tx.WithContext(ctx).Where("tenant_id = ?", usecase.TenantFrom(ctx).ID)
+}
+
+func (r *gormAPIKeyRepository) first(stmt *gorm.DB, query string, arg any) (*entity.APIKey, error) {
+	var model gormmodel.APIKeyModel
+
+	err := stmt.First(&model, query, arg).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, usecase.ErrNotFound
+		}
+		return nil, wrap(usecase.ErrRepository, err)
+	}
+
+	key, err := model.ToEntity()
+	if err != nil {
+		return nil, wrap(usecase.ErrRepository, err)
+	}
+	return key, nil
+}
@@ -1,12 +1,18 @@
 package gorm
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/MDx3R/ef-test/internal/config"
 	gormmodel "github.com/MDx3R/ef-test/internal/infra/database/gorm/model"
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+
+	// Pure Go SQLite driver, registered as "sqlite". It does not require cgo.
+	_ "modernc.org/sqlite"
 )
 
 type GormDatabase struct {
@@ -34,22 +40,69 @@ func (d *GormDatabase) Dispose() error {
 }
 
 func (d *GormDatabase) Migrate() error {
-	err := d.db.AutoMigrate(&gormmodel.SubscriptionModel{})
+	err := d.db.AutoMigrate(&gormmodel.SubscriptionModel{}, &gormmodel.APIKeyModel{}, &gormmodel.IdempotencyModel{}, &gormmodel.SubscriptionMergeModel{})
 	if err != nil {
 		return fmt.Errorf("failed to migrate DB: %w", err)
 	}
 	return nil
 }
 
+// Ping checks that the database accepts connections.
+func (d *GormDatabase) Ping(ctx context.Context) error {
+	sqlDB, err := d.db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to obtain DB: %w", err)
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping DB: %w", err)
+	}
+	return nil
+}
+
+// Name is the name of the database the connection uses.
+func (d *GormDatabase) Name() string {
+	return d.cfg.Database
+}
+
 func (d *GormDatabase) GetDB() *gorm.DB {
 	return d.db
 }
 
 func createEngine(cfg *config.DatabaseConfig) (*gorm.DB, error) {
-	dsn := cfg.GetDSN()
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	dialector, err := createDialector(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to DB: %w", err)
 	}
+
+	if cfg.GetDialect() == "sqlite" {
+		// SQLite allows a single writer, and every connection to ":memory:"
+		// opens a separate database, so the pool is limited to one connection.
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, fmt.Errorf("failed to obtain DB: %w", err)
+		}
+		sqlDB.SetMaxOpenConns(1)
+	}
+
 	return db, nil
 }
+
+func createDialector(cfg *config.DatabaseConfig) (gorm.Dialector, error) {
+	dsn := cfg.GetDSN()
+
+	switch cfg.GetDialect() {
+	case "postgres":
+		return postgres.Open(dsn), nil
+	case "mysql":
+		return mysql.Open(dsn), nil
+	case "sqlite":
+		return sqlite.New(sqlite.Config{DriverName: "sqlite", DSN: dsn}), nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %q", cfg.Driver)
+	}
+}
@@ -0,0 +1,69 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/MDx3R/ef-test/internal/usecase"
+)
+
+// migrationsTable is where golang-migrate records the schema version.
+const migrationsTable = "schema_migrations"
+
+type databaseHealthCheck struct {
+	db *GormDatabase
+}
+
+// NewDatabaseHealthCheck checks that the database accepts connections.
+func NewDatabaseHealthCheck(db *GormDatabase) usecase.HealthCheck {
+	return &databaseHealthCheck{db}
+}
+
+func (c *databaseHealthCheck) Name() string {
+	return "database"
+}
+
+func (c *databaseHealthCheck) Check(ctx context.Context) error {
+	return c.db.Ping(ctx)
+}
+
+type migrationHealthCheck struct {
+	db     *GormDatabase
+	latest uint
+}
+
+// NewMigrationHealthCheck checks that the schema is migrated at least to the latest
+// version and that no migration has failed halfway. A schema ahead of the binary
+// passes, so that instances still running during a rolling deploy stay ready.
+func NewMigrationHealthCheck(db *GormDatabase, latest uint) usecase.HealthCheck {
+	return &migrationHealthCheck{db: db, latest: latest}
+}
+
+func (c *migrationHealthCheck) Name() string {
+	return "migrations"
+}
+
+func (c *migrationHealthCheck) Check(ctx context.Context) error {
+	db := c.db.GetDB().WithContext(ctx)
+	if !db.Migrator().HasTable(migrationsTable) {
+		return errors.New("no migrations applied")
+	}
+
+	var state struct {
+		Version uint
+		Dirty   bool
+	}
+	result := db.Table(migrationsTable).Select("version", "dirty").Limit(1).Scan(&state)
+	switch {
+	case result.Error != nil:
+		return fmt.Errorf("failed to read schema version: %w", result.Error)
+	case result.RowsAffected == 0:
+		return errors.New("no migrations applied")
+	case state.Dirty:
+		return fmt.Errorf("migration %d failed, schema is dirty", state.Version)
+	case state.Version < c.latest:
+		return fmt.Errorf("schema version is %d, want %d", state.Version, c.latest)
+	}
+	return nil
+}
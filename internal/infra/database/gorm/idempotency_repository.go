@@ -0,0 +1,89 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	gormmodel "github.com/MDx3R/ef-test/internal/infra/database/gorm/model"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type gormIdempotencyRepository struct {
+	tx *gorm.DB
+}
+
+func NewGormIdempotencyRepository(db *gorm.DB) usecase.IdempotencyRepository {
+	return &gormIdempotencyRepository{db}
+}
+
+func (r *gormIdempotencyRepository) Reserve(ctx context.Context, record dto.IdempotencyRecord) (dto.IdempotencyRecord, bool, error) {
+	model := gormmodel.FromIdempotencyRecord(usecase.TenantFrom(ctx).ID, record)
+
+	// An expired record frees its key.
+	err := r.byKey(ctx, record.Owner, record.Key).
+		Where("expires_at <= ?", record.CreatedAt).
+		Delete(&gormmodel.IdempotencyModel{}).Error
+	if err != nil {
+		return dto.IdempotencyRecord{}, false, wrap(usecase.ErrRepository, err)
+	}
+
+	result := r.tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&model)
+	if result.Error != nil {
+		return dto.IdempotencyRecord{}, false, wrap(usecase.ErrRepository, result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return record, true, nil
+	}
+
+	var existing gormmodel.IdempotencyModel
+	if err := r.byKey(ctx, record.Owner, record.Key).First(&existing).Error; err != nil {
+		return dto.IdempotencyRecord{}, false, wrap(usecase.ErrRepository, err)
+	}
+	return existing.ToRecord(), false, nil
+}
+
+func (r *gormIdempotencyRepository) Complete(ctx context.Context, owner, key string, response dto.IdempotentResponse, expiresAt time.Time) error {
+	result := r.byKey(ctx, owner, key).
+		Where("status_code = 0").
+		Updates(map[string]any{
+			"status_code":  response.StatusCode,
+			"content_type": response.ContentType,
+			"body":         response.Body,
+			"expires_at":   expiresAt,
+		})
+	if result.Error != nil {
+		return wrap(usecase.ErrRepository, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return usecase.ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormIdempotencyRepository) Release(ctx context.Context, owner, key string) error {
+	err := r.byKey(ctx, owner, key).
+		Where("status_code = 0").
+		Delete(&gormmodel.IdempotencyModel{}).Error
+	if err != nil {
+		return wrap(usecase.ErrRepository, err)
+	}
+	return nil
+}
+
+func (r *gormIdempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := r.tx.WithContext(ctx).Where("expires_at <= ?", now).Delete(&gormmodel.IdempotencyModel{})
+	if result.Error != nil {
+		return 0, wrap(usecase.ErrRepository, result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// byKey starts a statement limited to the record of owner and key in the tenant of ctx.
+func (r *gormIdempotencyRepository) byKey(ctx context.Context, owner, key string) *gorm.DB {
+	return r.tx.WithContext(ctx).
+		Model(&gormmodel.IdempotencyModel{}).
+		Where("tenant_id = ? AND owner = ? AND idempotency_key = ?", usecase.TenantFrom(ctx).ID, owner, key)
+}
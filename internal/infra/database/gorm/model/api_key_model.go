@@ -0,0 +1,74 @@
+package gormmodel
+
+import (
+	"strings"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+type APIKeyModel struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TenantID string    `gorm:"size:64;not null;index"`
+	Name     string    `gorm:"not null"`
+	Hash     string    `gorm:"column:key_hash;uniqueIndex;size:64;not null"`
+	Prefix   string    `gorm:"not null"`
+	// Scopes are stored space-separated, like the OAuth scope parameter.
+	Scopes     string `gorm:"not null"`
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+func FromAPIKey(key *entity.APIKey) APIKeyModel {
+	return APIKeyModel{
+		ID:         key.ID(),
+		TenantID:   key.TenantID(),
+		Name:       key.Name(),
+		Hash:       key.Hash(),
+		Prefix:     key.Prefix(),
+		Scopes:     strings.Join(key.Scopes(), " "),
+		CreatedAt:  key.CreatedAt(),
+		ExpiresAt:  key.ExpiresAt(),
+		LastUsedAt: key.LastUsedAt(),
+		RevokedAt:  key.RevokedAt(),
+	}
+}
+
+func (m *APIKeyModel) ToEntity() (*entity.APIKey, error) {
+	key, err := entity.NewAPIKeyWithID(
+		m.ID,
+		m.TenantID,
+		m.Name,
+		m.Hash,
+		m.Prefix,
+		strings.Fields(m.Scopes),
+		m.CreatedAt.UTC(),
+		utcPtr(m.ExpiresAt),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if m.LastUsedAt != nil {
+		key.Touch(m.LastUsedAt.UTC())
+	}
+	if m.RevokedAt != nil {
+		key.Revoke(m.RevokedAt.UTC())
+	}
+
+	return key, nil
+}
+
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	u := t.UTC()
+	return &u
+}
+
+func (APIKeyModel) TableName() string {
+	return "api_keys"
+}
@@ -0,0 +1,59 @@
+package gormmodel
+
+import (
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+)
+
+type IdempotencyModel struct {
+	TenantID    string `gorm:"primaryKey;size:64"`
+	Owner       string `gorm:"primaryKey;size:255"`
+	Key         string `gorm:"column:idempotency_key;primaryKey;size:255"`
+	RequestHash string `gorm:"size:64;not null"`
+	// StatusCode is zero while the request is being handled.
+	StatusCode  int    `gorm:"not null;default:0"`
+	ContentType string `gorm:"size:255;not null;default:''"`
+	Body        []byte
+	CreatedAt   time.Time `gorm:"not null"`
+	ExpiresAt   time.Time `gorm:"not null;index"`
+}
+
+func FromIdempotencyRecord(tenantID string, record dto.IdempotencyRecord) IdempotencyModel {
+	model := IdempotencyModel{
+		TenantID:    tenantID,
+		Owner:       record.Owner,
+		Key:         record.Key,
+		RequestHash: record.RequestHash,
+		CreatedAt:   record.CreatedAt,
+		ExpiresAt:   record.ExpiresAt,
+	}
+	if record.Response != nil {
+		model.StatusCode = record.Response.StatusCode
+		model.ContentType = record.Response.ContentType
+		model.Body = record.Response.Body
+	}
+	return model
+}
+
+func (m *IdempotencyModel) ToRecord() dto.IdempotencyRecord {
+	record := dto.IdempotencyRecord{
+		Owner:       m.Owner,
+		Key:         m.Key,
+		RequestHash: m.RequestHash,
+		CreatedAt:   m.CreatedAt.UTC(),
+		ExpiresAt:   m.ExpiresAt.UTC(),
+	}
+	if m.StatusCode != 0 {
+		record.Response = &dto.IdempotentResponse{
+			StatusCode:  m.StatusCode,
+			ContentType: m.ContentType,
+			Body:        m.Body,
+		}
+	}
+	return record
+}
+
+func (IdempotencyModel) TableName() string {
+	return "idempotency_keys"
+}
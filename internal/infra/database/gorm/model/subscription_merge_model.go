@@ -0,0 +1,46 @@
+package gormmodel
+
+import (
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// SubscriptionMergeModel records a subscription removed by a merge,
+// keeping a snapshot of its values.
+type SubscriptionMergeModel struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	TenantID    string     `gorm:"size:64;not null;index:idx_subscription_merges_tenant_target,priority:1"`
+	TargetID    uuid.UUID  `gorm:"type:uuid;not null;index:idx_subscription_merges_tenant_target,priority:2"`
+	SourceID    uuid.UUID  `gorm:"type:uuid;not null"`
+	ServiceName string     `gorm:"not null"`
+	Price       int        `gorm:"not null"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null"`
+	StartDate   time.Time  `gorm:"type:date;not null"`
+	EndDate     *time.Time `gorm:"type:date"`
+	Notes       string     `gorm:"not null"`
+	MergedBy    string     `gorm:"size:255;not null"`
+	MergedAt    time.Time  `gorm:"not null"`
+}
+
+func NewSubscriptionMergeModel(tenantID string, targetID uuid.UUID, source *entity.Subscription, mergedBy string, mergedAt time.Time) SubscriptionMergeModel {
+	return SubscriptionMergeModel{
+		ID:          uuid.New(),
+		TenantID:    tenantID,
+		TargetID:    targetID,
+		SourceID:    source.ID(),
+		ServiceName: source.ServiceName(),
+		Price:       source.Price(),
+		UserID:      source.UserID(),
+		StartDate:   ToDate(source.StartDate()),
+		EndDate:     ToDatePtr(source.EndDate()),
+		Notes:       source.Notes(),
+		MergedBy:    mergedBy,
+		MergedAt:    mergedAt,
+	}
+}
+
+func (SubscriptionMergeModel) TableName() string {
+	return "subscription_merges"
+}
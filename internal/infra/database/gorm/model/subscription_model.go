@@ -1,6 +1,7 @@
 package gormmodel
 
 import (
+	"strings"
 	"time"
 
 	"github.com/MDx3R/ef-test/internal/domain/entity"
@@ -8,22 +9,30 @@ import (
 )
 
 type SubscriptionModel struct {
-	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ID uuid.UUID `gorm:"type:uuid;primaryKey;index:idx_subscriptions_tenant_start,priority:3"`
+	// TenantID is set by the repository from the request context.
+	TenantID    string `gorm:"size:64;not null;index:idx_subscriptions_tenant_user,priority:1;index:idx_subscriptions_tenant_start,priority:1"`
 	ServiceName string
-	Price       int
-	UserID      uuid.UUID  `gorm:"type:uuid"`
-	StartDate   time.Time  `gorm:"type:date"`
-	EndDate     *time.Time `gorm:"type:date"`
+	// ServiceNameLower is lower-cased in Go, since LOWER of some dialects
+	// folds only ASCII.
+	ServiceNameLower string `gorm:"not null"`
+	Price            int
+	UserID           uuid.UUID  `gorm:"type:uuid;index:idx_subscriptions_tenant_user,priority:2"`
+	StartDate        time.Time  `gorm:"type:date;index:idx_subscriptions_tenant_start,priority:2"`
+	EndDate          *time.Time `gorm:"type:date"`
+	Notes            string     `gorm:"not null"`
 }
 
 func FromEntity(entity *entity.Subscription) SubscriptionModel {
 	return SubscriptionModel{
-		ID:          entity.ID(),
-		ServiceName: entity.ServiceName(),
-		Price:       entity.Price(),
-		UserID:      entity.UserID(),
-		StartDate:   entity.StartDate(),
-		EndDate:     entity.EndDate(),
+		ID:               entity.ID(),
+		ServiceName:      entity.ServiceName(),
+		ServiceNameLower: strings.ToLower(entity.ServiceName()),
+		Price:            entity.Price(),
+		UserID:           entity.UserID(),
+		StartDate:        ToDate(entity.StartDate()),
+		EndDate:          ToDatePtr(entity.EndDate()),
+		Notes:            entity.Notes(),
 	}
 }
 
@@ -39,10 +48,26 @@ func (m *SubscriptionModel) ToEntity() (*entity.Subscription, error) {
 	if err != nil {
 		return nil, err
 	}
+	sub.SetNotes(m.Notes)
 
 	return sub, nil
 }
 
+// ToDate truncates t to midnight UTC of its calendar date.
+// Dialects without a native DATE type compare dates as text,
+// so both stored values and query arguments must be normalised the same way.
+func ToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func ToDatePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	d := ToDate(*t)
+	return &d
+}
+
 func (SubscriptionModel) TableName() string {
 	return "subscriptions"
 }
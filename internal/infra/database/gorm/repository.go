@@ -1,7 +1,9 @@
 package gorm
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/MDx3R/ef-test/internal/domain/entity"
 	gormmodel "github.com/MDx3R/ef-test/internal/infra/database/gorm/model"
@@ -9,6 +11,7 @@ import (
 	"github.com/MDx3R/ef-test/internal/usecase/dto"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type gormSubscriptionRepository struct {
@@ -19,10 +22,18 @@ func NewGormSubscriptionRepository(db *gorm.DB) usecase.SubscriptionRepository {
 	return &gormSubscriptionRepository{db}
 }
 
-func (r *gormSubscriptionRepository) Get(id uuid.UUID) (*entity.Subscription, error) {
+// scoped starts a statement limited to subscriptions of the tenant in ctx.
+// Every query of the repository must start with it.
+func (r *gormSubscriptionRepository) scoped(ctx context.Context) *gorm.DB {
+	return r.tx.WithContext(ctx).
+		Model(&gormmodel.SubscriptionModel{}).
+		Where("tenant_id = ?", usecase.TenantFrom(ctx).ID)
+}
+
+func (r *gormSubscriptionRepository) Get(ctx context.Context, id uuid.UUID) (*entity.Subscription, error) {
 	var model gormmodel.SubscriptionModel
 
-	err := r.tx.First(&model, "id = ?", id).Error
+	err := r.scoped(ctx).First(&model, "id = ?", id).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, usecase.ErrNotFound
@@ -37,10 +48,10 @@ func (r *gormSubscriptionRepository) Get(id uuid.UUID) (*entity.Subscription, er
 
 	return sub, nil
 }
-func (r *gormSubscriptionRepository) List(filter dto.SubscriptionFilter) ([]*entity.Subscription, error) {
+func (r *gormSubscriptionRepository) List(ctx context.Context, filter dto.SubscriptionFilter) (dto.SubscriptionPage, error) {
 	var subs []gormmodel.SubscriptionModel
 
-	stmt := r.tx
+	stmt := r.scoped(ctx)
 	if filter.UserID != nil {
 		stmt = stmt.Where("user_id = ?", *filter.UserID)
 	}
@@ -48,65 +59,142 @@ func (r *gormSubscriptionRepository) List(filter dto.SubscriptionFilter) ([]*ent
 		stmt = stmt.Where("service_name = ?", *filter.ServiceName)
 	}
 	if filter.StartDate != nil {
-		stmt = stmt.Where("start_date >= ?", filter.StartDate)
+		stmt = stmt.Where("start_date >= ?", gormmodel.ToDate(*filter.StartDate))
 	}
 	if filter.EndDate != nil {
-		stmt = stmt.Where("end_date IS NULL OR end_date <= ?", filter.EndDate)
+		stmt = stmt.Where("(end_date IS NULL OR end_date <= ?)", gormmodel.ToDate(*filter.EndDate))
+	}
+	if len(filter.ServiceNames) > 0 {
+		stmt = stmt.Where("service_name IN ?", filter.ServiceNames)
+	}
+	if filter.ServiceNamePrefix != nil {
+		stmt = stmt.Where("service_name_lower LIKE ? ESCAPE '!'", likePrefix(strings.ToLower(*filter.ServiceNamePrefix)))
+	}
+	if filter.PriceMin != nil {
+		stmt = stmt.Where("price >= ?", *filter.PriceMin)
+	}
+	if filter.PriceMax != nil {
+		stmt = stmt.Where("price <= ?", *filter.PriceMax)
+	}
+	if filter.ActiveAt != nil {
+		activeAt, activeUntil := gormmodel.ToDate(*filter.ActiveAt), gormmodel.ToDate(*filter.ActiveAt)
+		if filter.ActiveUntil != nil {
+			activeUntil = gormmodel.ToDate(*filter.ActiveUntil)
+		}
+		stmt = stmt.Where("start_date <= ? AND (end_date IS NULL OR end_date >= ?)", activeUntil, activeAt)
+	}
+	if filter.ExpiringBefore != nil {
+		stmt = stmt.Where("end_date < ?", gormmodel.ToDate(*filter.ExpiringBefore))
+	}
+	if filter.OpenEnded != nil {
+		if *filter.OpenEnded {
+			stmt = stmt.Where("end_date IS NULL")
+		} else {
+			stmt = stmt.Where("end_date IS NOT NULL")
+		}
 	}
 
-	offset := (filter.Page - 1) * filter.PageSize
-	err := stmt.Offset(offset).Limit(filter.PageSize).Find(&subs).Error
+	// The filtered statement is shared by the COUNT and the page queries.
+	stmt = stmt.Session(&gorm.Session{})
+
+	var total *int
+	if filter.IncludeTotal {
+		var count int64
+		if err := stmt.Count(&count).Error; err != nil {
+			return dto.SubscriptionPage{}, wrap(usecase.ErrRepository, err)
+		}
+		n := int(count)
+		total = &n
+	}
 
+	page := stmt
+	if filter.After != nil {
+		// Keyset pagination, written without row values to stay portable across dialects.
+		after := gormmodel.ToDate(filter.After.StartDate)
+		page = page.Where(
+			"(start_date > ? OR (start_date = ? AND id > ?))",
+			after, after, filter.After.ID,
+		)
+	} else {
+		offset := (filter.Page - 1) * filter.PageSize
+		page = page.Offset(offset)
+	}
+
+	page = applyOrder(page, filter.Sort)
+
+	// One extra row tells whether there is a next page.
+	limit := max(filter.PageSize, 0)
+	err := page.Limit(limit + 1).Find(&subs).Error
 	if err != nil {
-		return nil, wrap(usecase.ErrRepository, err)
+		return dto.SubscriptionPage{}, wrap(usecase.ErrRepository, err)
+	}
+
+	hasMore := len(subs) > limit
+	if hasMore {
+		subs = subs[:limit]
 	}
 
 	result := make([]*entity.Subscription, len(subs))
 	for i, model := range subs {
 		sub, err := model.ToEntity()
 		if err != nil {
-			return nil, wrap(usecase.ErrRepository, err)
+			return dto.SubscriptionPage{}, wrap(usecase.ErrRepository, err)
 		}
 
 		result[i] = sub
 	}
 
-	return result, nil
+	return dto.SubscriptionPage{Subscriptions: result, HasMore: hasMore, Total: total}, nil
 }
-func (r *gormSubscriptionRepository) Add(sub *entity.Subscription) error {
+func (r *gormSubscriptionRepository) Add(ctx context.Context, sub *entity.Subscription) error {
 	model := gormmodel.FromEntity(sub)
+	model.TenantID = usecase.TenantFrom(ctx).ID
 
-	err := r.tx.Create(model).Error
+	err := r.tx.WithContext(ctx).Create(&model).Error
 	if err != nil {
 		return wrap(usecase.ErrRepository, err)
 	}
 	return nil
 }
-func (r *gormSubscriptionRepository) Update(sub *entity.Subscription) error {
+
+// Update inserts missing subscriptions. Save is not used: its upsert
+// would overwrite a subscription with the same ID of another tenant,
+// while a plain insert fails on the primary key.
+func (r *gormSubscriptionRepository) Update(ctx context.Context, sub *entity.Subscription) error {
 	model := gormmodel.FromEntity(sub)
+	model.TenantID = usecase.TenantFrom(ctx).ID
 
-	err := r.tx.Save(model).Error
-	if err != nil {
+	exists, err := updateRow(r.scoped(ctx).Where("id = ?", model.ID), &model)
+	if err != nil || exists {
+		return err
+	}
+
+	if err := r.tx.WithContext(ctx).Create(&model).Error; err != nil {
 		return wrap(usecase.ErrRepository, err)
 	}
 	return nil
 }
-func (r *gormSubscriptionRepository) Delete(id uuid.UUID) error {
-	err := r.tx.Delete(&gormmodel.SubscriptionModel{}, "id = ?", id).Error
+func (r *gormSubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	err := r.scoped(ctx).Delete(&gormmodel.SubscriptionModel{}, "id = ?", id).Error
 	if err != nil {
 		return wrap(usecase.ErrRepository, err)
 	}
 	return nil
 }
-func (r *gormSubscriptionRepository) CalculateTotalCost(filter dto.TotalCostFilter) (int, error) {
+func (r *gormSubscriptionRepository) CalculateTotalCost(ctx context.Context, filter dto.TotalCostFilter) (int, error) {
 	var result int
 
 	// Calculate the total subscription cost for the given user and service,
 	// considering only subscriptions whose start_date falls within the specified period.
-	stmt := r.tx.Model(&gormmodel.SubscriptionModel{}).Select("sum(price)")
+	// COALESCE keeps the result an integer when nothing matches, as SUM yields NULL on every dialect.
+	stmt := r.scoped(ctx).Select("COALESCE(SUM(price), 0)")
 	stmt = stmt.Where("user_id = ?", filter.UserID)
 	stmt = stmt.Where("service_name = ?", filter.ServiceName)
-	stmt = stmt.Where("start_date BETWEEN ? AND ?", filter.PeriodStart, filter.PeriodEnd)
+	stmt = stmt.Where(
+		"start_date BETWEEN ? AND ?",
+		gormmodel.ToDate(filter.PeriodStart),
+		gormmodel.ToDate(filter.PeriodEnd),
+	)
 
 	err := stmt.Scan(&result).Error
 	if err != nil {
@@ -115,6 +203,184 @@ func (r *gormSubscriptionRepository) CalculateTotalCost(filter dto.TotalCostFilt
 	return result, nil
 }
 
+func (r *gormSubscriptionRepository) Search(ctx context.Context, filter dto.SubscriptionSearchFilter) ([]*entity.Subscription, error) {
+	var subs []gormmodel.SubscriptionModel
+
+	stmt := r.scoped(ctx)
+	if filter.UserID != nil {
+		stmt = stmt.Where("user_id = ?", *filter.UserID)
+	}
+
+	if r.tx.Dialector.Name() == "postgres" {
+		stmt = searchPostgres(stmt, filter.Query)
+	} else {
+		stmt = searchLike(stmt, filter.Query)
+	}
+
+	err := stmt.Limit(filter.Limit).Find(&subs).Error
+	if err != nil {
+		return nil, wrap(usecase.ErrRepository, err)
+	}
+
+	result := make([]*entity.Subscription, len(subs))
+	for i, model := range subs {
+		sub, err := model.ToEntity()
+		if err != nil {
+			return nil, wrap(usecase.ErrRepository, err)
+		}
+		result[i] = sub
+	}
+	return result, nil
+}
+
+func (r *gormSubscriptionRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Subscription, error) {
+	var subs []gormmodel.SubscriptionModel
+
+	err := r.scoped(ctx).Where("user_id = ?", userID).Order("start_date, id").Find(&subs).Error
+	if err != nil {
+		return nil, wrap(usecase.ErrRepository, err)
+	}
+
+	result := make([]*entity.Subscription, len(subs))
+	for i, model := range subs {
+		sub, err := model.ToEntity()
+		if err != nil {
+			return nil, wrap(usecase.ErrRepository, err)
+		}
+		result[i] = sub
+	}
+	return result, nil
+}
+
+func (r *gormSubscriptionRepository) Merge(ctx context.Context, merge dto.SubscriptionMerge) error {
+	tenantID := usecase.TenantFrom(ctx).ID
+
+	return r.tx.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		repo := &gormSubscriptionRepository{tx}
+
+		target := gormmodel.FromEntity(merge.Target)
+		target.TenantID = tenantID
+
+		exists, err := updateRow(repo.scoped(ctx).Where("id = ?", target.ID), &target)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return usecase.ErrNotFound
+		}
+
+		audit := make([]gormmodel.SubscriptionMergeModel, len(merge.Sources))
+		ids := make([]uuid.UUID, len(merge.Sources))
+		for i, source := range merge.Sources {
+			ids[i] = source.ID()
+			audit[i] = gormmodel.NewSubscriptionMergeModel(tenantID, target.ID, source, merge.MergedBy, merge.MergedAt)
+		}
+
+		result := repo.scoped(ctx).Delete(&gormmodel.SubscriptionModel{}, "id IN ?", ids)
+		if result.Error != nil {
+			return wrap(usecase.ErrRepository, result.Error)
+		}
+		if result.RowsAffected != int64(len(ids)) {
+			return usecase.ErrNotFound
+		}
+
+		if len(audit) > 0 {
+			if err := tx.WithContext(ctx).Create(&audit).Error; err != nil {
+				return wrap(usecase.ErrRepository, err)
+			}
+		}
+		return nil
+	})
+}
+
+// searchVector must match the expression of the GIN index created by the search migration,
+// otherwise Postgres can't use the index.
+const searchVector = "(setweight(to_tsvector('simple', service_name), 'A') || setweight(to_tsvector('simple', notes), 'B'))"
+
+// searchPostgres matches words through full-text search and misspellings through pg_trgm.
+// Service name matches rank above matches in notes.
+func searchPostgres(stmt *gorm.DB, query string) *gorm.DB {
+	stmt = stmt.Where(
+		searchVector+" @@ plainto_tsquery('simple', ?) OR service_name % ? OR ? <% service_name OR ? <% notes",
+		query, query, query, query,
+	)
+	return stmt.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL: "ts_rank(" + searchVector + ", plainto_tsquery('simple', ?))" +
+			" + GREATEST(similarity(service_name, ?), word_similarity(?, service_name))" +
+			" + 0.5 * word_similarity(?, notes) DESC, id",
+		Vars:               []any{query, query, query, query},
+		WithoutParentheses: true,
+	}})
+}
+
+// searchLike is the portable fallback: a case-insensitive substring match,
+// service name matches first.
+func searchLike(stmt *gorm.DB, query string) *gorm.DB {
+	pattern := likeContains(strings.ToLower(query))
+	stmt = stmt.Where("(LOWER(service_name) LIKE ? ESCAPE '!' OR LOWER(notes) LIKE ? ESCAPE '!')", pattern, pattern)
+	return stmt.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:                "CASE WHEN LOWER(service_name) LIKE ? ESCAPE '!' THEN 0 ELSE 1 END, id",
+		Vars:               []any{pattern},
+		WithoutParentheses: true,
+	}})
+}
+
+// applyOrder orders by the requested fields followed by id, or by (start_date, id) by default.
+// Fields come from a whitelist, so they are safe to use as column names.
+func applyOrder(stmt *gorm.DB, sort []dto.SortOrder) *gorm.DB {
+	if len(sort) == 0 {
+		sort = []dto.SortOrder{{Field: dto.SortByStartDate}}
+	}
+
+	for _, order := range sort {
+		if order.Field == dto.SortByEndDate {
+			// NULL ordering differs between dialects, so open-ended
+			// subscriptions are explicitly placed after the others.
+			stmt = stmt.Order(clause.OrderByColumn{Column: clause.Column{Name: "end_date IS NULL", Raw: true}})
+		}
+		stmt = stmt.Order(clause.OrderByColumn{
+			Column: clause.Column{Name: string(order.Field)},
+			Desc:   order.Desc,
+		})
+	}
+
+	return stmt.Order(clause.OrderByColumn{Column: clause.Column{Name: "id"}})
+}
+
+// likePrefix builds a LIKE pattern matching strings starting with prefix,
+// escaping wildcards with '!'.
+func likePrefix(prefix string) string {
+	return likeEscaper.Replace(prefix) + "%"
+}
+
+// likeContains builds a LIKE pattern matching strings containing s.
+func likeContains(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// updateRow rewrites every column of the row stmt matches with model and
+// reports whether the row exists. MySQL reports no affected rows when nothing
+// changed, so the row is counted when none were.
+func updateRow(stmt *gorm.DB, model any) (bool, error) {
+	stmt = stmt.Session(&gorm.Session{})
+
+	result := stmt.Select("*").Updates(model)
+	if result.Error != nil {
+		return false, wrap(usecase.ErrRepository, result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	var count int64
+	if err := stmt.Count(&count).Error; err != nil {
+		return false, wrap(usecase.ErrRepository, err)
+	}
+	return count > 0, nil
+}
+
 func wrap(to, with error) error {
 	return fmt.Errorf("%w: %v", to, with)
 }
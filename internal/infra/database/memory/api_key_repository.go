@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/google/uuid"
+)
+
+type memoryAPIKeyRepository struct {
+	mu   sync.RWMutex
+	keys map[uuid.UUID]*entity.APIKey
+}
+
+func NewMemoryAPIKeyRepository() usecase.APIKeyRepository {
+	return &memoryAPIKeyRepository{
+		keys: make(map[uuid.UUID]*entity.APIKey),
+	}
+}
+
+func (r *memoryAPIKeyRepository) Get(ctx context.Context, id uuid.UUID) (*entity.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, ok := r.keys[id]
+	if !ok || key.TenantID() != usecase.TenantFrom(ctx).ID {
+		return nil, usecase.ErrNotFound
+	}
+	return cloneAPIKey(key)
+}
+
+func (r *memoryAPIKeyRepository) GetByHash(_ context.Context, hash string) (*entity.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.Hash() == hash {
+			return cloneAPIKey(key)
+		}
+	}
+	return nil, usecase.ErrNotFound
+}
+
+func (r *memoryAPIKeyRepository) List(ctx context.Context) ([]*entity.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tenant := usecase.TenantFrom(ctx).ID
+
+	result := make([]*entity.APIKey, 0, len(r.keys))
+	for _, key := range r.keys {
+		if key.TenantID() != tenant {
+			continue
+		}
+		c, err := cloneAPIKey(key)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, c)
+	}
+
+	slices.SortFunc(result, func(a, b *entity.APIKey) int {
+		if c := b.CreatedAt().Compare(a.CreatedAt()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID().String(), b.ID().String())
+	})
+	return result, nil
+}
+
+func (r *memoryAPIKeyRepository) Add(_ context.Context, key *entity.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, stored := range r.keys {
+		if stored.ID() == key.ID() || stored.Hash() == key.Hash() {
+			return wrap(usecase.ErrRepository, fmt.Errorf("duplicate key: %s", key.ID()))
+		}
+	}
+
+	return r.store(key)
+}
+
+// Update keeps a key in the tenant it was issued for. Authentication updates
+// keys before the tenant of the request is known, so ctx is not consulted.
+func (r *memoryAPIKeyRepository) Update(_ context.Context, key *entity.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if stored, ok := r.keys[key.ID()]; !ok || stored.TenantID() != key.TenantID() {
+		return usecase.ErrNotFound
+	}
+
+	return r.store(key)
+}
+
+func (r *memoryAPIKeyRepository) TouchLastUsed(_ context.Context, tenantID string, id uuid.UUID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.keys[id]
+	if !ok || stored.TenantID() != tenantID || stored.RevokedAt() != nil {
+		return usecase.ErrNotFound
+	}
+
+	stored.Touch(at)
+	return nil
+}
+
+// store saves a copy of key. Callers must hold the write lock.
+func (r *memoryAPIKeyRepository) store(key *entity.APIKey) error {
+	c, err := cloneAPIKey(key)
+	if err != nil {
+		return err
+	}
+
+	r.keys[c.ID()] = c
+	return nil
+}
+
+func cloneAPIKey(key *entity.APIKey) (*entity.APIKey, error) {
+	c, err := entity.NewAPIKeyWithID(
+		key.ID(),
+		key.TenantID(),
+		key.Name(),
+		key.Hash(),
+		key.Prefix(),
+		key.Scopes(),
+		key.CreatedAt(),
+		key.ExpiresAt(),
+	)
+	if err != nil {
+		return nil, wrap(usecase.ErrRepository, err)
+	}
+	if last := key.LastUsedAt(); last != nil {
+		c.Touch(*last)
+	}
+	if revoked := key.RevokedAt(); revoked != nil {
+		c.Revoke(*revoked)
+	}
+	return c, nil
+}
@@ -0,0 +1,15 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/MDx3R/ef-test/internal/infra/database/memory"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/test/contract"
+)
+
+func TestMemoryAPIKeyRepository_Contract(t *testing.T) {
+	contract.RunAPIKeyRepositoryTests(t, func(t *testing.T) usecase.APIKeyRepository {
+		return memory.NewMemoryAPIKeyRepository()
+	})
+}
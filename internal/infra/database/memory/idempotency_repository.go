@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+)
+
+type idempotencyKey struct {
+	tenantID string
+	owner    string
+	key      string
+}
+
+type memoryIdempotencyRepository struct {
+	mu      sync.Mutex
+	records map[idempotencyKey]dto.IdempotencyRecord
+}
+
+func NewMemoryIdempotencyRepository() usecase.IdempotencyRepository {
+	return &memoryIdempotencyRepository{
+		records: make(map[idempotencyKey]dto.IdempotencyRecord),
+	}
+}
+
+func (r *memoryIdempotencyRepository) Reserve(ctx context.Context, record dto.IdempotencyRecord) (dto.IdempotencyRecord, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k := keyOf(ctx, record.Owner, record.Key)
+	if existing, ok := r.records[k]; ok && existing.ExpiresAt.After(record.CreatedAt) {
+		return cloneIdempotencyRecord(existing), false, nil
+	}
+
+	r.records[k] = cloneIdempotencyRecord(record)
+	return record, true, nil
+}
+
+func (r *memoryIdempotencyRepository) Complete(ctx context.Context, owner, key string, response dto.IdempotentResponse, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k := keyOf(ctx, owner, key)
+	record, ok := r.records[k]
+	if !ok || record.Response != nil {
+		return usecase.ErrNotFound
+	}
+
+	response.Body = bytes.Clone(response.Body)
+	record.Response = &response
+	record.ExpiresAt = expiresAt
+	r.records[k] = record
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) Release(ctx context.Context, owner, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k := keyOf(ctx, owner, key)
+	if record, ok := r.records[k]; ok && record.Response == nil {
+		delete(r.records, k)
+	}
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) DeleteExpired(_ context.Context, now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var deleted int64
+	for k, record := range r.records {
+		if !record.ExpiresAt.After(now) {
+			delete(r.records, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func keyOf(ctx context.Context, owner, key string) idempotencyKey {
+	return idempotencyKey{tenantID: usecase.TenantFrom(ctx).ID, owner: owner, key: key}
+}
+
+func cloneIdempotencyRecord(record dto.IdempotencyRecord) dto.IdempotencyRecord {
+	if record.Response != nil {
+		response := *record.Response
+		response.Body = bytes.Clone(response.Body)
+		record.Response = &response
+	}
+	return record
+}
@@ -0,0 +1,376 @@
+package memory
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	"github.com/google/uuid"
+)
+
+// memorySubscriptionRepository keeps subscriptions in process memory.
+// It mirrors the semantics of the gorm repository: dates are compared by calendar day,
+// lists are ordered by (start_date, id) by default, Update inserts missing records
+// and Delete of a missing record is not an error.
+// IDs are unique across tenants, as they are in the database.
+type memorySubscriptionRepository struct {
+	mu   sync.RWMutex
+	subs map[uuid.UUID]*entity.Subscription
+	// tenants maps a subscription ID to the tenant owning it.
+	tenants map[uuid.UUID]string
+}
+
+func NewMemorySubscriptionRepository() usecase.SubscriptionRepository {
+	return &memorySubscriptionRepository{
+		subs:    make(map[uuid.UUID]*entity.Subscription),
+		tenants: make(map[uuid.UUID]string),
+	}
+}
+
+func (r *memorySubscriptionRepository) Get(ctx context.Context, id uuid.UUID) (*entity.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	sub, ok := r.subs[id]
+	if !ok || r.tenants[id] != usecase.TenantFrom(ctx).ID {
+		return nil, usecase.ErrNotFound
+	}
+
+	return clone(sub)
+}
+func (r *memorySubscriptionRepository) List(ctx context.Context, filter dto.SubscriptionFilter) (dto.SubscriptionPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := make([]*entity.Subscription, 0)
+	for _, sub := range r.ofTenant(ctx) {
+		if matches(sub, filter) {
+			matched = append(matched, sub)
+		}
+	}
+
+	slices.SortFunc(matched, func(a, b *entity.Subscription) int {
+		return compareSubscriptions(a, b, filter.Sort)
+	})
+
+	var total *int
+	if filter.IncludeTotal {
+		n := len(matched)
+		total = &n
+	}
+
+	matched, hasMore := paginate(matched, filter)
+
+	result := make([]*entity.Subscription, len(matched))
+	for i, sub := range matched {
+		c, err := clone(sub)
+		if err != nil {
+			return dto.SubscriptionPage{}, err
+		}
+		result[i] = c
+	}
+
+	return dto.SubscriptionPage{Subscriptions: result, HasMore: hasMore, Total: total}, nil
+}
+func (r *memorySubscriptionRepository) Add(ctx context.Context, sub *entity.Subscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.subs[sub.ID()]; ok {
+		return wrap(usecase.ErrRepository, fmt.Errorf("duplicate key: %s", sub.ID()))
+	}
+
+	return r.store(ctx, sub)
+}
+func (r *memorySubscriptionRepository) Update(ctx context.Context, sub *entity.Subscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// A subscription of another tenant can't be overwritten;
+	// inserting it again fails like a primary key conflict would.
+	if tenant, ok := r.tenants[sub.ID()]; ok && tenant != usecase.TenantFrom(ctx).ID {
+		return wrap(usecase.ErrRepository, fmt.Errorf("duplicate key: %s", sub.ID()))
+	}
+
+	return r.store(ctx, sub)
+}
+func (r *memorySubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.tenants[id] == usecase.TenantFrom(ctx).ID {
+		delete(r.subs, id)
+		delete(r.tenants, id)
+	}
+	return nil
+}
+func (r *memorySubscriptionRepository) CalculateTotalCost(ctx context.Context, filter dto.TotalCostFilter) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	periodStart := toDate(filter.PeriodStart)
+	periodEnd := toDate(filter.PeriodEnd)
+
+	result := 0
+	for _, sub := range r.ofTenant(ctx) {
+		if sub.UserID() != filter.UserID || sub.ServiceName() != filter.ServiceName {
+			continue
+		}
+
+		startDate := toDate(sub.StartDate())
+		if startDate.Before(periodStart) || startDate.After(periodEnd) {
+			continue
+		}
+
+		result += sub.Price()
+	}
+	return result, nil
+}
+
+func (r *memorySubscriptionRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	matched := make([]*entity.Subscription, 0)
+	for _, sub := range r.ofTenant(ctx) {
+		if sub.UserID() == userID {
+			matched = append(matched, sub)
+		}
+	}
+	slices.SortFunc(matched, func(a, b *entity.Subscription) int {
+		return compareKeys(a.StartDate(), a.ID(), b.StartDate(), b.ID())
+	})
+
+	result := make([]*entity.Subscription, len(matched))
+	for i, sub := range matched {
+		c, err := clone(sub)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = c
+	}
+	return result, nil
+}
+
+// Merge doesn't keep the audit entries: nothing reads them back
+// and they would be lost on shutdown anyway.
+func (r *memorySubscriptionRepository) Merge(ctx context.Context, merge dto.SubscriptionMerge) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tenant := usecase.TenantFrom(ctx).ID
+	ids := []uuid.UUID{merge.Target.ID()}
+	for _, source := range merge.Sources {
+		ids = append(ids, source.ID())
+	}
+	for _, id := range ids {
+		if _, ok := r.subs[id]; !ok || r.tenants[id] != tenant {
+			return usecase.ErrNotFound
+		}
+	}
+
+	if err := r.store(ctx, merge.Target); err != nil {
+		return err
+	}
+	for _, id := range ids[1:] {
+		delete(r.subs, id)
+		delete(r.tenants, id)
+	}
+	return nil
+}
+
+// store saves a copy of sub, so later changes made by the caller
+// are not visible until the next Update. Callers must hold the write lock.
+func (r *memorySubscriptionRepository) store(ctx context.Context, sub *entity.Subscription) error {
+	c, err := clone(sub)
+	if err != nil {
+		return err
+	}
+
+	r.subs[c.ID()] = c
+	r.tenants[c.ID()] = usecase.TenantFrom(ctx).ID
+	return nil
+}
+
+// ofTenant returns the subscriptions of the tenant in ctx. Callers must hold the lock.
+func (r *memorySubscriptionRepository) ofTenant(ctx context.Context) []*entity.Subscription {
+	tenant := usecase.TenantFrom(ctx).ID
+
+	result := make([]*entity.Subscription, 0, len(r.subs))
+	for id, sub := range r.subs {
+		if r.tenants[id] == tenant {
+			result = append(result, sub)
+		}
+	}
+	return result
+}
+
+func matches(sub *entity.Subscription, filter dto.SubscriptionFilter) bool {
+	if filter.UserID != nil && sub.UserID() != *filter.UserID {
+		return false
+	}
+	if filter.ServiceName != nil && sub.ServiceName() != *filter.ServiceName {
+		return false
+	}
+	if filter.StartDate != nil && sub.StartDate().Before(toDate(*filter.StartDate)) {
+		return false
+	}
+	if filter.EndDate != nil && sub.EndDate() != nil && sub.EndDate().After(toDate(*filter.EndDate)) {
+		return false
+	}
+	if len(filter.ServiceNames) > 0 && !slices.Contains(filter.ServiceNames, sub.ServiceName()) {
+		return false
+	}
+	if filter.ServiceNamePrefix != nil &&
+		!strings.HasPrefix(strings.ToLower(sub.ServiceName()), strings.ToLower(*filter.ServiceNamePrefix)) {
+		return false
+	}
+	if filter.PriceMin != nil && sub.Price() < *filter.PriceMin {
+		return false
+	}
+	if filter.PriceMax != nil && sub.Price() > *filter.PriceMax {
+		return false
+	}
+	if filter.ActiveAt != nil {
+		activeAt, activeUntil := toDate(*filter.ActiveAt), toDate(*filter.ActiveAt)
+		if filter.ActiveUntil != nil {
+			activeUntil = toDate(*filter.ActiveUntil)
+		}
+		if sub.StartDate().After(activeUntil) || (sub.EndDate() != nil && sub.EndDate().Before(activeAt)) {
+			return false
+		}
+	}
+	if filter.ExpiringBefore != nil && (sub.EndDate() == nil || !sub.EndDate().Before(toDate(*filter.ExpiringBefore))) {
+		return false
+	}
+	if filter.OpenEnded != nil && *filter.OpenEnded != (sub.EndDate() == nil) {
+		return false
+	}
+	return true
+}
+
+// paginate applies keyset or OFFSET/LIMIT pagination to sorted subscriptions
+// the way the gorm repository does: a non-positive offset is ignored.
+func paginate(subs []*entity.Subscription, filter dto.SubscriptionFilter) ([]*entity.Subscription, bool) {
+	if filter.After != nil {
+		after := toDate(filter.After.StartDate)
+		start := slices.IndexFunc(subs, func(sub *entity.Subscription) bool {
+			return compareKeys(sub.StartDate(), sub.ID(), after, filter.After.ID) > 0
+		})
+		if start < 0 {
+			start = len(subs)
+		}
+		subs = subs[start:]
+	} else if offset := (filter.Page - 1) * filter.PageSize; offset > 0 {
+		subs = subs[min(offset, len(subs)):]
+	}
+
+	limit := max(filter.PageSize, 0)
+	if len(subs) > limit {
+		return subs[:limit], true
+	}
+	return subs, false
+}
+
+// compareSubscriptions orders by the requested fields followed by id,
+// or by (start_date, id) by default, like the gorm repository.
+func compareSubscriptions(a, b *entity.Subscription, sort []dto.SortOrder) int {
+	if len(sort) == 0 {
+		return compareKeys(a.StartDate(), a.ID(), b.StartDate(), b.ID())
+	}
+
+	for _, order := range sort {
+		if order.Field == dto.SortByEndDate {
+			// Subscriptions without end date come last in either direction.
+			if c := cmp.Compare(boolToInt(a.EndDate() == nil), boolToInt(b.EndDate() == nil)); c != 0 {
+				return c
+			}
+		}
+
+		c := compareField(a, b, order.Field)
+		if order.Desc {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	aID, bID := a.ID(), b.ID()
+	return bytes.Compare(aID[:], bID[:])
+}
+
+func compareField(a, b *entity.Subscription, field dto.SortField) int {
+	switch field {
+	case dto.SortByServiceName:
+		return strings.Compare(a.ServiceName(), b.ServiceName())
+	case dto.SortByPrice:
+		return cmp.Compare(a.Price(), b.Price())
+	case dto.SortByStartDate:
+		return a.StartDate().Compare(b.StartDate())
+	case dto.SortByEndDate:
+		if a.EndDate() == nil || b.EndDate() == nil {
+			return 0
+		}
+		return a.EndDate().Compare(*b.EndDate())
+	default:
+		return 0
+	}
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// compareKeys orders subscriptions by (start_date, id).
+// UUIDs are compared bytewise, which matches the order of their text form in SQL.
+func compareKeys(aDate time.Time, aID uuid.UUID, bDate time.Time, bID uuid.UUID) int {
+	if c := aDate.Compare(bDate); c != 0 {
+		return c
+	}
+	return bytes.Compare(aID[:], bID[:])
+}
+
+// clone returns a deep copy of sub with dates truncated to calendar days,
+// the same precision a DATE column keeps.
+func clone(sub *entity.Subscription) (*entity.Subscription, error) {
+	c, err := entity.NewSubscriptionWithID(
+		sub.ID(),
+		sub.ServiceName(),
+		sub.UserID(),
+		sub.Price(),
+		toDate(sub.StartDate()),
+		toDatePtr(sub.EndDate()),
+	)
+	if err != nil {
+		return nil, wrap(usecase.ErrRepository, err)
+	}
+	c.SetNotes(sub.Notes())
+	return c, nil
+}
+
+func toDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func toDatePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	d := toDate(*t)
+	return &d
+}
+
+func wrap(to, with error) error {
+	return fmt.Errorf("%w: %v", to, with)
+}
@@ -0,0 +1,101 @@
+package memory_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	"github.com/MDx3R/ef-test/internal/infra/database/memory"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	"github.com/MDx3R/ef-test/test/contract"
+	"github.com/MDx3R/ef-test/test/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemorySubscriptionRepository_Get_ReturnsCopy(t *testing.T) {
+	repo := memory.NewMemorySubscriptionRepository()
+
+	// Arrange
+	sub := testutil.MakeTestSubscription(t)
+	require.NoError(t, repo.Add(t.Context(), sub))
+
+	// Act
+	sub.SetPrice(500)
+	got, err := repo.Get(t.Context(), sub.ID())
+	require.NoError(t, err)
+	got.SetServiceName("changed")
+	again, errAgain := repo.Get(t.Context(), sub.ID())
+
+	// Assert
+	assert.NoError(t, errAgain)
+	assert.Equal(t, 100, again.Price())
+	assert.Equal(t, "test_service", again.ServiceName())
+}
+
+func TestMemorySubscriptionRepository_Add_Duplicate(t *testing.T) {
+	repo := memory.NewMemorySubscriptionRepository()
+
+	// Arrange
+	sub := testutil.MakeTestSubscription(t)
+	require.NoError(t, repo.Add(t.Context(), sub))
+
+	// Act
+	err := repo.Add(t.Context(), sub)
+
+	// Assert
+	assert.ErrorIs(t, err, usecase.ErrRepository)
+}
+
+func TestMemorySubscriptionRepository_ConcurrentAccess(t *testing.T) {
+	repo := memory.NewMemorySubscriptionRepository()
+
+	// Act
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sub := testutil.MakeTestSubscription(t)
+			assert.NoError(t, repo.Add(t.Context(), sub))
+			sub.SetPrice(200)
+			assert.NoError(t, repo.Update(t.Context(), sub))
+			_, err := repo.List(t.Context(), dto.SubscriptionFilter{Page: 1, PageSize: 10})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	page, err := repo.List(t.Context(), dto.SubscriptionFilter{Page: 1, PageSize: 100})
+	assert.NoError(t, err)
+	assert.Len(t, page.Subscriptions, 50)
+}
+
+func TestMemorySubscriptionRepository_Search_Misspelled(t *testing.T) {
+	// Arrange
+	repo := memory.NewMemorySubscriptionRepository()
+	netflix, err := entity.NewSubscription("Netflix", uuid.New(), 100, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), nil)
+	require.NoError(t, err)
+	spotify, err := entity.NewSubscription("Spotify", uuid.New(), 100, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.Add(t.Context(), netflix))
+	require.NoError(t, repo.Add(t.Context(), spotify))
+
+	// Act
+	subs, err := repo.Search(t.Context(), dto.SubscriptionSearchFilter{Query: "netflex", Limit: 10})
+
+	// Assert
+	assert.NoError(t, err)
+	require.Len(t, subs, 1)
+	assert.Equal(t, netflix.ID(), subs[0].ID())
+}
+
+func TestMemorySubscriptionRepository_Contract(t *testing.T) {
+	contract.RunSubscriptionRepositoryTests(t, func(t *testing.T) usecase.SubscriptionRepository {
+		return memory.NewMemorySubscriptionRepository()
+	})
+}
@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+)
+
+// similarityThreshold is the default pg_trgm similarity threshold.
+const similarityThreshold = 0.3
+
+func (r *memorySubscriptionRepository) Search(ctx context.Context, filter dto.SubscriptionSearchFilter) ([]*entity.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type ranked struct {
+		sub  *entity.Subscription
+		rank float64
+	}
+
+	query := strings.ToLower(strings.TrimSpace(filter.Query))
+
+	var matched []ranked
+	for _, sub := range r.ofTenant(ctx) {
+		if filter.UserID != nil && sub.UserID() != *filter.UserID {
+			continue
+		}
+		if rank := searchRank(sub, query); rank > 0 {
+			matched = append(matched, ranked{sub, rank})
+		}
+	}
+
+	slices.SortFunc(matched, func(a, b ranked) int {
+		if c := cmp.Compare(b.rank, a.rank); c != 0 {
+			return c
+		}
+		aID, bID := a.sub.ID(), b.sub.ID()
+		return bytes.Compare(aID[:], bID[:])
+	})
+	matched = matched[:min(len(matched), max(filter.Limit, 0))]
+
+	result := make([]*entity.Subscription, len(matched))
+	for i, m := range matched {
+		c, err := clone(m.sub)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = c
+	}
+	return result, nil
+}
+
+// searchRank approximates the Postgres ranking: service name matches,
+// exact or fuzzy, weigh twice as much as substring matches in notes.
+// Zero means no match.
+func searchRank(sub *entity.Subscription, query string) float64 {
+	serviceName := strings.ToLower(sub.ServiceName())
+
+	rank := 0.0
+	if strings.Contains(serviceName, query) {
+		rank = 1
+	} else if s := similarity(serviceName, query); s >= similarityThreshold {
+		rank = s
+	}
+	if strings.Contains(strings.ToLower(sub.Notes()), query) {
+		rank += 0.5
+	}
+	return rank
+}
+
+// similarity is the pg_trgm similarity of a and b: the share of trigrams they have in common.
+func similarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+
+	common := 0
+	for t := range ta {
+		if _, ok := tb[t]; ok {
+			common++
+		}
+	}
+	return float64(common) / float64(len(ta)+len(tb)-common)
+}
+
+// trigrams splits s into words and returns the trigrams of each word
+// padded with two spaces in front and one behind, like pg_trgm does.
+func trigrams(s string) map[string]struct{} {
+	result := make(map[string]struct{})
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			result[string(padded[i:i+3])] = struct{}{}
+		}
+	}
+	return result
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+)
+
+// memorySubscriptionEventLog keeps the latest events in a ring buffer.
+// It is local to the process, so instances don't see events of each other.
+type memorySubscriptionEventLog struct {
+	mu     sync.Mutex
+	events []dto.SubscriptionEventDTO
+	// next is the position in events the next event is stored at.
+	next    int
+	lastID  uint64
+	changed chan struct{}
+}
+
+func NewMemorySubscriptionEventLog(capacity int) usecase.SubscriptionEventLog {
+	return &memorySubscriptionEventLog{
+		events:  make([]dto.SubscriptionEventDTO, 0, max(capacity, 1)),
+		changed: make(chan struct{}),
+	}
+}
+
+func (l *memorySubscriptionEventLog) Append(_ context.Context, event dto.SubscriptionEventDTO) (dto.SubscriptionEventDTO, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.lastID++
+	event.ID = l.lastID
+	if len(l.events) < cap(l.events) {
+		l.events = append(l.events, event)
+	} else {
+		l.events[l.next] = event
+	}
+	l.next = (l.next + 1) % cap(l.events)
+
+	close(l.changed)
+	l.changed = make(chan struct{})
+	return event, nil
+}
+
+func (l *memorySubscriptionEventLog) After(_ context.Context, id uint64) ([]dto.SubscriptionEventDTO, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if id >= l.lastID {
+		return nil, true, nil
+	}
+
+	// Events are kept in ID order starting at the oldest one.
+	oldest := l.lastID - uint64(len(l.events)) + 1
+	from := max(id+1, oldest)
+	result := make([]dto.SubscriptionEventDTO, 0, l.lastID-from+1)
+	for eventID := from; eventID <= l.lastID; eventID++ {
+		result = append(result, l.events[l.position(eventID)])
+	}
+	return result, id+1 >= oldest, nil
+}
+
+// position returns where the kept event with the ID is stored.
+func (l *memorySubscriptionEventLog) position(id uint64) int {
+	back := int(l.lastID - id)
+	return (l.next - 1 - back + 2*cap(l.events)) % cap(l.events)
+}
+
+func (l *memorySubscriptionEventLog) LastID(_ context.Context) (uint64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lastID, nil
+}
+
+func (l *memorySubscriptionEventLog) Changed() <-chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.changed
+}
@@ -0,0 +1,15 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/MDx3R/ef-test/internal/infra/database/memory"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/test/contract"
+)
+
+func TestMemorySubscriptionEventLog_Contract(t *testing.T) {
+	contract.RunSubscriptionEventLogTests(t, func(t *testing.T, capacity int) usecase.SubscriptionEventLog {
+		return memory.NewMemorySubscriptionEventLog(capacity)
+	})
+}
@@ -1,11 +1,16 @@
 package migrate
 
 import (
+	"errors"
 	"fmt"
+	"os"
 
 	"github.com/MDx3R/ef-test/internal/config"
 	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/mysql"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/sirupsen/logrus"
 )
@@ -17,12 +22,19 @@ func MustRunMigrations(cfg *config.DatabaseConfig, logger *logrus.Logger) {
 }
 
 func RunMigrations(cfg *config.DatabaseConfig, logger *logrus.Logger) error {
+	if cfg.IsInMemory() {
+		logger.Info("in-memory storage, no migrations needed")
+		return nil
+	}
+
+	dialect := cfg.GetDialect()
+	if dialect == "" {
+		return fmt.Errorf("unsupported database driver: %q", cfg.Driver)
+	}
+
 	url := cfg.GetURL()
 
-	m, err := migrate.New(
-		"file://./migrations",
-		url,
-	)
+	m, err := migrate.New(sourceURL(dialect), url)
 	if err != nil {
 		return fmt.Errorf("failed to initialize migrations: %w", err)
 	}
@@ -39,3 +51,39 @@ func RunMigrations(cfg *config.DatabaseConfig, logger *logrus.Logger) error {
 	}
 	return nil
 }
+
+// LatestVersion returns the version of the last migration of the database dialect,
+// which the schema has once every migration is applied.
+func LatestVersion(cfg *config.DatabaseConfig) (uint, error) {
+	dialect := cfg.GetDialect()
+	if dialect == "" {
+		return 0, fmt.Errorf("unsupported database driver: %q", cfg.Driver)
+	}
+
+	src, err := source.Open(sourceURL(dialect))
+	if err != nil {
+		return 0, fmt.Errorf("failed to open migrations: %w", err)
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read migrations: %w", err)
+		}
+		version = next
+	}
+}
+
+// sourceURL locates the migrations of dialect. Every dialect has its own
+// folder with migrations written in its SQL flavour.
+func sourceURL(dialect string) string {
+	return fmt.Sprintf("file://./migrations/%s", dialect)
+}
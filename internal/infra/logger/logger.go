@@ -57,7 +57,9 @@ func LogConfig(logger *logrus.Logger, cfg *config.Config) {
 	logger.WithFields(logrus.Fields{
 		"env": cfg.Env,
 		"server": logrus.Fields{
-			"port": cfg.Server.Port,
+			"port":             cfg.Server.Port,
+			"v1_deprecated_at": cfg.Server.V1Deprecation.DeprecatedAt,
+			"v1_sunset_at":     cfg.Server.V1Deprecation.SunsetAt,
 		},
 		"database": logrus.Fields{
 			"driver":   cfg.Database.Driver,
@@ -70,5 +72,43 @@ func LogConfig(logger *logrus.Logger, cfg *config.Config) {
 			"level":  cfg.Logger.Level,
 			"format": cfg.Logger.Format,
 		},
+		"auth": logrus.Fields{
+			"enabled":   cfg.Auth.Enabled,
+			"jwks_file": cfg.Auth.JWKSFile,
+			"issuer":    cfg.Auth.Issuer,
+			"audience":  cfg.Auth.Audience,
+			"policy":    cfg.Auth.PolicyFile,
+		},
+		"rate_limit": logrus.Fields{
+			"enabled": cfg.RateLimit.Enabled,
+			"backend": cfg.RateLimit.Backend,
+		},
+		"cache": logrus.Fields{
+			"enabled": cfg.Cache.Enabled,
+			"backend": cfg.Cache.Backend,
+			"ttl":     cfg.Cache.TTL,
+			"size":    cfg.Cache.Size,
+		},
+		"idempotency": logrus.Fields{
+			"ttl": cfg.Idempotency.TTL,
+		},
+		"tracing": logrus.Fields{
+			"exporter":     cfg.Tracing.Exporter,
+			"endpoint":     cfg.Tracing.Endpoint,
+			"sample_ratio": cfg.Tracing.SampleRatio,
+		},
+		"metrics": logrus.Fields{
+			"enabled": cfg.Metrics.Enabled,
+			"path":    cfg.Metrics.Path,
+		},
+		"health": logrus.Fields{
+			"check_timeout":  cfg.Health.CheckTimeout,
+			"shutdown_delay": cfg.Health.ShutdownDelay,
+		},
+		"subscriptions": logrus.Fields{
+			"reject_duplicates": cfg.Subscriptions.RejectDuplicates,
+			"events_log_size":   cfg.Subscriptions.Events.LogSize,
+			"events_heartbeat":  cfg.Subscriptions.Events.Heartbeat,
+		},
 	}).Info("loaded configuration")
 }
@@ -0,0 +1,70 @@
+package metrics_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MDx3R/ef-test/internal/config"
+	gormdb "github.com/MDx3R/ef-test/internal/infra/database/gorm"
+	"github.com/MDx3R/ef-test/internal/infra/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	body, err := io.ReadAll(w.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMetrics_RecordOperation(t *testing.T) {
+	m := metrics.NewMetrics()
+
+	m.RecordOperation("create", nil)
+	m.RecordOperation("create", nil)
+	m.RecordOperation("delete", errors.New("failure"))
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `subscriptions_usecase_operations_total{operation="create",outcome="success"} 2`)
+	assert.Contains(t, body, `subscriptions_usecase_operations_total{operation="delete",outcome="error"} 1`)
+}
+
+func TestMetrics_RecordCacheLookup(t *testing.T) {
+	m := metrics.NewMetrics()
+
+	m.RecordCacheLookup("subscription", true)
+	m.RecordCacheLookup("subscription", false)
+	m.RecordCacheLookup("subscription", false)
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `subscriptions_cache_lookups_total{cache="subscription",result="hit"} 1`)
+	assert.Contains(t, body, `subscriptions_cache_lookups_total{cache="subscription",result="miss"} 2`)
+}
+
+func TestGormPlugin_ObservesQueries(t *testing.T) {
+	gormDB, err := gormdb.NewGormDatabase(&config.DatabaseConfig{Driver: "sqlite", Database: ":memory:"})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, gormDB.Dispose()) })
+	require.NoError(t, gormDB.Migrate())
+
+	m := metrics.NewMetrics()
+	db := gormDB.GetDB()
+	require.NoError(t, db.Use(metrics.NewGormPlugin(m)))
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	m.RegisterDBStats(sqlDB, "test")
+
+	var count int64
+	require.NoError(t, db.Table("subscriptions").Count(&count).Error)
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `subscriptions_db_query_duration_seconds_count{operation="query",table="subscriptions"} 1`)
+	assert.Contains(t, body, `go_sql_max_open_connections{db_name="test"} 1`)
+}
@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit is a token bucket that holds Burst tokens and refills at Rate tokens per second.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Unlimited reports whether the limit lets every request through.
+func (l Limit) Unlimited() bool {
+	return l.Rate <= 0
+}
+
+// Result is the state of a bucket after a request took a token from it.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+	// RetryAfter is the time until the next token, if the request wasn't allowed.
+	RetryAfter time.Duration
+}
+
+// Store keeps the buckets of all clients.
+type Store interface {
+	// Take takes a token from the bucket of key, refilled up to now.
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+}
+
+// refill returns the tokens of a bucket that held tokens at last.
+func refill(limit Limit, tokens float64, last, now time.Time) float64 {
+	elapsed := now.Sub(last).Seconds()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	return math.Min(float64(limit.Burst), tokens+elapsed*limit.Rate)
+}
+
+// take takes a token from a bucket holding tokens and returns the tokens left.
+func take(limit Limit, tokens float64) (Result, float64) {
+	result := Result{Allowed: tokens >= 1, Limit: limit.Burst}
+	if result.Allowed {
+		tokens--
+	} else {
+		result.RetryAfter = seconds((1 - tokens) / limit.Rate)
+	}
+
+	result.Remaining = int(math.Floor(tokens))
+	result.Reset = seconds((float64(limit.Burst) - tokens) / limit.Rate)
+	return result, tokens
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
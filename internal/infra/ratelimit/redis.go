@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// takeScript refills and takes from the bucket atomically. It returns the
+// tokens left and whether one was taken; Lua numbers are returned as strings
+// since Redis truncates them to integers.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "last")
+local tokens = tonumber(state[1]) or burst
+local last = tonumber(state[2]) or now
+
+tokens = math.min(burst, tokens + math.max(0, now - last) / 1000 * rate)
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "last", tostring(now))
+redis.call("PEXPIRE", KEYS[1], math.ceil((burst - tokens) / rate * 1000) + 1000)
+return {allowed, tostring(tokens)}
+`)
+
+type redisStore struct {
+	client redis.Scripter
+	prefix string
+}
+
+// NewRedisStore keeps the buckets in Redis, so that instances of the service
+// share them. Buckets expire once they are full again.
+func NewRedisStore(client redis.Scripter) Store {
+	return &redisStore{client: client, prefix: "ratelimit:"}
+}
+
+func (s *redisStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	reply, err := takeScript.Run(ctx, s.client, []string{s.prefix + key},
+		limit.Rate, limit.Burst, now.UnixMilli(),
+	).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("redis rate limit: %w", err)
+	}
+	if len(reply) != 2 {
+		return Result{}, fmt.Errorf("redis rate limit: unexpected reply %v", reply)
+	}
+
+	left, err := strconv.ParseFloat(fmt.Sprint(reply[1]), 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("redis rate limit: %w", err)
+	}
+
+	// take is replayed on the tokens before the request to build the result.
+	before := left
+	if reply[0] == int64(1) {
+		before++
+	}
+	result, _ := take(limit, math.Min(before, float64(limit.Burst)))
+	return result, nil
+}
@@ -0,0 +1,113 @@
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/infra/ratelimit"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+
+// stores runs test against every store, Redis being stood in by miniredis.
+func stores(t *testing.T, test func(t *testing.T, store ratelimit.Store)) {
+	t.Run("memory", func(t *testing.T) {
+		test(t, ratelimit.NewMemoryStore())
+	})
+
+	t.Run("redis", func(t *testing.T) {
+		server := miniredis.RunT(t)
+		client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+		t.Cleanup(func() { _ = client.Close() })
+
+		test(t, ratelimit.NewRedisStore(client))
+	})
+}
+
+func TestStore_Take_BurstThenDenied(t *testing.T) {
+	stores(t, func(t *testing.T, store ratelimit.Store) {
+		limit := ratelimit.Limit{Rate: 1, Burst: 3}
+
+		for remaining := 2; remaining >= 0; remaining-- {
+			result, err := store.Take(t.Context(), "client", limit, start)
+
+			require.NoError(t, err)
+			assert.True(t, result.Allowed)
+			assert.Equal(t, 3, result.Limit)
+			assert.Equal(t, remaining, result.Remaining)
+		}
+
+		result, err := store.Take(t.Context(), "client", limit, start)
+
+		require.NoError(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 0, result.Remaining)
+		assert.Equal(t, time.Second, result.RetryAfter)
+		assert.Equal(t, 3*time.Second, result.Reset)
+	})
+}
+
+func TestStore_Take_Refills(t *testing.T) {
+	stores(t, func(t *testing.T, store ratelimit.Store) {
+		limit := ratelimit.Limit{Rate: 2, Burst: 2}
+
+		for range 2 {
+			_, err := store.Take(t.Context(), "client", limit, start)
+			require.NoError(t, err)
+		}
+
+		result, err := store.Take(t.Context(), "client", limit, start.Add(500*time.Millisecond))
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+
+		result, err = store.Take(t.Context(), "client", limit, start.Add(time.Hour))
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 1, result.Remaining, "tokens never exceed the burst")
+	})
+}
+
+func TestStore_Take_KeysIsolated(t *testing.T) {
+	stores(t, func(t *testing.T, store ratelimit.Store) {
+		limit := ratelimit.Limit{Rate: 1, Burst: 1}
+
+		first, err := store.Take(t.Context(), "first", limit, start)
+		require.NoError(t, err)
+		second, err := store.Take(t.Context(), "second", limit, start)
+		require.NoError(t, err)
+
+		assert.True(t, first.Allowed)
+		assert.True(t, second.Allowed)
+	})
+}
+
+func TestRedisStore_Take_BucketExpires(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	store := ratelimit.NewRedisStore(client)
+
+	_, err := store.Take(t.Context(), "client", ratelimit.Limit{Rate: 1, Burst: 5}, start)
+	require.NoError(t, err)
+
+	server.FastForward(10 * time.Second)
+
+	assert.False(t, server.Exists("ratelimit:client"))
+}
+
+func TestRedisStore_Take_Unavailable(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+	store := ratelimit.NewRedisStore(client)
+
+	server.Close()
+
+	_, err := store.Take(t.Context(), "client", ratelimit.Limit{Rate: 1, Burst: 1}, start)
+
+	assert.Error(t, err)
+}
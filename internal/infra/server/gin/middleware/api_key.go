@@ -0,0 +1,51 @@
+package gin
+
+import (
+	"errors"
+
+	"github.com/MDx3R/ef-test/internal/transport/http/problem"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+// NewAPIKeyMiddleware authenticates requests carrying an API key and puts
+// the client into the request context. Requests without the header are
+// passed on unchanged, so the key can be combined with other authentication.
+func NewAPIKeyMiddleware(service usecase.APIKeyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(APIKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		principal, err := service.Authenticate(c.Request.Context(), key)
+		if errors.Is(err, usecase.ErrUnauthorized) {
+			problem.Respond(c, problem.CodeInvalidAPIKey, "")
+			return
+		}
+		if err != nil {
+			problem.RespondError(c, err)
+			return
+		}
+
+		c.Request = c.Request.WithContext(usecase.WithPrincipal(c.Request.Context(), principal))
+
+		c.Next()
+	}
+}
+
+// RequireScope rejects clients whose credentials do not grant scope.
+// Requests from users and unauthenticated requests are passed on,
+// since they are authorized by other means.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if p, ok := usecase.PrincipalFrom(c.Request.Context()); ok && !p.HasScope(scope) {
+			problem.Respond(c, problem.CodeMissingScope, "missing scope "+scope)
+			return
+		}
+		c.Next()
+	}
+}
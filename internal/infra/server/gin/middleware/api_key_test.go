@@ -0,0 +1,95 @@
+package gin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MDx3R/ef-test/internal/config"
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	ginware "github.com/MDx3R/ef-test/internal/infra/server/gin/middleware"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	mock_usecase "github.com/MDx3R/ef-test/internal/usecase/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var readClient = usecase.Principal{
+	Subject: "123e4567-e89b-12d3-a456-426614174000",
+	Client:  true,
+	Scopes:  []string{entity.ScopeSubscriptionsRead},
+}
+
+// setupAPIKeyRouter mounts the middleware the way the app does:
+// an API key first, then a JWT, then the scope of the route.
+func setupAPIKeyRouter(t *testing.T, scope string) (*gin.Engine, *mock_usecase.MockAPIKeyService) {
+	gin.SetMode(gin.TestMode)
+
+	service := mock_usecase.NewMockAPIKeyService(t)
+	jwtAuth, err := ginware.NewJWTMiddleware(&config.AuthConfig{Secret: testSecret, RolesClaim: "roles"})
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.GET("/", ginware.NewAPIKeyMiddleware(service), jwtAuth, ginware.RequireScope(scope), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return r, service
+}
+
+func doAPIKeyRequest(r *gin.Engine, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(ginware.APIKeyHeader, key)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestAPIKeyMiddleware_ValidKey(t *testing.T) {
+	r, service := setupAPIKeyRouter(t, entity.ScopeSubscriptionsRead)
+	service.On("Authenticate", mock.Anything, "sk_valid").Return(readClient, nil)
+
+	w := doAPIKeyRequest(r, "sk_valid")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAPIKeyMiddleware_InvalidKey(t *testing.T) {
+	r, service := setupAPIKeyRouter(t, entity.ScopeSubscriptionsRead)
+	service.On("Authenticate", mock.Anything, "sk_revoked").Return(usecase.Principal{}, usecase.ErrUnauthorized)
+
+	w := doAPIKeyRequest(r, "sk_revoked")
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"auth.invalid_api_key"`)
+}
+
+func TestAPIKeyMiddleware_MissingScope(t *testing.T) {
+	r, service := setupAPIKeyRouter(t, entity.ScopeSubscriptionsWrite)
+	service.On("Authenticate", mock.Anything, "sk_valid").Return(readClient, nil)
+
+	w := doAPIKeyRequest(r, "sk_valid")
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "missing scope subscriptions:write")
+}
+
+func TestAPIKeyMiddleware_FallsBackToJWT(t *testing.T) {
+	r, _ := setupAPIKeyRouter(t, entity.ScopeSubscriptionsWrite)
+	token := sign(t, jwt.SigningMethodHS256, []byte(testSecret), validClaims(), "")
+
+	w := doRequest(r, "Bearer "+token)
+
+	// Users are not limited by scopes.
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAPIKeyMiddleware_NoCredentials(t *testing.T) {
+	r, _ := setupAPIKeyRouter(t, entity.ScopeSubscriptionsRead)
+
+	w := doRequest(r, "")
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,153 @@
+package gin
+
+import (
+	"crypto"
+	"fmt"
+	"strings"
+
+	"github.com/MDx3R/ef-test/internal/config"
+	"github.com/MDx3R/ef-test/internal/transport/http/problem"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// NewJWTMiddleware authenticates requests by a bearer JWT and puts the caller
+// into the request context as a usecase.Principal with the token subject.
+// Tokens must be signed, not expired and carry the exp and sub claims.
+// Requests already authenticated by an earlier middleware, such as
+// an API key, are passed on without a token.
+func NewJWTMiddleware(cfg *config.AuthConfig) (gin.HandlerFunc, error) {
+	keyfunc, methods, err := newKeyfunc(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(methods),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+	parser := jwt.NewParser(options...)
+
+	return func(c *gin.Context) {
+		if _, ok := usecase.PrincipalFrom(c.Request.Context()); ok {
+			c.Next()
+			return
+		}
+
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			unauthorized(c, "missing bearer token")
+			return
+		}
+
+		claims := jwt.MapClaims{}
+		if _, err := parser.ParseWithClaims(token, claims, keyfunc); err != nil {
+			unauthorized(c, "invalid token")
+			return
+		}
+
+		subject, err := claims.GetSubject()
+		if err != nil || subject == "" {
+			unauthorized(c, "token has no subject")
+			return
+		}
+
+		tenant, _ := claims[cfg.TenantClaim].(string)
+		principal := usecase.Principal{
+			Subject: subject,
+			Roles:   roles(claims[cfg.RolesClaim]),
+			Tenant:  tenant,
+		}
+		c.Request = c.Request.WithContext(usecase.WithPrincipal(c.Request.Context(), principal))
+
+		c.Next()
+	}, nil
+}
+
+// newKeyfunc selects the verification key by the token algorithm,
+// so a token can't make an RSA public key be used as an HMAC secret.
+func newKeyfunc(cfg *config.AuthConfig) (jwt.Keyfunc, []string, error) {
+	var methods []string
+
+	secret := []byte(cfg.Secret)
+	if len(secret) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+
+	var keys map[string]crypto.PublicKey
+	if cfg.JWKSFile != "" {
+		var err error
+		if keys, err = loadJWKS(cfg.JWKSFile); err != nil {
+			return nil, nil, err
+		}
+		methods = append(methods,
+			jwt.SigningMethodRS256.Alg(),
+			jwt.SigningMethodES256.Alg(),
+			jwt.SigningMethodES384.Alg(),
+			jwt.SigningMethodES512.Alg(),
+		)
+	}
+
+	if len(methods) == 0 {
+		return nil, nil, fmt.Errorf("auth requires a jwt secret or a jwks file")
+	}
+
+	keyfunc := func(token *jwt.Token) (any, error) {
+		if token.Method == jwt.SigningMethodHS256 {
+			return secret, nil
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		if key, ok := keys[kid]; ok {
+			return key, nil
+		}
+		// A token without kid is accepted when there is only one key to pick.
+		if kid == "" && len(keys) == 1 {
+			for _, key := range keys {
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+
+	return keyfunc, methods, nil
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// roles reads a roles claim given either as a single string or as a list.
+func roles(claim any) []string {
+	switch v := claim.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return nil
+	}
+}
+
+func unauthorized(c *gin.Context, message string) {
+	c.Header("WWW-Authenticate", `Bearer realm="api"`)
+	problem.Respond(c, problem.CodeUnauthenticated, message)
+}
@@ -0,0 +1,254 @@
+package gin_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/config"
+	ginware "github.com/MDx3R/ef-test/internal/infra/server/gin/middleware"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret"
+
+func setupAuthRouter(t *testing.T, cfg *config.AuthConfig) (*gin.Engine, *usecase.Principal) {
+	gin.SetMode(gin.TestMode)
+
+	auth, err := ginware.NewJWTMiddleware(cfg)
+	require.NoError(t, err)
+
+	var principal usecase.Principal
+	r := gin.New()
+	r.GET("/", auth, func(c *gin.Context) {
+		principal, _ = usecase.PrincipalFrom(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	return r, &principal
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub": "123e4567-e89b-12d3-a456-426614174000",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, claims jwt.MapClaims, kid string) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	s, err := token.SignedString(key)
+	require.NoError(t, err)
+	return s
+}
+
+func doRequest(r *gin.Engine, authorization string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func writeJWKS(t *testing.T, keys ...map[string]string) string {
+	data, err := json.Marshal(map[string]any{"keys": keys})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func TestJWTMiddleware_HS256_Success(t *testing.T) {
+	router, principal := setupAuthRouter(t, &config.AuthConfig{Secret: testSecret, RolesClaim: "roles"})
+
+	claims := validClaims()
+	claims["roles"] = []string{"admin"}
+
+	w := doRequest(router, "Bearer "+sign(t, jwt.SigningMethodHS256, []byte(testSecret), claims, ""))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "123e4567-e89b-12d3-a456-426614174000", principal.Subject)
+	assert.True(t, principal.IsAdmin())
+}
+
+func TestJWTMiddleware_SingleRole(t *testing.T) {
+	router, principal := setupAuthRouter(t, &config.AuthConfig{Secret: testSecret, RolesClaim: "role"})
+
+	claims := validClaims()
+	claims["role"] = "admin"
+
+	w := doRequest(router, "Bearer "+sign(t, jwt.SigningMethodHS256, []byte(testSecret), claims, ""))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, principal.IsAdmin())
+}
+
+func TestJWTMiddleware_TenantClaim(t *testing.T) {
+	router, principal := setupAuthRouter(t, &config.AuthConfig{Secret: testSecret, TenantClaim: "org"})
+
+	claims := validClaims()
+	claims["org"] = "retail"
+
+	w := doRequest(router, "Bearer "+sign(t, jwt.SigningMethodHS256, []byte(testSecret), claims, ""))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "retail", principal.Tenant)
+}
+
+func TestJWTMiddleware_Rejects(t *testing.T) {
+	router, _ := setupAuthRouter(t, &config.AuthConfig{
+		Secret:     testSecret,
+		Issuer:     "issuer",
+		Audience:   "api",
+		RolesClaim: "roles",
+	})
+
+	withClaims := func(edit func(jwt.MapClaims)) jwt.MapClaims {
+		claims := validClaims()
+		claims["iss"] = "issuer"
+		claims["aud"] = "api"
+		edit(claims)
+		return claims
+	}
+	hs256 := func(claims jwt.MapClaims) string {
+		return "Bearer " + sign(t, jwt.SigningMethodHS256, []byte(testSecret), claims, "")
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+	}{
+		{"missing header", ""},
+		{"wrong scheme", "Basic dXNlcjpwYXNz"},
+		{"empty token", "Bearer "},
+		{"malformed token", "Bearer not.a.token"},
+		{"wrong secret", "Bearer " + sign(t, jwt.SigningMethodHS256, []byte("other"), withClaims(func(jwt.MapClaims) {}), "")},
+		{"expired", hs256(withClaims(func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() }))},
+		{"no expiry", hs256(withClaims(func(c jwt.MapClaims) { delete(c, "exp") }))},
+		{"no subject", hs256(withClaims(func(c jwt.MapClaims) { delete(c, "sub") }))},
+		{"wrong issuer", hs256(withClaims(func(c jwt.MapClaims) { c["iss"] = "other" }))},
+		{"wrong audience", hs256(withClaims(func(c jwt.MapClaims) { c["aud"] = "other" }))},
+		{"alg none", "Bearer " + sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, withClaims(func(jwt.MapClaims) {}), "")},
+		{"unexpected alg", "Bearer " + sign(t, jwt.SigningMethodHS512, []byte(testSecret), withClaims(func(jwt.MapClaims) {}), "")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := doRequest(router, tt.authorization)
+
+			assert.Equal(t, http.StatusUnauthorized, w.Code)
+			assert.Contains(t, w.Header().Get("WWW-Authenticate"), "Bearer")
+			assert.Contains(t, w.Body.String(), `"code":"auth.unauthenticated"`)
+		})
+	}
+}
+
+func TestJWTMiddleware_JWKS_RS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	path := writeJWKS(t, map[string]string{
+		"kty": "RSA",
+		"kid": "rsa-1",
+		"use": "sig",
+		"n":   b64(key.N.Bytes()),
+		"e":   b64(big.NewInt(int64(key.E)).Bytes()),
+	})
+	router, principal := setupAuthRouter(t, &config.AuthConfig{JWKSFile: path, RolesClaim: "roles"})
+
+	t.Run("known kid", func(t *testing.T) {
+		w := doRequest(router, "Bearer "+sign(t, jwt.SigningMethodRS256, key, validClaims(), "rsa-1"))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "123e4567-e89b-12d3-a456-426614174000", principal.Subject)
+	})
+
+	t.Run("single key without kid", func(t *testing.T) {
+		w := doRequest(router, "Bearer "+sign(t, jwt.SigningMethodRS256, key, validClaims(), ""))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("unknown kid", func(t *testing.T) {
+		w := doRequest(router, "Bearer "+sign(t, jwt.SigningMethodRS256, key, validClaims(), "rsa-2"))
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("HS256 without secret", func(t *testing.T) {
+		// The public key must never be used as an HMAC secret.
+		w := doRequest(router, "Bearer "+sign(t, jwt.SigningMethodHS256, key.N.Bytes(), validClaims(), "rsa-1"))
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestJWTMiddleware_JWKS_ES256(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	path := writeJWKS(t, map[string]string{
+		"kty": "EC",
+		"kid": "ec-1",
+		"crv": "P-256",
+		"x":   b64(key.X.FillBytes(make([]byte, 32))),
+		"y":   b64(key.Y.FillBytes(make([]byte, 32))),
+	})
+	router, _ := setupAuthRouter(t, &config.AuthConfig{JWKSFile: path, RolesClaim: "roles"})
+
+	w := doRequest(router, "Bearer "+sign(t, jwt.SigningMethodES256, key, validClaims(), "ec-1"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestNewJWTMiddleware_InvalidConfig(t *testing.T) {
+	t.Run("no keys", func(t *testing.T) {
+		_, err := ginware.NewJWTMiddleware(&config.AuthConfig{})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("missing jwks file", func(t *testing.T) {
+		_, err := ginware.NewJWTMiddleware(&config.AuthConfig{JWKSFile: filepath.Join(t.TempDir(), "missing.json")})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("point not on curve", func(t *testing.T) {
+		path := writeJWKS(t, map[string]string{
+			"kty": "EC",
+			"kid": "ec-1",
+			"crv": "P-256",
+			"x":   b64(make([]byte, 32)),
+			"y":   b64(make([]byte, 32)),
+		})
+
+		_, err := ginware.NewJWTMiddleware(&config.AuthConfig{JWKSFile: path})
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,36 @@
+package gin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/MDx3R/ef-test/internal/config"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	DeprecationHeader = "Deprecation"
+	SunsetHeader      = "Sunset"
+)
+
+// NewDeprecationMiddleware announces the retirement of the routes it guards
+// with the Deprecation (RFC 9745) and Sunset (RFC 8594) headers.
+func NewDeprecationMiddleware(cfg *config.DeprecationConfig) gin.HandlerFunc {
+	var deprecation, sunset string
+	if !cfg.DeprecatedAt.IsZero() {
+		deprecation = fmt.Sprintf("@%d", cfg.DeprecatedAt.Unix())
+	}
+	if !cfg.SunsetAt.IsZero() {
+		sunset = cfg.SunsetAt.UTC().Format(http.TimeFormat)
+	}
+
+	return func(c *gin.Context) {
+		if deprecation != "" {
+			c.Header(DeprecationHeader, deprecation)
+		}
+		if sunset != "" {
+			c.Header(SunsetHeader, sunset)
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,45 @@
+package gin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/config"
+	ginware "github.com/MDx3R/ef-test/internal/infra/server/gin/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func doDeprecatedRequest(cfg *config.DeprecationConfig) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(ginware.NewDeprecationMiddleware(cfg))
+	r.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	return w
+}
+
+func TestDeprecationMiddleware_Announces(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*60*60)
+	w := doDeprecatedRequest(&config.DeprecationConfig{
+		DeprecatedAt: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+		SunsetAt:     time.Date(2027, 5, 1, 3, 0, 0, 0, moscow),
+	})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "@1793491200", w.Header().Get(ginware.DeprecationHeader))
+	assert.Equal(t, "Sat, 01 May 2027 00:00:00 GMT", w.Header().Get(ginware.SunsetHeader))
+}
+
+func TestDeprecationMiddleware_ZeroTimesOmitted(t *testing.T) {
+	w := doDeprecatedRequest(&config.DeprecationConfig{})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Header(), ginware.DeprecationHeader)
+	assert.NotContains(t, w.Header(), ginware.SunsetHeader)
+}
@@ -0,0 +1,130 @@
+package gin
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/MDx3R/ef-test/internal/config"
+	"github.com/MDx3R/ef-test/internal/transport/http/problem"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	usecasedto "github.com/MDx3R/ef-test/internal/usecase/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader marks responses repeated from an earlier request.
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+)
+
+// NewIdempotencyMiddleware makes retries of POST and PATCH requests carrying
+// an Idempotency-Key header return the response of the first request instead
+// of repeating it. Other methods are idempotent by themselves. Server errors
+// and panics are not stored, so such requests may be retried with the same key.
+// It must run after authentication and tenant resolution, which scope the keys.
+func NewIdempotencyMiddleware(cfg *config.IdempotencyConfig, service usecase.IdempotencyService, logger *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || (c.Request.Method != http.MethodPost && c.Request.Method != http.MethodPatch) {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			problem.Respond(c, problem.CodeIdempotencyKeyTooLong, "")
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, cfg.MaxBodySize))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			problem.Respond(c, problem.CodeRequestTooLarge, "")
+			return
+		}
+		if err != nil {
+			problem.Respond(c, problem.CodeInvalidRequest, "failed to read request body")
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		replay, err := service.Begin(ctx, key, requestHash(c.Request, body))
+		switch {
+		case err != nil:
+			problem.RespondError(c, err)
+			return
+		case replay != nil:
+			c.Header(IdempotentReplayedHeader, "true")
+			c.Data(replay.StatusCode, replay.ContentType, replay.Body)
+			c.Abort()
+			return
+		}
+
+		recorder := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		// Deferred, so that the key is released if the handler panics too.
+		handled := false
+		defer func() {
+			finish(c, service, logger, key, recorder, handled)
+		}()
+
+		c.Next()
+		handled = true
+	}
+}
+
+// finish stores the response of a handled request or releases the key of one
+// that failed, even if the client has gone away meanwhile.
+func finish(c *gin.Context, service usecase.IdempotencyService, logger *logrus.Logger, key string, recorder *bodyRecorder, handled bool) {
+	ctx := context.WithoutCancel(c.Request.Context())
+	entry := requestLogger(c, logger).WithField("path", c.FullPath())
+
+	if !handled || recorder.Status() >= http.StatusInternalServerError {
+		if err := service.Abandon(ctx, key); err != nil {
+			entry.WithError(err).Error("failed to release idempotency key")
+		}
+		return
+	}
+
+	err := service.Complete(ctx, key, usecasedto.IdempotentResponse{
+		StatusCode:  recorder.Status(),
+		ContentType: recorder.Header().Get("Content-Type"),
+		Body:        recorder.body.Bytes(),
+	})
+	if err != nil {
+		entry.WithError(err).Error("failed to store idempotent response")
+	}
+}
+
+// requestHash tells apart requests reusing a key. It covers the target
+// as well as the body, so a key can't be moved to another endpoint.
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// bodyRecorder keeps a copy of the response body.
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,176 @@
+package gin_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/config"
+	"github.com/MDx3R/ef-test/internal/infra/database/memory"
+	ginware "github.com/MDx3R/ef-test/internal/infra/server/gin/middleware"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	mock_usecase "github.com/MDx3R/ef-test/internal/usecase/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// setupIdempotencyRouter counts the requests reaching the handler, which
+// answers with the status of the X-Status header and a body numbering the call.
+func setupIdempotencyRouter(service usecase.IdempotencyService) (*gin.Engine, *atomic.Int32) {
+	gin.SetMode(gin.TestMode)
+
+	var calls atomic.Int32
+	handler := func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		n := calls.Add(1)
+
+		status := http.StatusCreated
+		switch c.GetHeader("X-Status") {
+		case "500":
+			status = http.StatusInternalServerError
+		case "panic":
+			panic("handler failed")
+		}
+		c.JSON(status, gin.H{"call": n, "body": string(body)})
+	}
+
+	r := gin.New()
+	r.Use(gin.CustomRecovery(func(c *gin.Context, _ any) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	r.Use(ginware.NewIdempotencyMiddleware(&config.IdempotencyConfig{MaxBodySize: 64}, service, logger))
+	r.POST("/subscriptions", handler)
+	r.PUT("/subscriptions/:id", handler)
+	return r, &calls
+}
+
+func doIdempotentRequest(r *gin.Engine, method, path, key, body string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(ginware.IdempotencyKeyHeader, key)
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func newIdempotencyService() usecase.IdempotencyService {
+	return usecase.NewIdempotencyService(memory.NewMemoryIdempotencyRepository(), time.Hour, time.Minute)
+}
+
+func TestIdempotencyMiddleware_ReplaysResponse(t *testing.T) {
+	router, calls := setupIdempotencyRouter(newIdempotencyService())
+
+	first := doIdempotentRequest(router, http.MethodPost, "/subscriptions", "key", `{"price":100}`)
+	retry := doIdempotentRequest(router, http.MethodPost, "/subscriptions", "key", `{"price":100}`)
+
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(ginware.IdempotentReplayedHeader))
+
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get(ginware.IdempotentReplayedHeader))
+	assert.Equal(t, first.Header().Get("Content-Type"), retry.Header().Get("Content-Type"))
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+}
+
+func TestIdempotencyMiddleware_DifferentBody(t *testing.T) {
+	router, calls := setupIdempotencyRouter(newIdempotencyService())
+
+	doIdempotentRequest(router, http.MethodPost, "/subscriptions", "key", `{"price":100}`)
+	w := doIdempotentRequest(router, http.MethodPost, "/subscriptions", "key", `{"price":200}`)
+
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"idempotency.key_reused"`)
+}
+
+func TestIdempotencyMiddleware_KeysIndependent(t *testing.T) {
+	router, calls := setupIdempotencyRouter(newIdempotencyService())
+
+	doIdempotentRequest(router, http.MethodPost, "/subscriptions", "key-1", `{}`)
+	doIdempotentRequest(router, http.MethodPost, "/subscriptions", "key-2", `{}`)
+	doIdempotentRequest(router, http.MethodPost, "/subscriptions", "", `{}`)
+	doIdempotentRequest(router, http.MethodPost, "/subscriptions", "", `{}`)
+
+	assert.Equal(t, int32(4), calls.Load())
+}
+
+func TestIdempotencyMiddleware_IdempotentMethodsPassed(t *testing.T) {
+	router, calls := setupIdempotencyRouter(newIdempotencyService())
+
+	doIdempotentRequest(router, http.MethodPut, "/subscriptions/1", "key", `{}`)
+	w := doIdempotentRequest(router, http.MethodPut, "/subscriptions/1", "key", `{}`)
+
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Empty(t, w.Header().Get(ginware.IdempotentReplayedHeader))
+}
+
+func TestIdempotencyMiddleware_ServerErrorRetried(t *testing.T) {
+	router, calls := setupIdempotencyRouter(newIdempotencyService())
+
+	failed := doIdempotentRequest(router, http.MethodPost, "/subscriptions", "key", `{}`, "X-Status", "500")
+	retry := doIdempotentRequest(router, http.MethodPost, "/subscriptions", "key", `{}`)
+
+	assert.Equal(t, http.StatusInternalServerError, failed.Code)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestIdempotencyMiddleware_PanicRetried(t *testing.T) {
+	router, calls := setupIdempotencyRouter(newIdempotencyService())
+
+	failed := doIdempotentRequest(router, http.MethodPost, "/subscriptions", "key", `{}`, "X-Status", "panic")
+	retry := doIdempotentRequest(router, http.MethodPost, "/subscriptions", "key", `{}`)
+
+	assert.Equal(t, http.StatusInternalServerError, failed.Code)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestIdempotencyMiddleware_BodyTooLarge(t *testing.T) {
+	router, calls := setupIdempotencyRouter(newIdempotencyService())
+
+	w := doIdempotentRequest(router, http.MethodPost, "/subscriptions", "key", strings.Repeat("x", 65))
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"request.too_large"`)
+	assert.Zero(t, calls.Load())
+}
+
+func TestIdempotencyMiddleware_Rejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    string
+		err    error
+		status int
+	}{
+		{"key too long", strings.Repeat("k", 256), nil, http.StatusBadRequest},
+		{"in process", "key", usecase.ErrIdempotencyKeyInProcess, http.StatusConflict},
+		{"repository error", "key", usecase.ErrRepository, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := mock_usecase.NewMockIdempotencyService(t)
+			if tt.err != nil {
+				mockService.On("Begin", mock.Anything, tt.key, mock.Anything).Return(nil, tt.err)
+			}
+			router, calls := setupIdempotencyRouter(mockService)
+
+			w := doIdempotentRequest(router, http.MethodPost, "/subscriptions", tt.key, `{}`)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Zero(t, calls.Load())
+		})
+	}
+}
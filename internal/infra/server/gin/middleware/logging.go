@@ -7,6 +7,9 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// LoggerMiddleware logs every handled request. Registered after
+// NewRequestIDMiddleware, it logs through the entry of the request, so the
+// line also names the request ID and the authenticated user.
 func LoggerMiddleware(logger *logrus.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
@@ -24,12 +27,14 @@ func LoggerMiddleware(logger *logrus.Logger) gin.HandlerFunc {
 			path = path + "?" + raw
 		}
 
-		entry := logger.WithFields(logrus.Fields{
+		// The context carries the span, so the entry gets the trace ID.
+		entry := requestLogger(c, logger).WithFields(logrus.Fields{
 			"status":  status,
 			"method":  method,
 			"path":    path,
 			"ip":      clientIP,
 			"latency": latency,
+			"size":    max(c.Writer.Size(), 0),
 		})
 
 		if status >= 500 {
@@ -0,0 +1,42 @@
+package gin
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/infra/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute labels requests no route matched, so that arbitrary paths
+// don't create a series each.
+const unmatchedRoute = "unmatched"
+
+// otherMethod labels requests with methods outside of standardMethods,
+// since clients may send any verb.
+const otherMethod = "OTHER"
+
+var standardMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace,
+}
+
+// NewMetricsMiddleware records the count and latency of requests per route and status.
+func NewMetricsMiddleware(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		method := c.Request.Method
+		if !slices.Contains(standardMethods, method) {
+			method = otherMethod
+		}
+		m.ObserveHTTPRequest(method, route, c.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,68 @@
+package gin_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MDx3R/ef-test/internal/infra/metrics"
+	ginware "github.com/MDx3R/ef-test/internal/infra/server/gin/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupMetricsRouter() (*gin.Engine, *metrics.Metrics) {
+	gin.SetMode(gin.TestMode)
+
+	m := metrics.NewMetrics()
+	r := gin.New()
+	r.Use(ginware.NewMetricsMiddleware(m))
+	r.GET("/subscriptions/:id", func(c *gin.Context) {
+		c.Status(http.StatusNotFound)
+	})
+	return r, m
+}
+
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	body, err := io.ReadAll(w.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestMetricsMiddleware_LabelsRoutePattern(t *testing.T) {
+	router, m := setupMetricsRouter()
+
+	for _, id := range []string{"a", "b"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/subscriptions/"+id, nil))
+	}
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `subscriptions_http_requests_total{method="GET",route="/subscriptions/:id",status="404"} 2`)
+	assert.Contains(t, body, `subscriptions_http_request_duration_seconds_count{method="GET",route="/subscriptions/:id",status="404"} 2`)
+}
+
+func TestMetricsMiddleware_Unmatched(t *testing.T) {
+	router, m := setupMetricsRouter()
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/no/such/path", nil))
+
+	assert.Contains(t, scrape(t, m), `subscriptions_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+}
+
+func TestMetricsMiddleware_NonStandardMethod(t *testing.T) {
+	router, m := setupMetricsRouter()
+
+	for _, method := range []string{"FOO", "BAR"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, "/no/such/path", nil))
+	}
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `subscriptions_http_requests_total{method="OTHER",route="unmatched",status="404"} 2`)
+	assert.NotContains(t, body, `method="FOO"`)
+}
@@ -0,0 +1,113 @@
+package gin
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/config"
+	"github.com/MDx3R/ef-test/internal/infra/ratelimit"
+	"github.com/MDx3R/ef-test/internal/transport/http/problem"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	RateLimitLimitHeader     = "RateLimit-Limit"
+	RateLimitRemainingHeader = "RateLimit-Remaining"
+	RateLimitResetHeader     = "RateLimit-Reset"
+	RateLimitPolicyHeader    = "RateLimit-Policy"
+)
+
+// NewRateLimitMiddleware limits the requests every client makes to a route.
+// It must run after authentication and tenant resolution: clients are told
+// apart by API key, user or, for anonymous requests, IP address, and their
+// limits depend on the tenant. Requests are let through when the store fails.
+func NewRateLimitMiddleware(
+	cfg *config.RateLimitConfig,
+	tenancy *config.TenancyConfig,
+	store ratelimit.Store,
+	logger *logrus.Logger,
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		route := routeOf(c)
+		tenantID := usecase.TenantFrom(ctx).ID
+		client, clientID := clientOf(c)
+
+		limit := limitOf(cfg, tenancy, tenantID, route, clientID)
+		if limit.Unlimited() {
+			c.Next()
+			return
+		}
+
+		result, err := store.Take(ctx, tenantID+":"+route+":"+client, limit, time.Now())
+		if err != nil {
+			requestLogger(c, logger).WithError(err).WithField("route", route).Warn("rate limit store failed, request let through")
+			c.Next()
+			return
+		}
+
+		c.Header(RateLimitLimitHeader, strconv.Itoa(result.Limit))
+		c.Header(RateLimitRemainingHeader, strconv.Itoa(result.Remaining))
+		c.Header(RateLimitResetHeader, ceilSeconds(result.Reset))
+		// The policy names the window in which a full bucket refills.
+		window := time.Duration(float64(limit.Burst) / limit.Rate * float64(time.Second))
+		c.Header(RateLimitPolicyHeader, fmt.Sprintf("%d;w=%s", limit.Burst, ceilSeconds(window)))
+
+		if !result.Allowed {
+			c.Header("Retry-After", ceilSeconds(result.RetryAfter))
+			problem.Respond(c, problem.CodeRateLimited, "")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// clientOf returns the bucket key of the caller and the ID its limits are configured by.
+func clientOf(c *gin.Context) (string, string) {
+	p, ok := usecase.PrincipalFrom(c.Request.Context())
+	switch {
+	case ok && p.Client:
+		return "key:" + p.Subject, p.Subject
+	case ok:
+		return "user:" + p.Subject, p.Subject
+	default:
+		return "ip:" + c.ClientIP(), c.ClientIP()
+	}
+}
+
+// versionPrefix matches the API version a route is mounted under.
+var versionPrefix = regexp.MustCompile(`^/v[0-9]+/`)
+
+// routeOf names the route of the request regardless of its API version,
+// so that the versions of a route share its limits and buckets.
+func routeOf(c *gin.Context) string {
+	return c.Request.Method + " " + versionPrefix.ReplaceAllString(c.FullPath(), "/")
+}
+
+// limitOf looks up the limit of a client on a route: client overrides win
+// over route overrides, which win over the tenant settings.
+func limitOf(cfg *config.RateLimitConfig, tenancy *config.TenancyConfig, tenantID, route, clientID string) ratelimit.Limit {
+	settings := tenancy.Settings(tenantID).RateLimit
+	for _, override := range []config.RateLimitSettings{cfg.Routes[route], cfg.Clients[clientID]} {
+		if override.RequestsPerSecond > 0 {
+			settings.RequestsPerSecond = override.RequestsPerSecond
+		}
+		if override.Burst > 0 {
+			settings.Burst = override.Burst
+		}
+	}
+
+	// A bucket must hold at least one request to let any through.
+	burst := max(settings.Burst, 1)
+	return ratelimit.Limit{Rate: settings.RequestsPerSecond, Burst: burst}
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
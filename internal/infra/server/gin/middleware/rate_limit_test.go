@@ -0,0 +1,169 @@
+package gin_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/config"
+	logruslogger "github.com/MDx3R/ef-test/internal/infra/logger"
+	"github.com/MDx3R/ef-test/internal/infra/ratelimit"
+	ginware "github.com/MDx3R/ef-test/internal/infra/server/gin/middleware"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var rateLimitTenancy = config.TenancyConfig{
+	Defaults: config.TenantSettings{RateLimit: config.RateLimitSettings{RequestsPerSecond: 1, Burst: 2}},
+	Tenants: map[string]config.TenantSettings{
+		"retail": {RateLimit: config.RateLimitSettings{Burst: 3}},
+	},
+}
+
+var logger = logruslogger.NewLogger()
+
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Limit, time.Time) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("connection refused")
+}
+
+// setupRateLimitRouter authenticates requests as the subject of the X-Subject
+// header and puts them into the tenant of the X-Tenant-ID header.
+func setupRateLimitRouter(cfg *config.RateLimitConfig, tenancy *config.TenancyConfig, store ratelimit.Store) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		ctx := c.Request.Context()
+		if subject := c.GetHeader("X-Subject"); subject != "" {
+			ctx = usecase.WithPrincipal(ctx, usecase.Principal{Subject: subject})
+		}
+		if tenant := c.GetHeader("X-Tenant-ID"); tenant != "" {
+			ctx = usecase.WithTenant(ctx, usecase.Tenant{ID: tenant})
+		}
+		c.Request = c.Request.WithContext(ctx)
+	}, ginware.NewRateLimitMiddleware(cfg, tenancy, store, logger))
+
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/subscriptions", ok)
+	r.GET("/subscriptions/total", ok)
+	r.GET("/v1/subscriptions/total", ok)
+	r.GET("/v2/subscriptions/total", ok)
+	return r
+}
+
+func doRateLimitedRequest(r *gin.Engine, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// allowed returns how many of n requests get through.
+func allowed(r *gin.Engine, n int, path string, headers map[string]string) int {
+	count := 0
+	for range n {
+		if doRateLimitedRequest(r, path, headers).Code == http.StatusOK {
+			count++
+		}
+	}
+	return count
+}
+
+func TestRateLimitMiddleware_Exceeded(t *testing.T) {
+	router := setupRateLimitRouter(&config.RateLimitConfig{}, &rateLimitTenancy, ratelimit.NewMemoryStore())
+
+	first := doRateLimitedRequest(router, "/subscriptions", nil)
+	doRateLimitedRequest(router, "/subscriptions", nil)
+	w := doRateLimitedRequest(router, "/subscriptions", nil)
+
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "2", first.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "2;w=2", first.Header().Get("RateLimit-Policy"))
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"type": "urn:problem:rate_limit.exceeded",
+		"title": "Rate limit exceeded",
+		"status": 429,
+		"instance": "/subscriptions",
+		"code": "rate_limit.exceeded"
+	}`, w.Body.String())
+}
+
+func TestRateLimitMiddleware_Buckets(t *testing.T) {
+	cfg := &config.RateLimitConfig{
+		Routes:  map[string]config.RateLimitSettings{"GET /subscriptions/total": {Burst: 1}},
+		Clients: map[string]config.RateLimitSettings{"batch": {Burst: 5}},
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		headers map[string]string
+		allowed int
+	}{
+		{"tenant default", "/subscriptions", nil, 2},
+		{"tenant override", "/subscriptions", map[string]string{"X-Tenant-ID": "retail"}, 3},
+		{"route override", "/subscriptions/total", nil, 1},
+		{"client override", "/subscriptions/total", map[string]string{"X-Subject": "batch"}, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupRateLimitRouter(cfg, &rateLimitTenancy, ratelimit.NewMemoryStore())
+
+			assert.Equal(t, tt.allowed, allowed(router, 10, tt.path, tt.headers))
+		})
+	}
+}
+
+func TestRateLimitMiddleware_VersionsShareBuckets(t *testing.T) {
+	cfg := &config.RateLimitConfig{
+		Routes: map[string]config.RateLimitSettings{"GET /subscriptions/total": {Burst: 3}},
+	}
+	router := setupRateLimitRouter(cfg, &rateLimitTenancy, ratelimit.NewMemoryStore())
+
+	assert.Equal(t, 1, allowed(router, 1, "/subscriptions/total", nil))
+	assert.Equal(t, 1, allowed(router, 1, "/v1/subscriptions/total", nil))
+	assert.Equal(t, 1, allowed(router, 2, "/v2/subscriptions/total", nil))
+}
+
+func TestRateLimitMiddleware_ClientsIsolated(t *testing.T) {
+	router := setupRateLimitRouter(&config.RateLimitConfig{}, &rateLimitTenancy, ratelimit.NewMemoryStore())
+
+	assert.Equal(t, 2, allowed(router, 3, "/subscriptions", map[string]string{"X-Subject": "alice"}))
+	assert.Equal(t, 2, allowed(router, 3, "/subscriptions", map[string]string{"X-Subject": "bob"}))
+	assert.Equal(t, 2, allowed(router, 3, "/subscriptions", nil))
+	assert.Equal(t, 2, allowed(router, 3, "/subscriptions/total", nil))
+}
+
+func TestRateLimitMiddleware_Unlimited(t *testing.T) {
+	tenancy := config.TenancyConfig{}
+	router := setupRateLimitRouter(&config.RateLimitConfig{}, &tenancy, ratelimit.NewMemoryStore())
+
+	w := doRateLimitedRequest(router, "/subscriptions", nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimitMiddleware_StoreFailure_LetsThrough(t *testing.T) {
+	router := setupRateLimitRouter(&config.RateLimitConfig{}, &rateLimitTenancy, failingStore{})
+
+	assert.Equal(t, 5, allowed(router, 5, "/subscriptions", nil))
+}
@@ -0,0 +1,42 @@
+package gin
+
+import (
+	"regexp"
+
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDPattern keeps IDs from callers short and free of characters
+// that could forge log lines or headers.
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// NewRequestIDMiddleware identifies the request with the ID sent by the caller
+// in X-Request-ID, or a new one, and echoes it in the response. It puts a log
+// entry with the ID into the request context, see usecase.LoggerFrom.
+func NewRequestIDMiddleware(logger *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !requestIDPattern.MatchString(id) {
+			id = uuid.NewString()
+		}
+		c.Header(RequestIDHeader, id)
+
+		entry := logger.WithField("request_id", id)
+		c.Request = c.Request.WithContext(usecase.WithLogger(c.Request.Context(), entry))
+
+		c.Next()
+	}
+}
+
+// requestLogger returns the log entry of the request or, without one, an entry of logger.
+func requestLogger(c *gin.Context, logger *logrus.Logger) *logrus.Entry {
+	if entry, ok := usecase.LoggerFrom(c.Request.Context()); ok {
+		return entry
+	}
+	return logger.WithContext(c.Request.Context())
+}
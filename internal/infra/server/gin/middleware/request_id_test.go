@@ -0,0 +1,100 @@
+package gin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ginware "github.com/MDx3R/ef-test/internal/infra/server/gin/middleware"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupRequestIDRouter logs the requests to the returned hook.
+// The handler authenticates the request as user and logs a line of its own.
+func setupRequestIDRouter(user string) (*gin.Engine, *test.Hook) {
+	gin.SetMode(gin.TestMode)
+
+	testLogger, hook := test.NewNullLogger()
+	r := gin.New()
+	r.Use(ginware.NewRequestIDMiddleware(testLogger), ginware.LoggerMiddleware(testLogger))
+	r.GET("/", func(c *gin.Context) {
+		ctx := usecase.WithPrincipal(c.Request.Context(), usecase.Principal{Subject: user})
+		c.Request = c.Request.WithContext(ctx)
+
+		entry, ok := usecase.LoggerFrom(ctx)
+		if !ok {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		entry.Info("handling request")
+		c.String(http.StatusOK, "hello")
+	})
+	return r, hook
+}
+
+func TestRequestIDMiddleware_Generates(t *testing.T) {
+	router, hook := setupRequestIDRouter("user")
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	id := w.Header().Get(ginware.RequestIDHeader)
+	_, err := uuid.Parse(id)
+	require.NoError(t, err)
+	require.Len(t, hook.AllEntries(), 2)
+	for _, entry := range hook.AllEntries() {
+		assert.Equal(t, id, entry.Data["request_id"])
+	}
+}
+
+func TestRequestIDMiddleware_KeepsCallerID(t *testing.T) {
+	router, hook := setupRequestIDRouter("user")
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(ginware.RequestIDHeader, "edge-42:a1b2")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, "edge-42:a1b2", w.Header().Get(ginware.RequestIDHeader))
+	assert.Equal(t, "edge-42:a1b2", hook.LastEntry().Data["request_id"])
+}
+
+func TestRequestIDMiddleware_ReplacesInvalidID(t *testing.T) {
+	router, _ := setupRequestIDRouter("user")
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(ginware.RequestIDHeader, "forged\" level=error")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	_, err := uuid.Parse(w.Header().Get(ginware.RequestIDHeader))
+	assert.NoError(t, err)
+}
+
+func TestLoggerMiddleware_LogsRequest(t *testing.T) {
+	router, hook := setupRequestIDRouter("123e4567-e89b-12d3-a456-426614174000")
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	handled := hook.LastEntry()
+	require.NotNil(t, handled)
+	assert.Equal(t, logrus.InfoLevel, handled.Level)
+	assert.Equal(t, "request handled", handled.Message)
+	assert.Equal(t, w.Header().Get(ginware.RequestIDHeader), handled.Data["request_id"])
+	assert.Equal(t, "123e4567-e89b-12d3-a456-426614174000", handled.Data["user_id"])
+	assert.Equal(t, http.StatusOK, handled.Data["status"])
+	assert.Equal(t, len("hello"), handled.Data["size"])
+}
@@ -0,0 +1,58 @@
+package gin
+
+import (
+	"regexp"
+
+	"github.com/MDx3R/ef-test/internal/config"
+	"github.com/MDx3R/ef-test/internal/transport/http/problem"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+var tenantIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]{0,63}$`)
+
+// NewTenantMiddleware puts the tenant of the request into its context.
+// It must run after authentication. The tenant is taken from the caller's
+// credentials; the header may only repeat it. Users whose credentials name
+// no tenant belong to the default one, while admins and unauthenticated
+// requests may select any tenant with the header.
+func NewTenantMiddleware(cfg *config.TenancyConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requested := c.GetHeader(cfg.Header)
+		if requested != "" && !tenantIDPattern.MatchString(requested) {
+			problem.Respond(c, problem.CodeInvalidTenant, "")
+			return
+		}
+
+		tenantID, ok := resolveTenant(c, requested)
+		if !ok {
+			problem.Respond(c, problem.CodeTenantForbidden, "")
+			return
+		}
+
+		tenant := usecase.Tenant{ID: tenantID, Currency: cfg.Settings(tenantID).Currency}
+		c.Request = c.Request.WithContext(usecase.WithTenant(c.Request.Context(), tenant))
+
+		c.Next()
+	}
+}
+
+func resolveTenant(c *gin.Context, requested string) (string, bool) {
+	p, authenticated := usecase.PrincipalFrom(c.Request.Context())
+
+	own := ""
+	switch {
+	case authenticated && p.Tenant != "":
+		own = p.Tenant
+	case authenticated && !p.IsAdmin():
+		own = usecase.DefaultTenantID
+	}
+
+	if own != "" {
+		return own, requested == "" || requested == own
+	}
+	if requested != "" {
+		return requested, true
+	}
+	return usecase.DefaultTenantID, true
+}
@@ -0,0 +1,109 @@
+package gin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MDx3R/ef-test/internal/config"
+	ginware "github.com/MDx3R/ef-test/internal/infra/server/gin/middleware"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var tenancyConfig = config.TenancyConfig{
+	Header:   "X-Tenant-ID",
+	Defaults: config.TenantSettings{Currency: "RUB"},
+	Tenants:  map[string]config.TenantSettings{"retail": {Currency: "EUR"}},
+}
+
+// setupTenantRouter authenticates requests as principal, if it is set.
+func setupTenantRouter(principal *usecase.Principal) (*gin.Engine, *usecase.Tenant) {
+	gin.SetMode(gin.TestMode)
+
+	var tenant usecase.Tenant
+	r := gin.New()
+	r.GET("/",
+		func(c *gin.Context) {
+			if principal != nil {
+				c.Request = c.Request.WithContext(usecase.WithPrincipal(c.Request.Context(), *principal))
+			}
+		},
+		ginware.NewTenantMiddleware(&tenancyConfig),
+		func(c *gin.Context) {
+			tenant = usecase.TenantFrom(c.Request.Context())
+			c.Status(http.StatusOK)
+		},
+	)
+	return r, &tenant
+}
+
+func doTenantRequest(r *gin.Engine, header string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if header != "" {
+		req.Header.Set("X-Tenant-ID", header)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestTenantMiddleware_Resolves(t *testing.T) {
+	admin := usecase.Principal{Subject: "admin", Roles: []string{usecase.RoleAdmin}}
+	user := usecase.Principal{Subject: "123e4567-e89b-12d3-a456-426614174000"}
+	retailUser := usecase.Principal{Subject: "123e4567-e89b-12d3-a456-426614174000", Tenant: "retail"}
+
+	tests := []struct {
+		name      string
+		principal *usecase.Principal
+		header    string
+		tenant    string
+		currency  string
+	}{
+		{"anonymous default", nil, "", usecase.DefaultTenantID, "RUB"},
+		{"anonymous header", nil, "retail", "retail", "EUR"},
+		{"credentials", &retailUser, "", "retail", "EUR"},
+		{"credentials and same header", &retailUser, "retail", "retail", "EUR"},
+		{"user without tenant", &user, "", usecase.DefaultTenantID, "RUB"},
+		{"admin header", &admin, "retail", "retail", "EUR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, tenant := setupTenantRouter(tt.principal)
+
+			w := doTenantRequest(router, tt.header)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.tenant, tenant.ID)
+			assert.Equal(t, tt.currency, tenant.Currency)
+		})
+	}
+}
+
+func TestTenantMiddleware_Rejects(t *testing.T) {
+	user := usecase.Principal{Subject: "123e4567-e89b-12d3-a456-426614174000"}
+	retailClient := usecase.Principal{Subject: "key", Tenant: "retail", Client: true}
+
+	tests := []struct {
+		name      string
+		principal *usecase.Principal
+		header    string
+		code      int
+	}{
+		{"foreign tenant of credentials", &retailClient, "wholesale", http.StatusForbidden},
+		{"user without tenant selects one", &user, "retail", http.StatusForbidden},
+		{"invalid tenant id", nil, "../retail", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, _ := setupTenantRouter(tt.principal)
+
+			w := doTenantRequest(router, tt.header)
+
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
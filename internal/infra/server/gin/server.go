@@ -4,25 +4,37 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"slices"
 	"time"
 
-	_ "github.com/MDx3R/ef-test/docs"
-
+	docsv1 "github.com/MDx3R/ef-test/docs/v1"
+	docsv2 "github.com/MDx3R/ef-test/docs/v2"
 	"github.com/MDx3R/ef-test/internal/config"
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	ginware "github.com/MDx3R/ef-test/internal/infra/server/gin/middleware"
 	ginhandlers "github.com/MDx3R/ef-test/internal/transport/http/gin"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
+const (
+	LivenessPath  = "/healthz"
+	ReadinessPath = "/readyz"
+)
+
 type GinServer struct {
 	cfg    *config.ServerConfig
 	engine *gin.Engine
 	server *http.Server
 }
 
-func New(cfg *config.ServerConfig) *GinServer {
+func New(cfg *config.ServerConfig) (*GinServer, error) {
 	engine := gin.New()
+	// Gin trusts every proxy by default, which lets any client pick its address.
+	if err := engine.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
 
 	s := &GinServer{
 		cfg:    cfg,
@@ -37,7 +49,7 @@ func New(cfg *config.ServerConfig) *GinServer {
 		IdleTimeout:  120 * time.Second,
 	}
 
-	return s
+	return s, nil
 }
 
 func SetMode(cfg *config.Config) {
@@ -65,19 +77,107 @@ func (g *GinServer) UseMiddleware(mw ...gin.HandlerFunc) {
 	g.engine.Use(mw...)
 }
 
+// RegisterSwagger serves the document of every API version under /swagger/<version>.
 func (g *GinServer) RegisterSwagger() {
-	g.engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	docsv1.SwaggerInfov1.Version = "1.0"
+	docsv2.SwaggerInfov2.Version = "2.0"
+
+	for _, version := range []string{docsv1.SwaggerInfov1.InstanceName(), docsv2.SwaggerInfov2.InstanceName()} {
+		g.engine.GET("/swagger/"+version+"/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.InstanceName(version)))
+	}
+}
+
+// RegisterMetrics serves the metrics at path without authentication.
+func (g *GinServer) RegisterMetrics(path string, handler http.Handler) {
+	g.engine.GET(path, gin.WrapH(handler))
+}
+
+// RegisterHealthHandler mounts the probes without authentication,
+// so that orchestrators and load balancers can reach them.
+func (g *GinServer) RegisterHealthHandler(handler *ginhandlers.HealthHandler) {
+	g.engine.GET(LivenessPath, handler.Live)
+	g.engine.GET(ReadinessPath, handler.Ready)
 }
 
-func (g *GinServer) RegisterSubscriptionHandler(handler *ginhandlers.SubscriptionHandler) {
-	subGroup := g.engine.Group("/subscriptions")
+// SubscriptionRoutes are the subscription handlers of an API version.
+type SubscriptionRoutes interface {
+	List(ctx *gin.Context)
+	Get(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Patch(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	CalculateTotalCost(ctx *gin.Context)
+	Search(ctx *gin.Context)
+	Merge(ctx *gin.Context)
+	Duplicates(ctx *gin.Context)
+}
+
+// RegisterSubscriptionHandler mounts the subscription routes of every API version.
+// The first version is served under /v1 and, for clients that predate versioning,
+// without a prefix; its responses announce its deprecation and sunset.
+// The second version is served under /v2.
+// The middleware, such as authentication, applies to these routes only.
+// Every route requires the API key scope of its action.
+func (g *GinServer) RegisterSubscriptionHandler(v1, v2 SubscriptionRoutes, middleware ...gin.HandlerFunc) {
+	// Announced first, so that rejected requests learn about the deprecation too.
+	deprecated := slices.Concat([]gin.HandlerFunc{ginware.NewDeprecationMiddleware(&g.cfg.V1Deprecation)}, middleware)
+
+	g.mountSubscriptions(&g.engine.RouterGroup, v1, deprecated)
+	g.mountSubscriptions(g.engine.Group("/v1"), v1, deprecated)
+	g.mountSubscriptions(g.engine.Group("/v2"), v2, middleware)
+}
+
+func (g *GinServer) mountSubscriptions(root *gin.RouterGroup, handler SubscriptionRoutes, middleware []gin.HandlerFunc) {
+	subGroup := root.Group("/subscriptions", middleware...)
+
+	read := ginware.RequireScope(entity.ScopeSubscriptionsRead)
+	write := ginware.RequireScope(entity.ScopeSubscriptionsWrite)
+	reports := ginware.RequireScope(entity.ScopeReportsRead)
+
+	subGroup.GET("", read, handler.List)
+	subGroup.GET("/:id", read, handler.Get)
+	subGroup.POST("", write, handler.Create)
+	subGroup.PUT("/:id", write, handler.Update)
+	subGroup.PATCH("/:id", write, handler.Patch)
+	subGroup.DELETE("/:id", write, handler.Delete)
+	subGroup.GET("/total", reports, handler.CalculateTotalCost)
+	subGroup.GET("/search", read, handler.Search)
+	subGroup.POST("/merge", write, handler.Merge)
+
+	userGroup := root.Group("/users", middleware...)
+	userGroup.GET("/:id/duplicates", read, handler.Duplicates)
+}
+
+// SubscriptionEventRoutes are the subscription event handlers of an API version.
+type SubscriptionEventRoutes interface {
+	Events(ctx *gin.Context)
+}
+
+// RegisterSubscriptionEventHandler mounts the subscription event stream of every
+// API version at the prefixes of RegisterSubscriptionHandler.
+func (g *GinServer) RegisterSubscriptionEventHandler(v1, v2 SubscriptionEventRoutes, middleware ...gin.HandlerFunc) {
+	deprecated := slices.Concat([]gin.HandlerFunc{ginware.NewDeprecationMiddleware(&g.cfg.V1Deprecation)}, middleware)
+
+	read := ginware.RequireScope(entity.ScopeSubscriptionsRead)
+	g.engine.Group("/subscriptions", deprecated...).GET("/events", read, v1.Events)
+	g.engine.Group("/v1/subscriptions", deprecated...).GET("/events", read, v1.Events)
+	g.engine.Group("/v2/subscriptions", middleware...).GET("/events", read, v2.Events)
+}
+
+// RegisterAPIKeyHandler mounts the API key management routes.
+// The middleware must authenticate the caller; the service only serves admins.
+func (g *GinServer) RegisterAPIKeyHandler(handler *ginhandlers.APIKeyHandler, middleware ...gin.HandlerFunc) {
+	keyGroup := g.engine.Group("/admin/api-keys", middleware...)
+
+	keyGroup.POST("", handler.Issue)
+	keyGroup.GET("", handler.List)
+	keyGroup.DELETE("/:id", handler.Revoke)
+}
 
-	subGroup.GET("", handler.List)
-	subGroup.GET("/:id", handler.Get)
-	subGroup.POST("", handler.Create)
-	subGroup.PUT("/:id", handler.Update)
-	subGroup.DELETE("/:id", handler.Delete)
-	subGroup.GET("/total", handler.CalculateTotalCost)
+// Handler returns the handler of the registered routes.
+func (g *GinServer) Handler() http.Handler {
+	return g.engine
 }
 
 func (g *GinServer) Run() error {
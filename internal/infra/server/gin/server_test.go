@@ -0,0 +1,65 @@
+package gin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MDx3R/ef-test/internal/config"
+	logruslogger "github.com/MDx3R/ef-test/internal/infra/logger"
+	"github.com/MDx3R/ef-test/internal/infra/ratelimit"
+	ginserver "github.com/MDx3R/ef-test/internal/infra/server/gin"
+	ginware "github.com/MDx3R/ef-test/internal/infra/server/gin/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRateLimitedServer serves /ping to one request per anonymous client.
+func newRateLimitedServer(t *testing.T, trustedProxies []string) http.Handler {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	server, err := ginserver.New(&config.ServerConfig{TrustedProxies: trustedProxies})
+	require.NoError(t, err)
+
+	tenancy := &config.TenancyConfig{Defaults: config.TenantSettings{
+		RateLimit: config.RateLimitSettings{RequestsPerSecond: 1, Burst: 1},
+	}}
+	server.UseMiddleware(ginware.NewRateLimitMiddleware(&config.RateLimitConfig{}, tenancy, ratelimit.NewMemoryStore(), logruslogger.NewLogger()))
+	server.RegisterMetrics("/ping", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	return server.Handler()
+}
+
+func ping(handler http.Handler, forwardedFor string) int {
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set("X-Forwarded-For", forwardedFor)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestNew_IgnoresForwardedForByDefault(t *testing.T) {
+	handler := newRateLimitedServer(t, nil)
+
+	assert.Equal(t, http.StatusOK, ping(handler, "198.51.100.1"))
+	// A spoofed address doesn't get the client a bucket of its own.
+	assert.Equal(t, http.StatusTooManyRequests, ping(handler, "198.51.100.2"))
+}
+
+func TestNew_TrustedProxyForwardsClients(t *testing.T) {
+	handler := newRateLimitedServer(t, []string{"192.0.2.0/24"})
+
+	assert.Equal(t, http.StatusOK, ping(handler, "198.51.100.1"))
+	assert.Equal(t, http.StatusOK, ping(handler, "198.51.100.2"))
+	assert.Equal(t, http.StatusTooManyRequests, ping(handler, "198.51.100.1"))
+}
+
+func TestNew_InvalidTrustedProxies(t *testing.T) {
+	_, err := ginserver.New(&config.ServerConfig{TrustedProxies: []string{"not an address"}})
+
+	assert.Error(t, err)
+}
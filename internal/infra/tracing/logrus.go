@@ -0,0 +1,32 @@
+package tracing
+
+import (
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// logrusHook adds the trace and span IDs to entries logged with a context
+// of a recorded span, such as logger.WithContext(ctx).
+type logrusHook struct{}
+
+func NewLogrusHook() logrus.Hook {
+	return logrusHook{}
+}
+
+func (logrusHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (logrusHook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+
+	span := trace.SpanContextFromContext(entry.Context)
+	if !span.IsValid() {
+		return nil
+	}
+	entry.Data["trace_id"] = span.TraceID().String()
+	entry.Data["span_id"] = span.SpanID().String()
+	return nil
+}
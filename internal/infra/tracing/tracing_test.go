@@ -0,0 +1,67 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/MDx3R/ef-test/internal/config"
+	"github.com/MDx3R/ef-test/internal/infra/tracing"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestNewTracerProvider_UnknownExporter(t *testing.T) {
+	_, err := tracing.NewTracerProvider(context.Background(), &config.TracingConfig{Exporter: "zipkin"})
+
+	assert.Error(t, err)
+}
+
+func TestNewTracerProvider_None(t *testing.T) {
+	provider, err := tracing.NewTracerProvider(context.Background(), &config.TracingConfig{
+		Exporter:    "none",
+		ServiceName: "test",
+		SampleRatio: 1,
+	})
+
+	require.NoError(t, err)
+	_, span := provider.Tracer("test").Start(context.Background(), "span")
+	assert.True(t, span.SpanContext().IsSampled())
+	span.End()
+	assert.NoError(t, provider.Shutdown(context.Background()))
+}
+
+func newHookedLogger() (*logrus.Logger, *bytes.Buffer) {
+	var out bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&out)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+	logger.AddHook(tracing.NewLogrusHook())
+	return logger, &out
+}
+
+func TestLogrusHook_AddsTraceIDs(t *testing.T) {
+	logger, out := newHookedLogger()
+	provider := sdktrace.NewTracerProvider()
+	ctx, span := provider.Tracer("test").Start(context.Background(), "span")
+	defer span.End()
+
+	logger.WithContext(ctx).Info("traced")
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &fields))
+	assert.Equal(t, span.SpanContext().TraceID().String(), fields["trace_id"])
+	assert.Equal(t, span.SpanContext().SpanID().String(), fields["span_id"])
+}
+
+func TestLogrusHook_WithoutSpan(t *testing.T) {
+	logger, out := newHookedLogger()
+
+	logger.WithContext(context.Background()).Info("untraced")
+	logger.Info("no context")
+
+	assert.NotContains(t, out.String(), "trace_id")
+}
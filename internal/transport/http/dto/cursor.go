@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	"github.com/google/uuid"
+)
+
+const cursorDateLayout = "2006-01-02"
+
+// cursorPayload is the JSON behind an opaque cursor.
+// Clients must treat cursors as opaque strings, so the layout may change.
+type cursorPayload struct {
+	StartDate string `json:"d"`
+	ID        string `json:"id"`
+}
+
+func EncodeCursor(c dto.Cursor) string {
+	payload, _ := json.Marshal(cursorPayload{
+		StartDate: c.StartDate.Format(cursorDateLayout),
+		ID:        c.ID.String(),
+	})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func DecodeCursor(s string) (*dto.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	startDate, err := time.Parse(cursorDateLayout, payload.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	id, err := uuid.Parse(payload.ID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	return &dto.Cursor{StartDate: startDate, ID: id}, nil
+}
@@ -0,0 +1,44 @@
+package dto_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/transport/http/dto"
+	usecasedto "github.com/MDx3R/ef-test/internal/usecase/dto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	cursor := usecasedto.Cursor{
+		StartDate: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+		ID:        uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"),
+	}
+
+	encoded := dto.EncodeCursor(cursor)
+	decoded, err := dto.DecodeCursor(encoded)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "eyJkIjoiMjAyNS0wOC0wMSIsImlkIjoiMTIzZTQ1NjctZTg5Yi0xMmQzLWE0NTYtNDI2NjE0MTc0MDAwIn0", encoded)
+	assert.Equal(t, cursor, *decoded)
+}
+
+func TestCursor_Decode_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "%%%"},
+		{name: "not json", cursor: "bm90LWpzb24"},
+		{name: "invalid date", cursor: "eyJkIjoieCIsImlkIjoiMTIzZTQ1NjctZTg5Yi0xMmQzLWE0NTYtNDI2NjE0MTc0MDAwIn0"},
+		{name: "invalid id", cursor: "eyJkIjoiMjAyNS0wOC0wMSIsImlkIjoieCJ9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := dto.DecodeCursor(tt.cursor)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package dto
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/MDx3R/ef-test/internal/usecase/dto"
@@ -26,10 +29,11 @@ func ToCreateSubscriptionCommand(r CreateSubscriptionRequest) (*dto.CreateSubscr
 
 	return &dto.CreateSubscriptionCommand{
 		ServiceName: r.ServiceName,
-		Price:       r.Price,
+		Price:       *r.Price,
 		UserID:      userID,
 		StartDate:   *startDate,
 		EndDate:     endDate,
+		Notes:       r.Notes,
 	}, nil
 }
 
@@ -47,9 +51,46 @@ func ToUpdateSubscriptionCommand(r UpdateSubscriptionRequest) (*dto.UpdateSubscr
 
 	return &dto.UpdateSubscriptionCommand{
 		ServiceName: r.ServiceName,
-		Price:       r.Price,
+		Price:       *r.Price,
 		StartDate:   *startDate,
 		EndDate:     endDate,
+		Notes:       r.Notes,
+	}, nil
+}
+
+func ToPatchSubscriptionCommand(r PatchSubscriptionRequest) (*dto.PatchSubscriptionCommand, error) {
+	for _, name := range []string{"service_name", "price", "start_date"} {
+		if r.nulls[name] {
+			return nil, fmt.Errorf("%s cannot be removed", name)
+		}
+	}
+
+	startDate, err := r.StartDate.Parse()
+	if err != nil {
+		return nil, err
+	}
+	if r.StartDate != nil && startDate == nil {
+		return nil, fmt.Errorf("start_date cannot be removed")
+	}
+
+	endDate, err := r.EndDate.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	notes := r.Notes
+	if r.nulls["notes"] {
+		notes = new(string)
+	}
+
+	return &dto.PatchSubscriptionCommand{
+		ServiceName: r.ServiceName,
+		Price:       r.Price,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		// The legacy "null" string of v1 responses removes the end date too.
+		ClearEndDate: r.nulls["end_date"] || (r.EndDate != nil && endDate == nil),
+		Notes:        notes,
 	}, nil
 }
 
@@ -74,16 +115,148 @@ func ToSubscriptionFilter(r SubscriptionQueryRequest) (*dto.SubscriptionFilter,
 		return nil, err
 	}
 
+	var activeAt, activeUntil, expiringBefore *time.Time
+	activeAt, activeUntil, err = r.ActiveAt.Period()
+	if err != nil {
+		return nil, err
+	}
+	expiringBefore, err = r.ExpiringBefore.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	if r.PriceMin != nil && r.PriceMax != nil && *r.PriceMin > *r.PriceMax {
+		return nil, fmt.Errorf("price_min must not exceed price_max")
+	}
+
+	var serviceNames []string
+	if r.ServiceNameIn != nil {
+		serviceNames = splitList(*r.ServiceNameIn)
+	}
+
+	var sort []dto.SortOrder
+	if r.Sort != nil {
+		sort, err = ParseSort(*r.Sort)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var after *dto.Cursor
+	if r.Cursor != nil {
+		if len(sort) > 0 {
+			return nil, fmt.Errorf("cursor cannot be combined with sort")
+		}
+		after, err = DecodeCursor(*r.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &dto.SubscriptionFilter{
 		UserID:      userID,
 		ServiceName: r.ServiceName,
 		StartDate:   startDate,
 		EndDate:     endDate,
-		Page:        r.Page,
-		PageSize:    r.PageSize,
+
+		ServiceNames:      serviceNames,
+		ServiceNamePrefix: r.ServiceNamePrefix,
+		PriceMin:          r.PriceMin,
+		PriceMax:          r.PriceMax,
+		ActiveAt:          activeAt,
+		ActiveUntil:       activeUntil,
+		ExpiringBefore:    expiringBefore,
+		OpenEnded:         r.OpenEnded,
+
+		Sort:         sort,
+		After:        after,
+		Page:         r.Page,
+		PageSize:     r.PageSize,
+		IncludeTotal: r.IncludeTotal,
+	}, nil
+}
+
+// splitList splits a comma separated list, dropping blank items.
+func splitList(s string) []string {
+	var result []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// ParseSort parses a sort expression such as "price,-start_date".
+func ParseSort(s string) ([]dto.SortOrder, error) {
+	var result []dto.SortOrder
+	seen := make(map[dto.SortField]bool)
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		order := dto.SortOrder{Field: dto.SortField(strings.TrimPrefix(part, "-")), Desc: strings.HasPrefix(part, "-")}
+		if !order.Field.IsValid() {
+			return nil, fmt.Errorf("unsupported sort field: %s", order.Field)
+		}
+		if seen[order.Field] {
+			return nil, fmt.Errorf("duplicate sort field: %s", order.Field)
+		}
+		seen[order.Field] = true
+
+		result = append(result, order)
+	}
+
+	return result, nil
+}
+
+func ToSubscriptionSearchFilter(r SubscriptionSearchRequest) (*dto.SubscriptionSearchFilter, error) {
+	query := strings.TrimSpace(r.Query)
+	if query == "" {
+		return nil, fmt.Errorf("search query must not be blank")
+	}
+
+	var userID *uuid.UUID
+	if r.UserID != nil {
+		uid, err := uuid.Parse(*r.UserID)
+		if err != nil {
+			return nil, err
+		}
+		userID = &uid
+	}
+
+	return &dto.SubscriptionSearchFilter{
+		Query:  query,
+		UserID: userID,
+		Limit:  r.Limit,
 	}, nil
 }
 
+func ToSubscriptionEventFilter(r SubscriptionEventsRequest) (*dto.SubscriptionEventFilter, error) {
+	filter := &dto.SubscriptionEventFilter{ServiceName: r.ServiceName}
+
+	if r.UserID != nil {
+		uid, err := uuid.Parse(*r.UserID)
+		if err != nil {
+			return nil, err
+		}
+		filter.UserID = &uid
+	}
+
+	if r.LastEventID != nil && *r.LastEventID != "" {
+		id, err := strconv.ParseUint(*r.LastEventID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid last event id %q", *r.LastEventID)
+		}
+		filter.LastEventID = &id
+	}
+
+	return filter, nil
+}
+
 func ToTotalCostFilter(r TotalCostQueryRequest) (*dto.TotalCostFilter, error) {
 	userID, err := uuid.Parse(r.UserID)
 	if err != nil {
@@ -108,14 +281,77 @@ func ToTotalCostFilter(r TotalCostQueryRequest) (*dto.TotalCostFilter, error) {
 	}, nil
 }
 
-func FromSubscriptionDTO(d dto.SubscriptionDTO) *SubscriptionResponse {
-	endDate := FromTime(d.EndDate)
-	return &SubscriptionResponse{
-		ID:          d.ID.String(),
-		UserID:      d.UserID.String(),
-		ServiceName: d.ServiceName,
-		Price:       d.Price,
-		StartDate:   FromTime(&d.StartDate),
-		EndDate:     &endDate,
+func ToMergeSubscriptionsCommand(r MergeSubscriptionsRequest) (*dto.MergeSubscriptionsCommand, error) {
+	targetID, err := uuid.Parse(r.TargetID)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceIDs := make([]uuid.UUID, len(r.SourceIDs))
+	for i, s := range r.SourceIDs {
+		sourceIDs[i], err = uuid.Parse(s)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &dto.MergeSubscriptionsCommand{TargetID: targetID, SourceIDs: sourceIDs}, nil
+}
+
+func FromUUIDs(ids []uuid.UUID) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = id.String()
+	}
+	return result
+}
+
+func ToIssueAPIKeyCommand(r IssueAPIKeyRequest) *dto.IssueAPIKeyCommand {
+	var expiresAt *time.Time
+	if r.ExpiresAt != nil {
+		t := r.ExpiresAt.UTC()
+		expiresAt = &t
+	}
+
+	return &dto.IssueAPIKeyCommand{
+		Name:      r.Name,
+		Scopes:    r.Scopes,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func FromAPIKeyDTO(d dto.APIKeyDTO) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         d.ID.String(),
+		Name:       d.Name,
+		Prefix:     d.Prefix,
+		Scopes:     d.Scopes,
+		CreatedAt:  d.CreatedAt,
+		ExpiresAt:  d.ExpiresAt,
+		LastUsedAt: d.LastUsedAt,
+		RevokedAt:  d.RevokedAt,
+	}
+}
+
+func FromIssuedAPIKeyDTO(d dto.IssuedAPIKeyDTO) *IssuedAPIKeyResponse {
+	return &IssuedAPIKeyResponse{
+		APIKeyResponse: FromAPIKeyDTO(d.APIKeyDTO),
+		Key:            d.Key,
+	}
+}
+
+func FromAPIKeyDTOs(items []dto.APIKeyDTO) *APIKeyListResponse {
+	result := make([]APIKeyResponse, len(items))
+	for i, item := range items {
+		result[i] = FromAPIKeyDTO(item)
+	}
+	return &APIKeyListResponse{Items: result}
+}
+
+func FromHealthReport(r dto.HealthReport) *HealthResponse {
+	components := make(map[string]ComponentHealthResponse, len(r.Components))
+	for _, c := range r.Components {
+		components[c.Name] = ComponentHealthResponse{Status: string(c.Status), Error: c.Error}
 	}
+	return &HealthResponse{Status: string(r.Status), Components: components}
 }
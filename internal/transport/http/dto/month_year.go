@@ -2,12 +2,61 @@ package dto
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 )
 
-const monthYearLayout = "01-2006"
+const (
+	monthYearLayout = "01-2006"
+	isoMonthLayout  = "2006-01"
+	isoDateLayout   = "2006-01-02"
+)
+
+// inputLayouts are the accepted spellings of a month, tried in order.
+// Parse drops days and times: subscriptions are billed by month.
+var inputLayouts = []string{monthYearLayout, isoMonthLayout, isoDateLayout, time.RFC3339}
+
+// legacyNull is how the legacy format spells an absent date.
+const legacyNull = "null"
+
+// DateFormat is the spelling of months in responses.
+type DateFormat string
+
+const (
+	// DateFormatLegacy renders months as MM-YYYY and absent dates as the string "null".
+	DateFormatLegacy DateFormat = "legacy"
+	// DateFormatISO renders months as YYYY-MM and absent dates as JSON null.
+	DateFormatISO DateFormat = "iso"
+)
+
+// ParseDateFormat parses the date_format query parameter, legacy by default.
+func ParseDateFormat(s string) (DateFormat, error) {
+	switch f := DateFormat(strings.ToLower(strings.TrimSpace(s))); f {
+	case "":
+		return DateFormatLegacy, nil
+	case DateFormatLegacy, DateFormatISO:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unsupported date format: %s", s)
+	}
+}
+
+// Format renders t in the format, an empty MonthYear is JSON null.
+func (f DateFormat) Format(t *time.Time) MonthYear {
+	if t == nil || t.IsZero() {
+		if f == DateFormatISO {
+			return ""
+		}
+		return legacyNull
+	}
+	if f == DateFormatISO {
+		return MonthYear(t.Format(isoMonthLayout))
+	}
+	return MonthYear(t.Format(monthYearLayout))
+}
 
+// MonthYear is a month accepted as MM-YYYY, YYYY-MM, YYYY-MM-DD or RFC 3339.
 type MonthYear string
 
 func (my MonthYear) ToTime() time.Time {
@@ -29,8 +78,8 @@ func (my MonthYear) MarshalJSON() ([]byte, error) {
 	}
 
 	s := strings.Trim(string(my), `"`)
-	if s == "" || s == "null" {
-		return []byte(`"null"`), nil
+	if s == "" {
+		return []byte("null"), nil
 	}
 
 	return json.Marshal(s)
@@ -38,7 +87,7 @@ func (my MonthYear) MarshalJSON() ([]byte, error) {
 
 func (my *MonthYear) UnmarshalJSON(b []byte) error {
 	if string(b) == "null" || len(b) == 0 {
-		*my = MonthYear("null")
+		*my = ""
 		return nil
 	}
 
@@ -52,8 +101,8 @@ func (my *MonthYear) UnmarshalJSON(b []byte) error {
 
 func (my *MonthYear) UnmarshalText(b []byte) error {
 	s := string(b)
-	if s == "" || s == "null" {
-		*my = MonthYear("null")
+	if s == "" || s == legacyNull {
+		*my = ""
 		return nil
 	}
 
@@ -66,26 +115,49 @@ func (my MonthYear) Validate() error {
 	return err
 }
 
+// Parse returns the first day of the month in UTC, or nil for an absent date.
 func (my *MonthYear) Parse() (*time.Time, error) {
-	if my == nil {
-		return nil, nil
-	}
-	s := strings.Trim(string(*my), `"`)
-	if s == "" || s == "null" {
-		return nil, nil
+	t, _, err := my.parse()
+	if err != nil || t == nil {
+		return nil, err
 	}
+	month := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return &month, nil
+}
 
-	t, err := time.Parse(monthYearLayout, s)
-	if err != nil {
-		return nil, err
+// Period returns the first and the last day my names in UTC: the day itself
+// when it is spelled with one, or else the days of the month. Both are nil
+// for an absent date.
+func (my *MonthYear) Period() (*time.Time, *time.Time, error) {
+	t, layout, err := my.parse()
+	if err != nil || t == nil {
+		return nil, nil, err
 	}
 
-	return &t, nil
+	if layout == monthYearLayout || layout == isoMonthLayout {
+		first := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		last := first.AddDate(0, 1, -1)
+		return &first, &last, nil
+	}
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return &day, &day, nil
 }
 
-func FromTime(t *time.Time) MonthYear {
-	if t == nil || t.IsZero() {
-		return "null"
+// parse returns the time my spells and the layout it is spelled in.
+func (my *MonthYear) parse() (*time.Time, string, error) {
+	if my == nil {
+		return nil, "", nil
 	}
-	return MonthYear(t.Format(monthYearLayout))
+	s := strings.Trim(string(*my), `"`)
+	if s == "" || s == legacyNull {
+		return nil, "", nil
+	}
+
+	for _, layout := range inputLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t, layout, nil
+		}
+	}
+
+	return nil, "", fmt.Errorf("invalid month %q: want MM-YYYY, YYYY-MM, YYYY-MM-DD or RFC 3339", s)
 }
@@ -22,14 +22,14 @@ func TestMonthYear_MarshalJSON(t *testing.T) {
 			expected: `"08-2025"`,
 		},
 		{
-			name:     "empty string",
+			name:     "legacy null",
 			input:    dto.MonthYear("null"),
 			expected: `"null"`,
 		},
 		{
 			name:     "empty string",
 			input:    dto.MonthYear(""),
-			expected: `"null"`,
+			expected: `null`,
 		},
 	}
 
@@ -67,9 +67,33 @@ func TestMonthYear_UnmarshalJSON(t *testing.T) {
 			expected: time.Time{},
 			wantErr:  false,
 		},
+		{
+			name:     "iso month",
+			jsonData: `"2025-08"`,
+			expected: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+			wantErr:  false,
+		},
+		{
+			name:     "iso date",
+			jsonData: `"2025-08-15"`,
+			expected: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+			wantErr:  false,
+		},
+		{
+			name:     "rfc 3339",
+			jsonData: `"2025-08-31T23:30:00+03:00"`,
+			expected: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+			wantErr:  false,
+		},
 		{
 			name:     "invalid format",
-			jsonData: `"2025-08-01"`,
+			jsonData: `"2025/08/01"`,
+			expected: time.Time{},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid month",
+			jsonData: `"13-2025"`,
 			expected: time.Time{},
 			wantErr:  true,
 		},
@@ -114,3 +138,89 @@ func TestMonthYear_IntegrationWithStruct(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedDate, parsed.Date)
 }
+
+func TestMonthYear_Period(t *testing.T) {
+	tests := []struct {
+		name  string
+		input dto.MonthYear
+		first time.Time
+		last  time.Time
+	}{
+		{"month", "02-2024", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"iso month", "2025-08", time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC)},
+		{"iso date", "2025-08-15", time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC), time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC)},
+		{"rfc 3339", "2025-08-15T10:30:00Z", time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC), time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, last, err := tt.input.Period()
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.first, *first)
+			assert.Equal(t, tt.last, *last)
+		})
+	}
+}
+
+func TestMonthYear_Period_Absent(t *testing.T) {
+	input := dto.MonthYear("")
+
+	first, last, err := input.Period()
+
+	assert.NoError(t, err)
+	assert.Nil(t, first)
+	assert.Nil(t, last)
+}
+
+func TestParseDateFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected dto.DateFormat
+		wantErr  bool
+	}{
+		{name: "default", input: "", expected: dto.DateFormatLegacy},
+		{name: "legacy", input: "legacy", expected: dto.DateFormatLegacy},
+		{name: "iso", input: "ISO", expected: dto.DateFormatISO},
+		{name: "unsupported", input: "rfc1123", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := dto.ParseDateFormat(tt.input)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, format)
+		})
+	}
+}
+
+func TestDateFormat_Format(t *testing.T) {
+	date := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		format   dto.DateFormat
+		input    *time.Time
+		expected string
+	}{
+		{name: "legacy date", format: dto.DateFormatLegacy, input: &date, expected: `"08-2025"`},
+		{name: "legacy absent", format: dto.DateFormatLegacy, input: nil, expected: `"null"`},
+		{name: "iso date", format: dto.DateFormatISO, input: &date, expected: `"2025-08"`},
+		{name: "iso absent", format: dto.DateFormatISO, input: nil, expected: `null`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.format.Format(tt.input))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(data))
+		})
+	}
+}
@@ -1,18 +1,60 @@
 package dto
 
+import (
+	"encoding/json"
+	"time"
+)
+
 type CreateSubscriptionRequest struct {
-	ServiceName string     `json:"service_name" binding:"required" example:"Netflix"`
-	Price       int        `json:"price" binding:"required" example:"999"`
-	UserID      string     `json:"user_id" binding:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
-	StartDate   MonthYear  `json:"start_date" binding:"required" example:"08-2025"`
-	EndDate     *MonthYear `json:"end_date,omitempty" example:"09-2025"`
+	ServiceName string `json:"service_name" binding:"required" example:"Netflix"`
+	// Price is a pointer, so that required accepts free subscriptions.
+	Price     *int       `json:"price" binding:"required" example:"999"`
+	UserID    string     `json:"user_id" binding:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	StartDate MonthYear  `json:"start_date" binding:"required" example:"08-2025"`
+	EndDate   *MonthYear `json:"end_date,omitempty" example:"09-2025"`
+	Notes     string     `json:"notes,omitempty" binding:"max=1000" example:"Семейный тариф"`
 }
 
 type UpdateSubscriptionRequest struct {
 	ServiceName string     `json:"service_name" binding:"required" example:"Netflix"`
-	Price       int        `json:"price" binding:"required" example:"999"`
+	Price       *int       `json:"price" binding:"required" example:"999"`
 	StartDate   MonthYear  `json:"start_date" binding:"required" example:"08-2025"`
 	EndDate     *MonthYear `json:"end_date,omitempty" example:"09-2025"`
+	// Notes are kept when absent, so that clients unaware of them don't erase them.
+	Notes *string `json:"notes,omitempty" binding:"omitempty,max=1000" example:"Семейный тариф"`
+}
+
+// PatchSubscriptionRequest is a JSON merge patch (RFC 7396) of a subscription.
+// Absent members are kept, end_date and notes are removed by null.
+type PatchSubscriptionRequest struct {
+	ServiceName *string    `json:"service_name" binding:"omitempty,min=1" example:"Netflix"`
+	Price       *int       `json:"price" example:"0"`
+	StartDate   *MonthYear `json:"start_date" example:"08-2025"`
+	EndDate     *MonthYear `json:"end_date" example:"09-2025"`
+	Notes       *string    `json:"notes" binding:"omitempty,max=1000" example:"Семейный тариф"`
+
+	// nulls are the members set to null.
+	nulls map[string]bool
+}
+
+func (r *PatchSubscriptionRequest) UnmarshalJSON(b []byte) error {
+	// members has no UnmarshalJSON, so decoding into it doesn't recurse.
+	type members PatchSubscriptionRequest
+	if err := json.Unmarshal(b, (*members)(r)); err != nil {
+		return err
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	r.nulls = make(map[string]bool)
+	for name, value := range raw {
+		if string(value) == "null" {
+			r.nulls[name] = true
+		}
+	}
+	return nil
 }
 
 type SubscriptionQueryRequest struct {
@@ -21,8 +63,54 @@ type SubscriptionQueryRequest struct {
 	StartDate   *MonthYear `form:"start_date" example:"08-2025"`
 	EndDate     *MonthYear `form:"end_date" example:"09-2025"`
 
-	Page     int `form:"page,default=1,gte=1" example:"1"`
-	PageSize int `form:"page_size,default=20,gte=1" example:"20"`
+	// ServiceNameIn is a comma separated list of exact service names.
+	ServiceNameIn *string `form:"service_name_in" example:"Netflix,Spotify"`
+	// ServiceNamePrefix matches service names starting with it, ignoring case.
+	ServiceNamePrefix *string `form:"service_name_prefix" example:"net"`
+
+	PriceMin *int `form:"price_min" binding:"omitempty,gte=0" example:"100"`
+	PriceMax *int `form:"price_max" binding:"omitempty,gte=0" example:"1000"`
+
+	// ActiveAt matches subscriptions active during the month, or on the day
+	// when it is spelled with one.
+	ActiveAt *MonthYear `form:"active_at" example:"08-2025"`
+	// ExpiringBefore matches subscriptions ending before the month.
+	ExpiringBefore *MonthYear `form:"expiring_before" example:"12-2025"`
+	// OpenEnded=true matches subscriptions without end date, false ones with it.
+	OpenEnded *bool `form:"open_ended" example:"true"`
+
+	// Sort is a comma separated list of service_name, price, start_date and end_date.
+	// A leading "-" sorts in descending order. Cannot be combined with Cursor.
+	Sort *string `form:"sort" example:"price,-start_date"`
+
+	// Cursor is the next_cursor of a previous page. When set, Page is ignored.
+	Cursor *string `form:"cursor" example:"eyJkIjoiMjAyNS0wOC0wMSIsImlkIjoiMTIzZTQ1NjctZTg5Yi0xMmQzLWE0NTYtNDI2NjE0MTc0MDAwIn0"`
+	Page   int     `form:"page,default=1" binding:"gte=1" example:"1"`
+	// PageSize is bounded by PageSizeLimit since the second API version.
+	PageSize int `form:"page_size,default=20" binding:"gte=1" example:"20"`
+
+	// IncludeTotal=false skips counting the matching subscriptions.
+	IncludeTotal bool `form:"include_total,default=true" example:"true"`
+}
+
+// PageSizeLimit bounds page_size since the second API version, so that a page
+// can't load a whole tenant. The first version accepts larger pages as it did.
+type PageSizeLimit struct {
+	PageSize int `binding:"lte=100"`
+}
+
+type SubscriptionSearchRequest struct {
+	Query  string  `form:"q" binding:"required" example:"netflix"`
+	UserID *string `form:"user_id" binding:"omitempty,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Limit  int     `form:"limit,default=20" binding:"gte=1,lte=100" example:"20"`
+}
+
+// SubscriptionEventsRequest filters the event stream. LastEventID duplicates the
+// Last-Event-ID header for clients that can't set headers, the header wins.
+type SubscriptionEventsRequest struct {
+	UserID      *string `form:"user_id" binding:"omitempty,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ServiceName *string `form:"service_name" binding:"omitempty,min=1" example:"Netflix"`
+	LastEventID *string `form:"last_event_id" example:"42"`
 }
 
 type TotalCostQueryRequest struct {
@@ -31,3 +119,16 @@ type TotalCostQueryRequest struct {
 	PeriodStart MonthYear `form:"period_start" binding:"required" example:"08-2025"`
 	PeriodEnd   MonthYear `form:"period_end" binding:"required" example:"09-2025"`
 }
+
+type IssueAPIKeyRequest struct {
+	Name   string   `json:"name" binding:"required,max=255" example:"billing-export"`
+	Scopes []string `json:"scopes" binding:"required,min=1,dive,oneof=subscriptions:read subscriptions:write reports:read" example:"subscriptions:read,reports:read"`
+	// ExpiresAt is optional; keys without it never expire.
+	ExpiresAt *time.Time `json:"expires_at,omitempty" example:"2026-01-01T00:00:00Z"`
+}
+
+type MergeSubscriptionsRequest struct {
+	// TargetID is the subscription that remains; its name and price are kept.
+	TargetID  string   `json:"target_id" binding:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	SourceIDs []string `json:"source_ids" binding:"required,min=1,dive,uuid" example:"223e4567-e89b-12d3-a456-426614174000"`
+}
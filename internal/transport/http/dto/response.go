@@ -1,6 +1,10 @@
 package dto
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type IDResponse struct {
 	ID uuid.UUID `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
@@ -10,20 +14,74 @@ type IntResponse struct {
 	Value int `json:"value" example:"999"`
 }
 
-type ErrorResponse struct {
-	Error string `json:"error" example:"error message"`
+type TotalCostResponse struct {
+	Value int `json:"value" example:"999"`
+	// Currency is the ISO 4217 code of the tenant's prices.
+	Currency string `json:"currency,omitempty" example:"RUB"`
+}
+
+// ProblemResponse describes an error in the format of RFC 7807,
+// served as application/problem+json.
+type ProblemResponse struct {
+	Type   string `json:"type" example:"urn:problem:subscription.not_found"`
+	Title  string `json:"title" example:"Subscription not found"`
+	Status int    `json:"status" example:"404"`
+	// Detail is left out for internal errors outside the local environment.
+	Detail   string `json:"detail,omitempty" example:"not found"`
+	Instance string `json:"instance,omitempty" example:"/subscriptions/123e4567-e89b-12d3-a456-426614174000"`
+	// Code is the stable identifier of the error, listed in the error catalogue.
+	Code string `json:"code" example:"subscription.not_found"`
+}
+
+type ValidationProblemResponse struct {
+	ProblemResponse
+	// Errors map invalid fields to what is wrong with them.
+	Errors map[string]string `json:"errors"`
+}
+
+// AccessDeniedProblemResponse explains which action the access policy denied.
+type AccessDeniedProblemResponse struct {
+	ProblemResponse
+	Action   string   `json:"action" example:"subscriptions:update"`
+	Resource string   `json:"resource" example:"subscriptions/123e4567-e89b-12d3-a456-426614174000"`
+	Roles    []string `json:"roles" example:"finance"`
+	Reason   string   `json:"reason" example:"no role grants the action"`
+}
+
+// DuplicateProblemResponse lists the subscriptions a new one would duplicate.
+type DuplicateProblemResponse struct {
+	ProblemResponse
+	DuplicateIDs []string `json:"duplicate_ids" example:"123e4567-e89b-12d3-a456-426614174000"`
+}
+
+type APIKeyResponse struct {
+	ID         string     `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name       string     `json:"name" example:"billing-export"`
+	Prefix     string     `json:"prefix" example:"sk_Xk3v9QmA"`
+	Scopes     []string   `json:"scopes" example:"subscriptions:read,reports:read"`
+	CreatedAt  time.Time  `json:"created_at" example:"2025-08-01T12:00:00Z"`
+	ExpiresAt  *time.Time `json:"expires_at" example:"2026-01-01T00:00:00Z"`
+	LastUsedAt *time.Time `json:"last_used_at" example:"2025-08-02T09:30:00Z"`
+	RevokedAt  *time.Time `json:"revoked_at" example:"2025-09-01T00:00:00Z"`
+}
+
+type IssuedAPIKeyResponse struct {
+	APIKeyResponse
+	// Key is shown only once and can't be recovered later.
+	Key string `json:"key" example:"sk_Xk3v9QmA5bT0cW2yZ8rN1pL7hD4fG6jE9sQ3uV0aB2c"`
+}
+
+type APIKeyListResponse struct {
+	Items []APIKeyResponse `json:"items"`
 }
 
-type ValidationErrorResponse struct {
-	Error  string            `json:"error" example:"validation error"`
-	Fields map[string]string `json:"fields"`
+type ComponentHealthResponse struct {
+	Status string `json:"status" example:"up" enums:"up,down"`
+	Error  string `json:"error,omitempty" example:"failed to ping DB: connection refused"`
 }
 
-type SubscriptionResponse struct {
-	ID          string     `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	ServiceName string     `json:"service_name" example:"Netflix"`
-	Price       int        `json:"price" example:"999"`
-	UserID      string     `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	StartDate   MonthYear  `json:"start_date" example:"08-2025"`
-	EndDate     *MonthYear `json:"end_date,omitempty" example:"09-2025"`
+type HealthResponse struct {
+	Status string `json:"status" example:"up" enums:"up,down"`
+	// Components are keyed by name, e.g. database or migrations.
+	Components map[string]ComponentHealthResponse `json:"components"`
 }
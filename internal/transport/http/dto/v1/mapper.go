@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"github.com/MDx3R/ef-test/internal/transport/http/dto"
+	usecasedto "github.com/MDx3R/ef-test/internal/usecase/dto"
+)
+
+func FromSubscriptionDTO(d usecasedto.SubscriptionDTO, format dto.DateFormat) *SubscriptionResponse {
+	endDate := format.Format(d.EndDate)
+	return &SubscriptionResponse{
+		ID:          d.ID.String(),
+		UserID:      d.UserID.String(),
+		ServiceName: d.ServiceName,
+		Price:       d.Price,
+		StartDate:   format.Format(&d.StartDate),
+		EndDate:     &endDate,
+		Notes:       d.Notes,
+	}
+}
+
+func FromSubscriptionDTOs(items []usecasedto.SubscriptionDTO, format dto.DateFormat) []SubscriptionResponse {
+	result := make([]SubscriptionResponse, len(items))
+	for i, item := range items {
+		result[i] = *FromSubscriptionDTO(item, format)
+	}
+	return result
+}
+
+func FromSubscriptionPageDTO(p usecasedto.SubscriptionPageDTO, filter usecasedto.SubscriptionFilter, format dto.DateFormat) *SubscriptionListResponse {
+	var nextCursor *string
+	if p.NextCursor != nil {
+		c := dto.EncodeCursor(*p.NextCursor)
+		nextCursor = &c
+	}
+
+	var page *int
+	if filter.After == nil {
+		page = &filter.Page
+	}
+
+	return &SubscriptionListResponse{
+		Items:      FromSubscriptionDTOs(p.Items, format),
+		Page:       page,
+		PageSize:   filter.PageSize,
+		Total:      p.Total,
+		NextCursor: nextCursor,
+		HasMore:    p.HasMore,
+	}
+}
+
+func FromDuplicateGroupDTOs(groups []usecasedto.DuplicateGroupDTO, format dto.DateFormat) *DuplicateGroupsResponse {
+	result := make([]DuplicateGroupResponse, len(groups))
+	for i, group := range groups {
+		result[i] = DuplicateGroupResponse{
+			ServiceName:   group.ServiceName,
+			Subscriptions: FromSubscriptionDTOs(group.Subscriptions, format),
+		}
+	}
+	return &DuplicateGroupsResponse{Items: result}
+}
+
+func FromSubscriptionMergeDTO(d usecasedto.SubscriptionMergeDTO, format dto.DateFormat) *MergeSubscriptionsResponse {
+	return &MergeSubscriptionsResponse{
+		Subscription: *FromSubscriptionDTO(d.Subscription, format),
+		MergedIDs:    dto.FromUUIDs(d.MergedIDs),
+		MergedBy:     d.MergedBy,
+		MergedAt:     d.MergedAt,
+	}
+}
+
+func FromSubscriptionEventDTO(d usecasedto.SubscriptionEventDTO, format dto.DateFormat) *SubscriptionEventResponse {
+	result := &SubscriptionEventResponse{Type: string(d.Type), OccurredAt: d.OccurredAt}
+	if d.Type != usecasedto.SubscriptionEventsReset {
+		result.Subscription = FromSubscriptionDTO(d.Subscription, format)
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package v2
+
+import (
+	"github.com/MDx3R/ef-test/internal/transport/http/dto"
+	usecasedto "github.com/MDx3R/ef-test/internal/usecase/dto"
+)
+
+func FromSubscriptionDTO(d usecasedto.SubscriptionDTO) *SubscriptionResponse {
+	var endDate *dto.MonthYear
+	if d.EndDate != nil {
+		month := dto.DateFormatISO.Format(d.EndDate)
+		endDate = &month
+	}
+
+	return &SubscriptionResponse{
+		ID:          d.ID.String(),
+		UserID:      d.UserID.String(),
+		ServiceName: d.ServiceName,
+		Price:       d.Price,
+		StartDate:   dto.DateFormatISO.Format(&d.StartDate),
+		EndDate:     endDate,
+		Notes:       d.Notes,
+	}
+}
+
+func FromSubscriptionDTOs(items []usecasedto.SubscriptionDTO) []SubscriptionResponse {
+	result := make([]SubscriptionResponse, len(items))
+	for i, item := range items {
+		result[i] = *FromSubscriptionDTO(item)
+	}
+	return result
+}
+
+func FromSubscriptionPageDTO(p usecasedto.SubscriptionPageDTO, filter usecasedto.SubscriptionFilter) *SubscriptionListResponse {
+	var nextCursor *string
+	if p.NextCursor != nil {
+		c := dto.EncodeCursor(*p.NextCursor)
+		nextCursor = &c
+	}
+
+	var page *int
+	if filter.After == nil {
+		page = &filter.Page
+	}
+
+	return &SubscriptionListResponse{
+		Items:      FromSubscriptionDTOs(p.Items),
+		Page:       page,
+		PageSize:   filter.PageSize,
+		Total:      p.Total,
+		NextCursor: nextCursor,
+		HasMore:    p.HasMore,
+	}
+}
+
+func FromDuplicateGroupDTOs(groups []usecasedto.DuplicateGroupDTO) *DuplicateGroupsResponse {
+	result := make([]DuplicateGroupResponse, len(groups))
+	for i, group := range groups {
+		result[i] = DuplicateGroupResponse{
+			ServiceName:   group.ServiceName,
+			Subscriptions: FromSubscriptionDTOs(group.Subscriptions),
+		}
+	}
+	return &DuplicateGroupsResponse{Items: result}
+}
+
+func FromSubscriptionMergeDTO(d usecasedto.SubscriptionMergeDTO) *MergeSubscriptionsResponse {
+	return &MergeSubscriptionsResponse{
+		Subscription: *FromSubscriptionDTO(d.Subscription),
+		MergedIDs:    dto.FromUUIDs(d.MergedIDs),
+		MergedBy:     d.MergedBy,
+		MergedAt:     d.MergedAt,
+	}
+}
+
+func FromSubscriptionEventDTO(d usecasedto.SubscriptionEventDTO) *SubscriptionEventResponse {
+	result := &SubscriptionEventResponse{Type: string(d.Type), OccurredAt: d.OccurredAt}
+	if d.Type != usecasedto.SubscriptionEventsReset {
+		result.Subscription = FromSubscriptionDTO(d.Subscription)
+	}
+	return result
+}
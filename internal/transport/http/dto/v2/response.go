@@ -0,0 +1,64 @@
+// Package v2 holds the response bodies of the second version of the API.
+// Months are rendered as YYYY-MM and absent dates as JSON null.
+package v2
+
+import (
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/transport/http/dto"
+)
+
+type SubscriptionResponse struct {
+	ID          string        `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ServiceName string        `json:"service_name" example:"Netflix"`
+	Price       int           `json:"price" example:"999"`
+	UserID      string        `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	StartDate   dto.MonthYear `json:"start_date" example:"2025-08"`
+	// EndDate is null for open-ended subscriptions.
+	EndDate *dto.MonthYear `json:"end_date" example:"2025-09"`
+	Notes   string         `json:"notes" example:"Семейный тариф"`
+}
+
+type SubscriptionListResponse struct {
+	Items []SubscriptionResponse `json:"items"`
+	// Page is null when the page was requested by cursor.
+	Page     *int `json:"page" example:"1"`
+	PageSize int  `json:"page_size" example:"20"`
+	// Total is null when include_total=false.
+	Total *int `json:"total" example:"42"`
+	// NextCursor is passed as the cursor query parameter to fetch the next page.
+	NextCursor *string `json:"next_cursor" example:"eyJkIjoiMjAyNS0wOC0wMSIsImlkIjoiMTIzZTQ1NjctZTg5Yi0xMmQzLWE0NTYtNDI2NjE0MTc0MDAwIn0"`
+	HasMore    bool    `json:"has_more" example:"true"`
+}
+
+type SubscriptionSearchResponse struct {
+	// Items are ordered by relevance, most relevant first.
+	Items []SubscriptionResponse `json:"items"`
+}
+
+type DuplicateGroupResponse struct {
+	ServiceName string `json:"service_name" example:"Netflix"`
+	// Subscriptions are ordered by start date.
+	Subscriptions []SubscriptionResponse `json:"subscriptions"`
+}
+
+type DuplicateGroupsResponse struct {
+	Items []DuplicateGroupResponse `json:"items"`
+}
+
+type MergeSubscriptionsResponse struct {
+	Subscription SubscriptionResponse `json:"subscription"`
+	MergedIDs    []string             `json:"merged_ids" example:"223e4567-e89b-12d3-a456-426614174000"`
+	// MergedBy is the subject of the caller, empty without authentication.
+	MergedBy string    `json:"merged_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+	MergedAt time.Time `json:"merged_at" example:"2025-08-01T12:00:00Z"`
+}
+
+// SubscriptionEventResponse is the data of an event in the subscription event stream.
+type SubscriptionEventResponse struct {
+	Type string `json:"type" enums:"created,updated,deleted,reset" example:"updated"`
+	// Subscription is the changed one, deleted ones have their last values.
+	// It is absent from reset events.
+	Subscription *SubscriptionResponse `json:"subscription,omitempty"`
+	OccurredAt   time.Time             `json:"occurred_at" example:"2025-08-01T12:00:00Z"`
+}
@@ -0,0 +1,124 @@
+package gin
+
+import (
+	"net/http"
+
+	"github.com/MDx3R/ef-test/internal/transport/http/dto"
+	"github.com/MDx3R/ef-test/internal/transport/http/problem"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type APIKeyHandler struct {
+	baseHandler
+	keyService usecase.APIKeyService
+}
+
+// Issue godoc
+// @Summary Выпустить API-ключ
+// @Description Выпускает ключ для межсервисного клиента. Ключ передаётся в заголовке X-API-Key
+// @Description и разрешает только действия из scopes: subscriptions:read — чтение подписок,
+// @Description subscriptions:write — их изменение, reports:read — расчёт суммарной стоимости.
+// @Description Сам ключ возвращается только в этом ответе, сервис хранит лишь его хэш.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param key body dto.IssueAPIKeyRequest true "Параметры ключа"
+// @Success 201 {object} dto.IssuedAPIKeyResponse "Выпущенный ключ"
+// @Failure 400 {object} dto.ProblemResponse "Неверный запрос"
+// @Failure 422 {object} dto.ValidationProblemResponse "Ошибка валидации"
+// @Failure 401 {object} dto.ProblemResponse "Требуется аутентификация"
+// @Failure 403 {object} dto.ProblemResponse "Требуется роль admin"
+// @Failure 429 {object} dto.ProblemResponse "Превышен лимит запросов"
+// @Failure 500 {object} dto.ProblemResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Router /admin/api-keys [post]
+func (h *APIKeyHandler) Issue(ctx *gin.Context) {
+	h.log(ctx).Info("handling issue api key request")
+	var request dto.IssueAPIKeyRequest
+
+	if err := ctx.ShouldBindBodyWithJSON(&request); err != nil {
+		h.log(ctx).WithError(err).Warn("invalid request body")
+		h.handleValidationError(ctx, err)
+		return
+	}
+
+	issued, err := h.keyService.IssueAPIKey(ctx.Request.Context(), *dto.ToIssueAPIKeyCommand(request))
+	if err != nil {
+		h.log(ctx).WithError(err).Error("failed to issue api key")
+		h.handleServiceError(ctx, err)
+		return
+	}
+
+	h.log(ctx).WithFields(logrus.Fields{
+		"api_key_id": issued.ID,
+		"scopes":     issued.Scopes,
+	}).Info("api key issued successfully")
+	ctx.JSON(http.StatusCreated, dto.FromIssuedAPIKeyDTO(issued))
+}
+
+// List godoc
+// @Summary Список API-ключей
+// @Description Возвращает все ключи, включая отозванные и истёкшие, новые первыми. Сами ключи не возвращаются.
+// @Tags api-keys
+// @Produce json
+// @Success 200 {object} dto.APIKeyListResponse
+// @Failure 401 {object} dto.ProblemResponse "Требуется аутентификация"
+// @Failure 403 {object} dto.ProblemResponse "Требуется роль admin"
+// @Failure 429 {object} dto.ProblemResponse "Превышен лимит запросов"
+// @Failure 500 {object} dto.ProblemResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Router /admin/api-keys [get]
+func (h *APIKeyHandler) List(ctx *gin.Context) {
+	h.log(ctx).Info("handling list api keys request")
+
+	keys, err := h.keyService.ListAPIKeys(ctx.Request.Context())
+	if err != nil {
+		h.log(ctx).WithError(err).Error("failed to list api keys")
+		h.handleServiceError(ctx, err)
+		return
+	}
+
+	h.log(ctx).WithField("count", len(keys)).Info("api keys listed successfully")
+	ctx.JSON(http.StatusOK, dto.FromAPIKeyDTOs(keys))
+}
+
+// Revoke godoc
+// @Summary Отозвать API-ключ
+// @Description Отзывает ключ по UUID. Отозванный ключ перестаёт приниматься сразу.
+// @Tags api-keys
+// @Param id path string true "API key ID" Format(uuid)
+// @Success 204 "Ключ отозван"
+// @Failure 400 {object} dto.ProblemResponse "Неверный UUID"
+// @Failure 404 {object} dto.ProblemResponse "Ключ не найден"
+// @Failure 401 {object} dto.ProblemResponse "Требуется аутентификация"
+// @Failure 403 {object} dto.ProblemResponse "Требуется роль admin"
+// @Failure 429 {object} dto.ProblemResponse "Превышен лимит запросов"
+// @Failure 500 {object} dto.ProblemResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Router /admin/api-keys/{id} [delete]
+func (h *APIKeyHandler) Revoke(ctx *gin.Context) {
+	h.log(ctx).Info("handling revoke api key request")
+	id, ok := h.parseUUIDParam(ctx, "id")
+	if !ok {
+		h.log(ctx).Warn("invalid uuid parameter")
+		return
+	}
+
+	if err := h.keyService.RevokeAPIKey(ctx.Request.Context(), id); err != nil {
+		h.log(ctx).WithError(err).WithField("api_key_id", id).Error("failed to revoke api key")
+		h.handleServiceError(ctx, err)
+		return
+	}
+
+	h.log(ctx).WithField("api_key_id", id).Info("api key revoked successfully")
+	ctx.Status(http.StatusNoContent)
+}
+
+func NewAPIKeyHandler(keyService usecase.APIKeyService, logger *logrus.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		baseHandler: baseHandler{logger: logger, notFound: problem.CodeAPIKeyNotFound},
+		keyService:  keyService,
+	}
+}
@@ -0,0 +1,157 @@
+package gin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/domain"
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	handlers "github.com/MDx3R/ef-test/internal/transport/http/gin"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	mock_usecase "github.com/MDx3R/ef-test/internal/usecase/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupAPIKeyRouter(t *testing.T) (*gin.Engine, *mock_usecase.MockAPIKeyService) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := mock_usecase.NewMockAPIKeyService(t)
+	handler := handlers.NewAPIKeyHandler(mockService, logger)
+
+	r := gin.New()
+	r.POST("/", handler.Issue)
+	r.GET("/", handler.List)
+	r.DELETE("/:id", handler.Revoke)
+
+	return r, mockService
+}
+
+func TestAPIKeyHandler_Issue_Success(t *testing.T) {
+	router, mockService := setupAPIKeyRouter(t)
+
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	command := dto.IssueAPIKeyCommand{
+		Name:      "billing-export",
+		Scopes:    []string{entity.ScopeReportsRead},
+		ExpiresAt: &expiresAt,
+	}
+	issued := dto.IssuedAPIKeyDTO{
+		APIKeyDTO: dto.APIKeyDTO{ID: uuid.New(), Name: command.Name, Prefix: "sk_abcdefgh", Scopes: command.Scopes},
+		Key:       "sk_abcdefghsecret",
+	}
+
+	mockService.On("IssueAPIKey", mock.Anything, command).Return(issued, nil)
+
+	body := `{"name":"billing-export","scopes":["reports:read"],"expires_at":"2030-01-01T03:00:00+03:00"}`
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockService.AssertExpectations(t)
+	assert.Contains(t, w.Body.String(), `"key":"sk_abcdefghsecret"`)
+	assert.Contains(t, w.Body.String(), `"scopes":["reports:read"]`)
+}
+
+func TestAPIKeyHandler_Issue_InvalidScope(t *testing.T) {
+	router, _ := setupAPIKeyRouter(t)
+
+	body := `{"name":"billing-export","scopes":["subscriptions:admin"]}`
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "Scopes[0]")
+}
+
+func TestAPIKeyHandler_Issue_ExpiryInPast(t *testing.T) {
+	router, mockService := setupAPIKeyRouter(t)
+
+	mockService.On("IssueAPIKey", mock.Anything, mock.Anything).Return(dto.IssuedAPIKeyDTO{}, domain.ErrInvalidExpiry)
+
+	body := `{"name":"billing-export","scopes":["reports:read"],"expires_at":"2020-01-01T00:00:00Z"}`
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestAPIKeyHandler_Issue_Forbidden(t *testing.T) {
+	router, mockService := setupAPIKeyRouter(t)
+
+	mockService.On("IssueAPIKey", mock.Anything, mock.Anything).Return(dto.IssuedAPIKeyDTO{}, usecase.ErrForbidden)
+
+	body := `{"name":"billing-export","scopes":["reports:read"]}`
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestAPIKeyHandler_List_Success(t *testing.T) {
+	router, mockService := setupAPIKeyRouter(t)
+
+	key := dto.APIKeyDTO{ID: uuid.New(), Name: "billing-export", Prefix: "sk_abcdefgh", Scopes: []string{entity.ScopeReportsRead}}
+	mockService.On("ListAPIKeys", mock.Anything).Return([]dto.APIKeyDTO{key}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+	assert.Contains(t, w.Body.String(), key.ID.String())
+	assert.NotContains(t, w.Body.String(), `"key"`)
+}
+
+func TestAPIKeyHandler_Revoke_Success(t *testing.T) {
+	router, mockService := setupAPIKeyRouter(t)
+
+	id := uuid.New()
+	mockService.On("RevokeAPIKey", mock.Anything, id).Return(nil)
+
+	req := httptest.NewRequest(http.MethodDelete, "/"+id.String(), nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestAPIKeyHandler_Revoke_NotFound(t *testing.T) {
+	router, mockService := setupAPIKeyRouter(t)
+
+	id := uuid.New()
+	mockService.On("RevokeAPIKey", mock.Anything, id).Return(usecase.ErrNotFound)
+
+	req := httptest.NewRequest(http.MethodDelete, "/"+id.String(), nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"api_key.not_found"`)
+	mockService.AssertExpectations(t)
+}
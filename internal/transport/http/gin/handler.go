@@ -0,0 +1,86 @@
+package gin
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/MDx3R/ef-test/internal/transport/http/problem"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// baseHandler holds the request parsing and error responses shared by handlers.
+type baseHandler struct {
+	logger *logrus.Logger
+	// notFound is the code of missing resources the handler serves.
+	notFound problem.Code
+}
+
+// log returns the log entry of the request, so that its lines share the request ID.
+func (h *baseHandler) log(ctx *gin.Context) *logrus.Entry {
+	if entry, ok := usecase.LoggerFrom(ctx.Request.Context()); ok {
+		return entry
+	}
+	return h.logger.WithContext(ctx.Request.Context())
+}
+
+func (h *baseHandler) parseUUIDParam(ctx *gin.Context, param string) (uuid.UUID, bool) {
+	idStr := ctx.Param(param)
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		h.log(ctx).WithField("param", idStr).Warn("uuid not valid")
+		problem.Respond(ctx, problem.CodeInvalidID, fmt.Sprintf("uuid not valid: %s", idStr))
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// handleServiceError responds with the problem of err from the catalogue.
+// Missing resources are reported with the notFound code of the handler.
+func (h *baseHandler) handleServiceError(ctx *gin.Context, err error) {
+	var denied *usecase.AccessDeniedError
+
+	switch {
+	case errors.As(err, &denied):
+		h.log(ctx).WithFields(logrus.Fields{
+			"action":   denied.Action,
+			"resource": denied.Resource,
+			"roles":    denied.Roles,
+		}).Warn("access denied")
+		problem.RespondAccessDenied(ctx, denied)
+	case errors.Is(err, usecase.ErrNotFound) && h.notFound != "":
+		problem.Respond(ctx, h.notFound, err.Error())
+	default:
+		problem.RespondError(ctx, err)
+	}
+}
+
+func (h *baseHandler) handleValidationError(ctx *gin.Context, err error) {
+	h.log(ctx).WithFields(logrus.Fields{
+		"error":  err,
+		"path":   ctx.FullPath(),
+		"method": ctx.Request.Method,
+	}).Warn("validation error")
+
+	var verr validator.ValidationErrors
+
+	if errors.As(err, &verr) {
+		problem.RespondValidation(ctx, h.buildMap(ctx, verr))
+		return
+	}
+
+	problem.Respond(ctx, problem.CodeInvalidRequest, err.Error())
+}
+
+// buildMap explains the invalid fields in the language of the request.
+func (h *baseHandler) buildMap(ctx *gin.Context, verr validator.ValidationErrors) map[string]string {
+	trans := problem.TranslatorFrom(ctx)
+	errorsMap := make(map[string]string)
+	for _, fe := range verr {
+		errorsMap[fe.Field()] = fe.Translate(trans)
+	}
+	return errorsMap
+}
@@ -0,0 +1,63 @@
+package gin
+
+import (
+	"net/http"
+
+	"github.com/MDx3R/ef-test/internal/transport/http/dto"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	usecasedto "github.com/MDx3R/ef-test/internal/usecase/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type HealthHandler struct {
+	baseHandler
+	healthService usecase.HealthService
+}
+
+// Live godoc
+// @Summary Проверка жизнеспособности
+// @Description Отвечает 200, пока процесс работает. Зависимости не проверяются,
+// @Description чтобы недоступность базы данных не приводила к перезапуску сервиса.
+// @Tags health
+// @Produce json
+// @Success 200 {object} dto.HealthResponse "Сервис работает"
+// @Router /healthz [get]
+func (h *HealthHandler) Live(ctx *gin.Context) {
+	h.respondHealth(ctx, h.healthService.Live(ctx.Request.Context()))
+}
+
+// Ready godoc
+// @Summary Проверка готовности
+// @Description Проверяет подключение к базе данных и применение всех миграций,
+// @Description состояние каждого компонента возвращается в components.
+// @Description Во время остановки сервиса отвечает 503, чтобы балансировщик перестал направлять запросы.
+// @Tags health
+// @Produce json
+// @Success 200 {object} dto.HealthResponse "Сервис готов принимать запросы"
+// @Failure 503 {object} dto.HealthResponse "Сервис не готов"
+// @Router /readyz [get]
+func (h *HealthHandler) Ready(ctx *gin.Context) {
+	report := h.healthService.Ready(ctx.Request.Context())
+	if report.Status != usecasedto.HealthStatusUp {
+		h.log(ctx).WithField("components", report.Components).Warn("service is not ready")
+	}
+	h.respondHealth(ctx, report)
+}
+
+func (h *HealthHandler) respondHealth(ctx *gin.Context, report usecasedto.HealthReport) {
+	code := http.StatusOK
+	if report.Status != usecasedto.HealthStatusUp {
+		code = http.StatusServiceUnavailable
+	}
+	// Probes must see the current state, not a cached one.
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(code, dto.FromHealthReport(report))
+}
+
+func NewHealthHandler(healthService usecase.HealthService, logger *logrus.Logger) *HealthHandler {
+	return &HealthHandler{
+		baseHandler:   baseHandler{logger: logger},
+		healthService: healthService,
+	}
+}
@@ -0,0 +1,81 @@
+package gin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	handlers "github.com/MDx3R/ef-test/internal/transport/http/gin"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	mock_usecase "github.com/MDx3R/ef-test/internal/usecase/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupHealthRouter(t *testing.T) (*gin.Engine, *mock_usecase.MockHealthService) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := mock_usecase.NewMockHealthService(t)
+	handler := handlers.NewHealthHandler(mockService, logger)
+
+	r := gin.New()
+	r.GET("/healthz", handler.Live)
+	r.GET("/readyz", handler.Ready)
+
+	return r, mockService
+}
+
+func TestHealthHandler_Live(t *testing.T) {
+	router, mockService := setupHealthRouter(t)
+
+	mockService.On("Live", mock.Anything).Return(dto.HealthReport{Status: dto.HealthStatusUp})
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.JSONEq(t, `{"status":"up","components":{}}`, w.Body.String())
+}
+
+func TestHealthHandler_Ready(t *testing.T) {
+	router, mockService := setupHealthRouter(t)
+
+	mockService.On("Ready", mock.Anything).Return(dto.HealthReport{
+		Status: dto.HealthStatusUp,
+		Components: []dto.ComponentHealth{
+			{Name: "database", Status: dto.HealthStatusUp},
+			{Name: "migrations", Status: dto.HealthStatusUp},
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"up","components":{"database":{"status":"up"},"migrations":{"status":"up"}}}`, w.Body.String())
+}
+
+func TestHealthHandler_Ready_NotReady(t *testing.T) {
+	router, mockService := setupHealthRouter(t)
+
+	mockService.On("Ready", mock.Anything).Return(dto.HealthReport{
+		Status: dto.HealthStatusDown,
+		Components: []dto.ComponentHealth{
+			{Name: "database", Status: dto.HealthStatusDown, Error: "connection refused"},
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.JSONEq(t, `{"status":"down","components":{"database":{"status":"down","error":"connection refused"}}}`, w.Body.String())
+}
@@ -0,0 +1,67 @@
+package gin
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	httpdto "github.com/MDx3R/ef-test/internal/transport/http/dto"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+)
+
+// buildLinks returns RFC 8288 links to the neighbouring pages of a listed page.
+// Links keep every query parameter of the request except the pagination ones.
+func buildLinks(u url.URL, result dto.SubscriptionPageDTO, filter dto.SubscriptionFilter) []string {
+	query := u.Query()
+
+	link := func(rel string, set func(q url.Values)) string {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		q.Del("cursor")
+		q.Del("page")
+		set(q)
+
+		target := u
+		target.RawQuery = q.Encode()
+		return fmt.Sprintf(`<%s>; rel="%s"`, target.RequestURI(), rel)
+	}
+
+	setPage := func(page int) func(q url.Values) {
+		return func(q url.Values) { q.Set("page", strconv.Itoa(page)) }
+	}
+
+	links := []string{link("first", setPage(1))}
+
+	// Cursor pages only know the way forward.
+	if filter.After != nil {
+		if result.NextCursor != nil {
+			cursor := httpdto.EncodeCursor(*result.NextCursor)
+			links = append(links, link("next", func(q url.Values) { q.Set("cursor", cursor) }))
+		}
+		return links
+	}
+
+	page := filter.Page
+	lastPage := 0
+	if result.Total != nil && filter.PageSize > 0 {
+		lastPage = max(1, (*result.Total+filter.PageSize-1)/filter.PageSize)
+	}
+
+	if page > 1 {
+		prev := page - 1
+		if lastPage > 0 {
+			prev = min(prev, lastPage)
+		}
+		links = append(links, link("prev", setPage(prev)))
+	}
+	if result.HasMore {
+		links = append(links, link("next", setPage(page+1)))
+	}
+	if lastPage > 0 {
+		links = append(links, link("last", setPage(lastPage)))
+	}
+
+	return links
+}
@@ -0,0 +1,121 @@
+package gin
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/transport/http/dto"
+	"github.com/MDx3R/ef-test/internal/transport/http/problem"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// LastEventIDHeader is sent by EventSource clients when they reconnect.
+const LastEventIDHeader = "Last-Event-ID"
+
+// SubscriptionEventHandler streams subscription changes as server-sent events.
+type SubscriptionEventHandler struct {
+	baseHandler
+	events usecase.SubscriptionEventService
+	view   SubscriptionView
+	// heartbeat is how often streams get a comment, so proxies keep them open.
+	// Streams get none if it isn't positive.
+	heartbeat time.Duration
+}
+
+func NewSubscriptionEventHandler(
+	events usecase.SubscriptionEventService,
+	view SubscriptionView,
+	heartbeat time.Duration,
+	logger *logrus.Logger,
+) *SubscriptionEventHandler {
+	return &SubscriptionEventHandler{
+		baseHandler: baseHandler{logger: logger},
+		events:      events,
+		view:        view,
+		heartbeat:   heartbeat,
+	}
+}
+
+// Events streams the changes of the subscriptions matching the query
+// until the client disconnects or the service shuts down.
+func (h *SubscriptionEventHandler) Events(ctx *gin.Context) {
+	h.log(ctx).Info("handling subscription events request")
+	var query dto.SubscriptionEventsRequest
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		h.log(ctx).WithError(err).Warn("invalid query parameters")
+		h.handleValidationError(ctx, err)
+		return
+	}
+	if lastEventID := ctx.GetHeader(LastEventIDHeader); lastEventID != "" {
+		query.LastEventID = &lastEventID
+	}
+
+	filter, err := dto.ToSubscriptionEventFilter(query)
+	if err != nil {
+		h.log(ctx).WithError(err).Warn("failed to build filter")
+		h.handleValidationError(ctx, err)
+		return
+	}
+
+	renderer, err := h.view.Negotiate(ctx)
+	if err != nil {
+		h.log(ctx).WithError(err).Warn("unsupported representation")
+		problem.Respond(ctx, problem.CodeInvalidRequest, err.Error())
+		return
+	}
+
+	events, err := h.events.Watch(ctx.Request.Context(), *filter)
+	if err != nil {
+		h.log(ctx).WithError(err).Error("failed to watch subscription events")
+		h.handleServiceError(ctx, err)
+		return
+	}
+
+	// Streams outlive the write timeout of the server.
+	if err := http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		h.log(ctx).WithError(err).Warn("failed to lift write deadline")
+	}
+
+	// Spelled as sse.Event sets it, so that the header doesn't change after the first event.
+	ctx.Header("Content-Type", sse.ContentType+";charset=utf-8")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	// Disables response buffering of nginx.
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.Flush()
+
+	var heartbeat <-chan time.Time
+	if h.heartbeat > 0 {
+		ticker := time.NewTicker(h.heartbeat)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+
+	sent := 0
+	for {
+		select {
+		case <-heartbeat:
+			if _, err := ctx.Writer.WriteString(":\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				h.log(ctx).WithField("events", sent).Info("subscription event stream closed")
+				return
+			}
+			ctx.Render(-1, sse.Event{
+				Id:    strconv.FormatUint(event.ID, 10),
+				Event: string(event.Type),
+				Data:  renderer.Event(event),
+			})
+			sent++
+		}
+		ctx.Writer.Flush()
+	}
+}
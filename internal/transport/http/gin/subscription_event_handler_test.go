@@ -0,0 +1,106 @@
+package gin_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	handlersv1 "github.com/MDx3R/ef-test/internal/transport/http/gin/v1"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	mock_usecase "github.com/MDx3R/ef-test/internal/usecase/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupEventRouter(t *testing.T) (*gin.Engine, *mock_usecase.MockSubscriptionEventService) {
+	gin.SetMode(gin.TestMode)
+
+	mockEvents := mock_usecase.NewMockSubscriptionEventService(t)
+	handler := handlersv1.NewSubscriptionEventHandler(mockEvents, 0, logger)
+
+	r := gin.New()
+	r.GET("/events", handler.Events)
+
+	return r, mockEvents
+}
+
+// closedEvents returns a channel that delivers the events and ends the stream.
+func closedEvents(events ...dto.SubscriptionEventDTO) <-chan dto.SubscriptionEventDTO {
+	ch := make(chan dto.SubscriptionEventDTO, len(events))
+	for _, event := range events {
+		ch <- event
+	}
+	close(ch)
+	return ch
+}
+
+func TestSubscriptionEventHandler_Events_Stream(t *testing.T) {
+	router, mockEvents := setupEventRouter(t)
+
+	sub := makeTestSubscriptionDTO(t)
+	mockEvents.On("Watch", mock.Anything, dto.SubscriptionEventFilter{}).Return(closedEvents(
+		dto.SubscriptionEventDTO{ID: 1, Type: dto.SubscriptionCreated, Subscription: sub},
+		dto.SubscriptionEventDTO{ID: 2, Type: dto.SubscriptionEventsReset},
+	), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/events", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream;charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "id:1\nevent:created\ndata:{\"type\":\"created\",\"subscription\":{\"id\":\""+sub.ID.String())
+	assert.Contains(t, w.Body.String(), `"start_date":"08-2025"`)
+	assert.Contains(t, w.Body.String(), "id:2\nevent:reset\ndata:{\"type\":\"reset\",\"occurred_at\"")
+}
+
+func TestSubscriptionEventHandler_Events_Filter(t *testing.T) {
+	router, mockEvents := setupEventRouter(t)
+
+	userID := uuid.New()
+	name := "Netflix"
+	lastEventID := uint64(42)
+	mockEvents.On("Watch", mock.Anything, dto.SubscriptionEventFilter{
+		UserID:      &userID,
+		ServiceName: &name,
+		LastEventID: &lastEventID,
+	}).Return(closedEvents(), nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/events?service_name=Netflix&last_event_id=7&user_id="+userID.String(), nil)
+	req.Header.Set("Last-Event-ID", "42")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockEvents.AssertExpectations(t)
+}
+
+func TestSubscriptionEventHandler_Events_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		expectCode int
+	}{
+		{name: "invalid user_id", url: "/events?user_id=not-uuid", expectCode: http.StatusUnprocessableEntity},
+		{name: "invalid last_event_id", url: "/events?last_event_id=abc", expectCode: http.StatusBadRequest},
+		{name: "invalid date_format", url: "/events?date_format=unix", expectCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, _ := setupEventRouter(t)
+
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectCode, w.Code)
+			assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+		})
+	}
+}
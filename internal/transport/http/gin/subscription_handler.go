@@ -1,309 +1,373 @@
 package gin
 
 import (
-	"errors"
-	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/MDx3R/ef-test/internal/transport/http/dto"
+	"github.com/MDx3R/ef-test/internal/transport/http/problem"
 	"github.com/MDx3R/ef-test/internal/usecase"
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
-	"github.com/google/uuid"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/sirupsen/logrus"
 )
 
+// SubscriptionHandler serves the subscription routes shared by API versions.
+// Subscriptions in responses are rendered by the view of the version.
 type SubscriptionHandler struct {
+	baseHandler
 	subService usecase.SubscriptionService
-	logger     *logrus.Logger
+	view       SubscriptionView
 }
 
-// Get godoc
-// @Summary Получить подписку по ID
-// @Description Возвращает подписку по заданному UUID
-// @Tags subscriptions
-// @Param id path string true "Subscription ID" Format(uuid)
-// @Produce json
-// @Success 200 {object} dto.SubscriptionResponse "Подписка найдена"
-// @Failure 400 {object} dto.ErrorResponse "Неверный UUID"
-// @Failure 404 {object} dto.ErrorResponse "Подписка не найдена"
-// @Failure 500 {object} dto.ErrorResponse "Внутренняя ошибка сервера"
-// @Router /subscriptions/{id} [get]
+// negotiate returns the renderer of the representation asked for by the request.
+func (h *SubscriptionHandler) negotiate(ctx *gin.Context) (SubscriptionRenderer, bool) {
+	renderer, err := h.view.Negotiate(ctx)
+	if err != nil {
+		h.log(ctx).WithError(err).Warn("unsupported representation")
+		problem.Respond(ctx, problem.CodeInvalidRequest, err.Error())
+		return nil, false
+	}
+	return renderer, true
+}
+
+// Get responds with the subscription of the id path parameter.
 func (h *SubscriptionHandler) Get(ctx *gin.Context) {
-	h.logger.Info("handling get subscription request")
+	h.log(ctx).Info("handling get subscription request")
 	id, ok := h.parseUUIDParam(ctx, "id")
 	if !ok {
-		h.logger.Warn("invalid uuid parameter")
+		h.log(ctx).Warn("invalid uuid parameter")
+		return
+	}
+
+	renderer, ok := h.negotiate(ctx)
+	if !ok {
 		return
 	}
 
-	sub, err := h.subService.GetSubscription(id)
+	sub, err := h.subService.GetSubscription(ctx.Request.Context(), id)
 	if err != nil {
-		h.logger.WithError(err).WithField("subscription_id", id).Error("failed to get subscription")
+		h.log(ctx).WithError(err).WithField("subscription_id", id).Error("failed to get subscription")
 		h.handleServiceError(ctx, err)
 		return
 	}
 
-	h.logger.WithField("subscription_id", id).Info("subscription retrieved successfully")
-	ctx.JSON(http.StatusOK, *dto.FromSubscriptionDTO(sub))
+	h.log(ctx).WithField("subscription_id", id).Info("subscription retrieved successfully")
+	ctx.JSON(http.StatusOK, renderer.Subscription(sub))
 }
 
-// List godoc
-// @Summary Список подписок
-// @Description Возвращает список подписок с фильтрацией по параметрам
-// @Tags subscriptions
-// @Produce json
-// @Param filter query dto.SubscriptionQueryRequest false "Фильтры подписок"
-// @Success 200 {array} dto.SubscriptionResponse
-// @Failure 400 {object} dto.ErrorResponse "Ошибка валидации параметров запроса"
-// @Failure 500 {object} dto.ErrorResponse "Внутренняя ошибка сервера"
-// @Router /subscriptions [get]
+// List responds with a page of the subscriptions matching the query.
 func (h *SubscriptionHandler) List(ctx *gin.Context) {
-	h.logger.Info("handling list subscriptions request")
+	h.log(ctx).Info("handling list subscriptions request")
 	var query dto.SubscriptionQueryRequest
 
+	renderer, ok := h.negotiate(ctx)
+	if !ok {
+		return
+	}
+
 	if err := ctx.ShouldBindQuery(&query); err != nil {
-		h.logger.WithError(err).Warn("failed to bind query parameters")
+		h.log(ctx).WithError(err).Warn("failed to bind query parameters")
 		h.handleValidationError(ctx, err)
 		return
 	}
+	if h.view.BoundsPageSize() {
+		if err := binding.Validator.ValidateStruct(dto.PageSizeLimit{PageSize: query.PageSize}); err != nil {
+			h.log(ctx).WithError(err).Warn("page size exceeds the limit")
+			h.handleValidationError(ctx, err)
+			return
+		}
+	}
 
 	filter, err := dto.ToSubscriptionFilter(query)
 	if err != nil {
-		h.logger.WithError(err).Warn("failed to build filter")
+		h.log(ctx).WithError(err).Warn("failed to build filter")
 		h.handleValidationError(ctx, err)
 		return
 	}
 
-	subs, err := h.subService.ListSubscriptions(*filter)
+	page, err := h.subService.ListSubscriptions(ctx.Request.Context(), *filter)
 	if err != nil {
-		h.logger.WithError(err).Error("failed to list subscriptions")
+		h.log(ctx).WithError(err).Error("failed to list subscriptions")
 		h.handleServiceError(ctx, err)
 		return
 	}
 
-	result := make([]dto.SubscriptionResponse, len(subs))
-	for i, sub := range subs {
-		result[i] = *dto.FromSubscriptionDTO(sub)
+	if links := buildLinks(*ctx.Request.URL, page, *filter); len(links) > 0 {
+		ctx.Header("Link", strings.Join(links, ", "))
+	}
+
+	h.log(ctx).WithFields(logrus.Fields{
+		"count":    len(page.Items),
+		"has_more": page.HasMore,
+	}).Info("subscriptions listed successfully")
+	ctx.JSON(http.StatusOK, renderer.Page(page, *filter))
+}
+
+// Search responds with the subscriptions matching the search query by relevance.
+func (h *SubscriptionHandler) Search(ctx *gin.Context) {
+	h.log(ctx).Info("handling search subscriptions request")
+	var query dto.SubscriptionSearchRequest
+
+	renderer, ok := h.negotiate(ctx)
+	if !ok {
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		h.log(ctx).WithError(err).Warn("failed to bind query parameters")
+		h.handleValidationError(ctx, err)
+		return
+	}
+
+	filter, err := dto.ToSubscriptionSearchFilter(query)
+	if err != nil {
+		h.log(ctx).WithError(err).Warn("failed to build filter")
+		h.handleValidationError(ctx, err)
+		return
+	}
+
+	subs, err := h.subService.SearchSubscriptions(ctx.Request.Context(), *filter)
+	if err != nil {
+		h.log(ctx).WithError(err).Error("failed to search subscriptions")
+		h.handleServiceError(ctx, err)
+		return
 	}
 
-	h.logger.WithField("count", len(result)).Info("subscriptions listed successfully")
-	ctx.JSON(http.StatusOK, subs)
+	h.log(ctx).WithField("count", len(subs)).Info("subscriptions searched successfully")
+	ctx.JSON(http.StatusOK, renderer.Search(subs))
 }
 
-// Delete godoc
-// @Summary Удалить подписку по ID
-// @Description Удаляет подписку по UUID
-// @Tags subscriptions
-// @Param id path string true "Subscription ID" Format(uuid)
-// @Success 204 "Подписка удалена"
-// @Failure 400 {object} dto.ErrorResponse "Неверный UUID"
-// @Failure 404 {object} dto.ErrorResponse "Подписка не найдена"
-// @Failure 500 {object} dto.ErrorResponse "Внутренняя ошибка сервера"
-// @Router /subscriptions/{id} [delete]
+// Delete deletes the subscription of the id path parameter.
 func (h *SubscriptionHandler) Delete(ctx *gin.Context) {
-	h.logger.Info("handling delete subscription request")
+	h.log(ctx).Info("handling delete subscription request")
 	id, ok := h.parseUUIDParam(ctx, "id")
 	if !ok {
-		h.logger.Warn("invalid uuid parameter")
+		h.log(ctx).Warn("invalid uuid parameter")
 		return
 	}
 
-	if err := h.subService.DeleteSubscription(id); err != nil {
-		h.logger.WithError(err).WithField("subscription_id", id).Error("failed to delete subscription")
+	if err := h.subService.DeleteSubscription(ctx.Request.Context(), id); err != nil {
+		h.log(ctx).WithError(err).WithField("subscription_id", id).Error("failed to delete subscription")
 		h.handleServiceError(ctx, err)
 		return
 	}
 
-	h.logger.WithField("subscription_id", id).Info("subscription deleted successfully")
+	h.log(ctx).WithField("subscription_id", id).Info("subscription deleted successfully")
 	ctx.JSON(http.StatusNoContent, gin.H{})
 }
 
-// Create godoc
-// @Summary Создать подписку
-// @Description Создает новую подписку с данными из JSON
-// @Tags subscriptions
-// @Accept json
-// @Produce json
-// @Param subscription body dto.CreateSubscriptionRequest true "Данные новой подписки"
-// @Success 201 {object} dto.IDResponse "ID созданной подписки"
-// @Failure 400 {object} dto.ErrorResponse "Неверный запрос"
-// @Failure 422 {object} dto.ValidationErrorResponse "Ошибка валидации"
-// @Failure 500 {object} dto.ErrorResponse "Внутренняя ошибка сервера"
-// @Router /subscriptions [post]
+// Create creates a subscription and responds with its ID.
 func (h *SubscriptionHandler) Create(ctx *gin.Context) {
-	h.logger.Info("handling create subscription request")
+	h.log(ctx).Info("handling create subscription request")
 	var request dto.CreateSubscriptionRequest
 
 	if err := ctx.ShouldBindBodyWithJSON(&request); err != nil {
-		h.logger.WithError(err).Warn("invalid request body")
+		h.log(ctx).WithError(err).Warn("invalid request body")
 		h.handleValidationError(ctx, err)
 		return
 	}
 
 	command, err := dto.ToCreateSubscriptionCommand(request)
 	if err != nil {
-		h.logger.WithError(err).Warn("failed to build command")
+		h.log(ctx).WithError(err).Warn("failed to build command")
 		h.handleValidationError(ctx, err)
 		return
 	}
 
-	id, err := h.subService.CreateSubscription(*command)
+	id, err := h.subService.CreateSubscription(ctx.Request.Context(), *command)
 	if err != nil {
-		h.logger.WithError(err).Error("failed to create subscription")
+		h.log(ctx).WithError(err).Error("failed to create subscription")
 		h.handleServiceError(ctx, err)
 		return
 	}
 
-	h.logger.WithField("subscription_id", id).Info("subscription created successfully")
+	h.log(ctx).WithField("subscription_id", id).Info("subscription created successfully")
 	ctx.JSON(http.StatusCreated, dto.IDResponse{ID: id})
 }
 
-// Update godoc
-// @Summary Обновить подписку
-// @Description Обновляет подписку по UUID с данными из JSON
-// @Tags subscriptions
-// @Accept json
-// @Produce json
-// @Param id path string true "Subscription ID" Format(uuid)
-// @Param subscription body dto.UpdateSubscriptionRequest true "Данные обновления подписки"
-// @Success 204 "Подписка обновлена"
-// @Failure 400 {object} dto.ErrorResponse "Неверный UUID или данные запроса"
-// @Failure 422 {object} dto.ValidationErrorResponse "Ошибка валидации"
-// @Failure 404 {object} dto.ErrorResponse "Подписка не найдена"
-// @Failure 500 {object} dto.ErrorResponse "Внутренняя ошибка сервера"
-// @Router /subscriptions/{id} [put]
+// Update replaces the subscription of the id path parameter.
 func (h *SubscriptionHandler) Update(ctx *gin.Context) {
-	h.logger.Info("handling update subscription request")
+	h.log(ctx).Info("handling update subscription request")
 	var request dto.UpdateSubscriptionRequest
 
 	id, ok := h.parseUUIDParam(ctx, "id")
 	if !ok {
-		h.logger.Warn("invalid uuid parameter")
+		h.log(ctx).Warn("invalid uuid parameter")
 		return
 	}
 
 	if err := ctx.ShouldBindBodyWithJSON(&request); err != nil {
-		h.logger.WithError(err).Warn("invalid request body")
+		h.log(ctx).WithError(err).Warn("invalid request body")
 		h.handleValidationError(ctx, err)
 		return
 	}
 
 	command, err := dto.ToUpdateSubscriptionCommand(request)
 	if err != nil {
-		h.logger.WithError(err).Warn("failed to build command")
+		h.log(ctx).WithError(err).Warn("failed to build command")
 		h.handleValidationError(ctx, err)
 		return
 	}
 
-	if err := h.subService.UpdateSubscription(id, *command); err != nil {
-		h.logger.WithError(err).WithField("subscription_id", id).Error("failed to update subscription")
+	if err := h.subService.UpdateSubscription(ctx.Request.Context(), id, *command); err != nil {
+		h.log(ctx).WithError(err).WithField("subscription_id", id).Error("failed to update subscription")
 		h.handleServiceError(ctx, err)
 		return
 	}
 
-	h.logger.WithField("subscription_id", id).Info("subscription updated successfully")
+	h.log(ctx).WithField("subscription_id", id).Info("subscription updated successfully")
 	ctx.JSON(http.StatusNoContent, gin.H{})
 }
 
-// CalculateTotalCost godoc
-// @Summary Рассчитать общую стоимость подписок
-// @Description Возвращает общую стоимость подписок по фильтру
-// @Tags subscriptions
-// @Produce json
-// @Param filter query dto.TotalCostQueryRequest true "Фильтр для расчета стоимости"
-// @Success 200 {object} dto.IntResponse "Результат расчета стоимости"
-// @Failure 400 {object} dto.ErrorResponse "Ошибка валидации параметров запроса"
-// @Failure 500 {object} dto.ErrorResponse "Внутренняя ошибка сервера"
-// @Router /subscriptions/total [get]
+// MergePatchContentType is the media type of JSON merge patches (RFC 7396).
+const MergePatchContentType = "application/merge-patch+json"
+
+// Patch applies a JSON merge patch to a subscription and responds with the result.
+func (h *SubscriptionHandler) Patch(ctx *gin.Context) {
+	h.log(ctx).Info("handling patch subscription request")
+	var request dto.PatchSubscriptionRequest
+
+	id, ok := h.parseUUIDParam(ctx, "id")
+	if !ok {
+		h.log(ctx).Warn("invalid uuid parameter")
+		return
+	}
+
+	if ctx.ContentType() != MergePatchContentType {
+		h.log(ctx).WithField("content_type", ctx.ContentType()).Warn("unsupported patch media type")
+		ctx.Header("Accept-Patch", MergePatchContentType)
+		problem.Respond(ctx, problem.CodeUnsupportedMediaType, "use "+MergePatchContentType)
+		return
+	}
+
+	renderer, ok := h.negotiate(ctx)
+	if !ok {
+		return
+	}
+
+	if err := ctx.ShouldBindBodyWithJSON(&request); err != nil {
+		h.log(ctx).WithError(err).Warn("invalid request body")
+		h.handleValidationError(ctx, err)
+		return
+	}
+
+	command, err := dto.ToPatchSubscriptionCommand(request)
+	if err != nil {
+		h.log(ctx).WithError(err).Warn("failed to build command")
+		h.handleValidationError(ctx, err)
+		return
+	}
+
+	sub, err := h.subService.PatchSubscription(ctx.Request.Context(), id, *command)
+	if err != nil {
+		h.log(ctx).WithError(err).WithField("subscription_id", id).Error("failed to patch subscription")
+		h.handleServiceError(ctx, err)
+		return
+	}
+
+	h.log(ctx).WithField("subscription_id", id).Info("subscription patched successfully")
+	ctx.JSON(http.StatusOK, renderer.Subscription(sub))
+}
+
+// CalculateTotalCost responds with the cost of the subscriptions over a period.
 func (h *SubscriptionHandler) CalculateTotalCost(ctx *gin.Context) {
-	h.logger.Info("handling calculate total cost request")
+	h.log(ctx).Info("handling calculate total cost request")
 	var query dto.TotalCostQueryRequest
 
 	if err := ctx.ShouldBindQuery(&query); err != nil {
-		h.logger.WithError(err).Warn("invalid query parameters")
+		h.log(ctx).WithError(err).Warn("invalid query parameters")
 		h.handleValidationError(ctx, err)
 		return
 	}
 
 	filter, err := dto.ToTotalCostFilter(query)
 	if err != nil {
-		h.logger.WithError(err).Warn("failed to build filter")
+		h.log(ctx).WithError(err).Warn("failed to build filter")
 		h.handleValidationError(ctx, err)
 		return
 	}
 
-	result, err := h.subService.CalculateTotalCost(*filter)
+	result, err := h.subService.CalculateTotalCost(ctx.Request.Context(), *filter)
 	if err != nil {
-		h.logger.WithError(err).Error("failed to calculate total cost")
+		h.log(ctx).WithError(err).Error("failed to calculate total cost")
 		h.handleServiceError(ctx, err)
 		return
 	}
 
-	h.logger.WithField("total_cost", result).Info("total cost calculated successfully")
-	ctx.JSON(http.StatusOK, dto.IntResponse{Value: result})
+	h.log(ctx).WithField("total_cost", result).Info("total cost calculated successfully")
+	ctx.JSON(http.StatusOK, dto.TotalCostResponse{
+		Value:    result,
+		Currency: usecase.TenantFrom(ctx.Request.Context()).Currency,
+	})
 }
 
-func (h *SubscriptionHandler) parseUUIDParam(ctx *gin.Context, param string) (uuid.UUID, bool) {
-	idStr := ctx.Param(param)
-	id, err := uuid.Parse(idStr)
-	if err != nil {
-		h.logger.WithField("param", idStr).Warn("uuid not valid")
-		h.respondError(ctx, http.StatusBadRequest, fmt.Errorf("uuid not valid: %s", idStr))
-		return uuid.Nil, false
+// Duplicates responds with the likely duplicated subscriptions of a user.
+func (h *SubscriptionHandler) Duplicates(ctx *gin.Context) {
+	h.log(ctx).Info("handling find duplicates request")
+	userID, ok := h.parseUUIDParam(ctx, "id")
+	if !ok {
+		h.log(ctx).Warn("invalid uuid parameter")
+		return
+	}
+
+	renderer, ok := h.negotiate(ctx)
+	if !ok {
+		return
 	}
-	return id, true
-}
 
-func (h *SubscriptionHandler) handleServiceError(ctx *gin.Context, err error) {
-	switch {
-	case errors.Is(err, usecase.ErrNotFound):
-		h.respondError(ctx, http.StatusNotFound, err)
-	default:
-		h.respondError(ctx, http.StatusInternalServerError, err)
+	groups, err := h.subService.FindDuplicates(ctx.Request.Context(), userID)
+	if err != nil {
+		h.log(ctx).WithError(err).WithField("user_id", userID).Error("failed to find duplicates")
+		h.handleServiceError(ctx, err)
+		return
 	}
-}
 
-func (h *SubscriptionHandler) handleValidationError(ctx *gin.Context, err error) {
-	h.logger.WithFields(logrus.Fields{
-		"error":  err,
-		"path":   ctx.FullPath(),
-		"method": ctx.Request.Method,
-	}).Warn("validation error")
+	h.log(ctx).WithField("groups", len(groups)).Info("duplicates found successfully")
+	ctx.JSON(http.StatusOK, renderer.Duplicates(groups))
+}
 
-	var verr validator.ValidationErrors
+// Merge merges the source subscriptions into the target one.
+func (h *SubscriptionHandler) Merge(ctx *gin.Context) {
+	h.log(ctx).Info("handling merge subscriptions request")
+	var request dto.MergeSubscriptionsRequest
 
-	if errors.As(err, &verr) {
-		errorsMap := h.buildMap(verr)
-		h.respondValidationError(ctx, errorsMap)
+	renderer, ok := h.negotiate(ctx)
+	if !ok {
 		return
 	}
 
-	h.respondError(ctx, http.StatusBadRequest, err)
-}
+	if err := ctx.ShouldBindBodyWithJSON(&request); err != nil {
+		h.log(ctx).WithError(err).Warn("invalid request body")
+		h.handleValidationError(ctx, err)
+		return
+	}
 
-func (h *SubscriptionHandler) buildMap(verr validator.ValidationErrors) map[string]string {
-	errorsMap := make(map[string]string)
-	for _, fe := range verr {
-		errorsMap[fe.Field()] = fmt.Sprintf("field '%s' validation failed on '%s' tag", fe.Field(), fe.Tag())
+	command, err := dto.ToMergeSubscriptionsCommand(request)
+	if err != nil {
+		h.log(ctx).WithError(err).Warn("failed to build command")
+		h.handleValidationError(ctx, err)
+		return
 	}
-	return errorsMap
-}
 
-func (h *SubscriptionHandler) respondError(ctx *gin.Context, code int, err error) {
-	ctx.AbortWithStatusJSON(code, dto.ErrorResponse{Error: err.Error()})
-}
+	merged, err := h.subService.MergeSubscriptions(ctx.Request.Context(), *command)
+	if err != nil {
+		h.log(ctx).WithError(err).WithField("subscription_id", command.TargetID).Error("failed to merge subscriptions")
+		h.handleServiceError(ctx, err)
+		return
+	}
 
-func (h *SubscriptionHandler) respondValidationError(ctx *gin.Context, errMap map[string]string) {
-	ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, dto.ValidationErrorResponse{
-		Error:  "validation error",
-		Fields: errMap,
-	})
+	h.log(ctx).WithFields(logrus.Fields{
+		"subscription_id": merged.Subscription.ID,
+		"merged_ids":      merged.MergedIDs,
+	}).Info("subscriptions merged successfully")
+	ctx.JSON(http.StatusOK, renderer.Merge(merged))
 }
 
-func NewSubscriptionHandler(subService usecase.SubscriptionService, logger *logrus.Logger) *SubscriptionHandler {
+func NewSubscriptionHandler(subService usecase.SubscriptionService, view SubscriptionView, logger *logrus.Logger) *SubscriptionHandler {
 	return &SubscriptionHandler{
-		subService: subService,
-		logger:     logger,
+		baseHandler: baseHandler{logger: logger, notFound: problem.CodeSubscriptionNotFound},
+		subService:  subService,
+		view:        view,
 	}
 }
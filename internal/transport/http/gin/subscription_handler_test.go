@@ -1,6 +1,7 @@
 package gin_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -9,15 +10,22 @@ import (
 	"testing"
 	"time"
 
+	"github.com/MDx3R/ef-test/internal/domain"
 	logruslogger "github.com/MDx3R/ef-test/internal/infra/logger"
-	handlers "github.com/MDx3R/ef-test/internal/transport/http/gin"
+	httpdto "github.com/MDx3R/ef-test/internal/transport/http/dto"
+	dtov1 "github.com/MDx3R/ef-test/internal/transport/http/dto/v1"
+	handlersv1 "github.com/MDx3R/ef-test/internal/transport/http/gin/v1"
+	"github.com/MDx3R/ef-test/internal/transport/http/problem"
 	"github.com/MDx3R/ef-test/internal/usecase"
 	"github.com/MDx3R/ef-test/internal/usecase/dto"
 	mock_usecase "github.com/MDx3R/ef-test/internal/usecase/mocks"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 var logger = logruslogger.NewLogger()
@@ -26,15 +34,19 @@ func setupRouterAndHandler(t *testing.T) (*gin.Engine, *mock_usecase.MockSubscri
 	gin.SetMode(gin.TestMode)
 
 	mockService := mock_usecase.NewMockSubscriptionService(t)
-	handler := handlers.NewSubscriptionHandler(mockService, logger)
+	handler := handlersv1.NewSubscriptionHandler(mockService, logger)
 
 	r := gin.New()
 	r.GET("", handler.List)
 	r.GET("/:id", handler.Get)
 	r.POST("", handler.Create)
 	r.PUT("/:id", handler.Update)
+	r.PATCH("/:id", handler.Patch)
 	r.DELETE("/:id", handler.Delete)
 	r.GET("/total", handler.CalculateTotalCost)
+	r.GET("/search", handler.Search)
+	r.POST("/merge", handler.Merge)
+	r.GET("/users/:id/duplicates", handler.Duplicates)
 
 	return r, mockService
 }
@@ -57,7 +69,7 @@ func TestSubscriptionHandler_Get_Success(t *testing.T) {
 	sub := makeTestSubscriptionDTO(t)
 	id := sub.ID
 
-	mockService.On("GetSubscription", id).Return(sub, nil)
+	mockService.On("GetSubscription", mock.Anything, id).Return(sub, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/"+id.String(), nil)
 	w := httptest.NewRecorder()
@@ -90,7 +102,7 @@ func TestSubscriptionHandler_Get_NotFound(t *testing.T) {
 	sub := makeTestSubscriptionDTO(t)
 	id := sub.ID
 
-	mockService.On("GetSubscription", id).Return(dto.SubscriptionDTO{}, usecase.ErrNotFound)
+	mockService.On("GetSubscription", mock.Anything, id).Return(dto.SubscriptionDTO{}, usecase.ErrNotFound)
 
 	req := httptest.NewRequest(http.MethodGet, "/"+id.String(), nil)
 	w := httptest.NewRecorder()
@@ -98,7 +110,7 @@ func TestSubscriptionHandler_Get_NotFound(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusNotFound, w.Code)
-	assert.Contains(t, w.Body.String(), "not found")
+	assert.Contains(t, w.Body.String(), `"code":"subscription.not_found"`)
 	mockService.AssertExpectations(t)
 }
 
@@ -110,9 +122,10 @@ func TestSubscriptionHandler_List_Success(t *testing.T) {
 		makeTestSubscriptionDTO(t),
 	}
 
-	filter := dto.SubscriptionFilter{Page: 1, PageSize: 20}
+	total := 2
+	filter := dto.SubscriptionFilter{Page: 1, PageSize: 20, IncludeTotal: true}
 
-	mockService.On("ListSubscriptions", filter).Return(subs, nil)
+	mockService.On("ListSubscriptions", mock.Anything, filter).Return(dto.SubscriptionPageDTO{Items: subs, Total: &total}, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
 	w := httptest.NewRecorder()
@@ -121,11 +134,258 @@ func TestSubscriptionHandler_List_Success(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	mockService.AssertExpectations(t)
-	assert.Contains(t, w.Body.String(), subs[0].ID.String())
-	assert.Contains(t, w.Body.String(), subs[1].ID.String())
+	assert.Contains(t, w.Body.String(), fmt.Sprintf(`"id":"%s"`, subs[0].ID.String()))
+	assert.Contains(t, w.Body.String(), fmt.Sprintf(`"id":"%s"`, subs[1].ID.String()))
+	assert.Contains(t, w.Body.String(), `"start_date":"08-2025"`)
+	assert.Contains(t, w.Body.String(), `"has_more":false`)
+	assert.Contains(t, w.Body.String(), `"next_cursor":null`)
+	assert.Contains(t, w.Body.String(), `"page":1`)
+	assert.Contains(t, w.Body.String(), `"page_size":20`)
+	assert.Contains(t, w.Body.String(), `"total":2`)
 }
 
-// TODO: Filter DTO tests
+func TestSubscriptionHandler_List_DateFormatISO(t *testing.T) {
+	router, mockService := setupRouterAndHandler(t)
+
+	filter := dto.SubscriptionFilter{Page: 1, PageSize: 20, IncludeTotal: true}
+
+	mockService.On("ListSubscriptions", mock.Anything, filter).Return(dto.SubscriptionPageDTO{
+		Items: []dto.SubscriptionDTO{makeTestSubscriptionDTO(t)},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/?date_format=iso", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+	assert.Contains(t, w.Body.String(), `"start_date":"2025-08"`)
+	assert.Contains(t, w.Body.String(), `"end_date":null`)
+	assert.Contains(t, w.Header().Get("Link"), "date_format=iso")
+}
+
+func TestSubscriptionHandler_List_InvalidDateFormat(t *testing.T) {
+	router, _ := setupRouterAndHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/?date_format=unix", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"request.invalid"`)
+}
+
+func TestSubscriptionHandler_List_Cursor(t *testing.T) {
+	router, mockService := setupRouterAndHandler(t)
+
+	sub := makeTestSubscriptionDTO(t)
+	next := dto.CursorOf(sub)
+	cursor := httpdto.EncodeCursor(next)
+
+	filter := dto.SubscriptionFilter{After: &next, Page: 1, PageSize: 1, IncludeTotal: true}
+
+	mockService.On("ListSubscriptions", mock.Anything, filter).Return(dto.SubscriptionPageDTO{
+		Items:      []dto.SubscriptionDTO{sub},
+		NextCursor: &next,
+		HasMore:    true,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/?page_size=1&cursor="+cursor, nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+	assert.Contains(t, w.Body.String(), `"has_more":true`)
+	assert.Contains(t, w.Body.String(), fmt.Sprintf(`"next_cursor":"%s"`, cursor))
+	assert.Contains(t, w.Body.String(), `"page":null`)
+	assert.Equal(t, fmt.Sprintf(`</?page=1&page_size=1>; rel="first", </?cursor=%s&page_size=1>; rel="next"`, cursor), w.Header().Get("Link"))
+}
+
+func TestSubscriptionHandler_List_Sort(t *testing.T) {
+	router, mockService := setupRouterAndHandler(t)
+
+	filter := dto.SubscriptionFilter{
+		Sort: []dto.SortOrder{
+			{Field: dto.SortByPrice},
+			{Field: dto.SortByStartDate, Desc: true},
+		},
+		Page:     1,
+		PageSize: 20,
+	}
+
+	mockService.On("ListSubscriptions", mock.Anything, filter).Return(dto.SubscriptionPageDTO{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/?sort=price,-start_date&include_total=false", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+	assert.Contains(t, w.Body.String(), `"items":[]`)
+	assert.Contains(t, w.Body.String(), `"total":null`)
+}
+
+func TestSubscriptionHandler_List_InvalidSort(t *testing.T) {
+	router, _ := setupRouterAndHandler(t)
+
+	for _, sort := range []string{"user_id", "price,-price", "-"} {
+		req := httptest.NewRequest(http.MethodGet, "/?sort="+sort, nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, sort)
+	}
+}
+
+func TestSubscriptionHandler_List_SortWithCursor(t *testing.T) {
+	router, _ := setupRouterAndHandler(t)
+
+	cursor := httpdto.EncodeCursor(dto.CursorOf(makeTestSubscriptionDTO(t)))
+
+	req := httptest.NewRequest(http.MethodGet, "/?sort=price&cursor="+cursor, nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "cursor cannot be combined with sort")
+}
+
+func TestSubscriptionHandler_List_LinkHeader(t *testing.T) {
+	router, mockService := setupRouterAndHandler(t)
+
+	total := 25
+	service := "Netflix"
+	filter := dto.SubscriptionFilter{ServiceName: &service, Page: 2, PageSize: 10, IncludeTotal: true}
+
+	mockService.On("ListSubscriptions", mock.Anything, filter).Return(dto.SubscriptionPageDTO{
+		Items:   []dto.SubscriptionDTO{makeTestSubscriptionDTO(t)},
+		HasMore: true,
+		Total:   &total,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/?service_name=Netflix&page=2&page_size=10", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+	assert.Equal(t,
+		`</?page=1&page_size=10&service_name=Netflix>; rel="first", `+
+			`</?page=1&page_size=10&service_name=Netflix>; rel="prev", `+
+			`</?page=3&page_size=10&service_name=Netflix>; rel="next", `+
+			`</?page=3&page_size=10&service_name=Netflix>; rel="last"`,
+		w.Header().Get("Link"),
+	)
+}
+
+func TestSubscriptionHandler_List_InvalidCursor(t *testing.T) {
+	router, _ := setupRouterAndHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/?cursor=not-a-cursor", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid cursor")
+}
+
+func TestSubscriptionHandler_List_InvalidPageSize(t *testing.T) {
+	router, _ := setupRouterAndHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/?page_size=0", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "PageSize")
+}
+
+func TestSubscriptionHandler_List_LargePage(t *testing.T) {
+	router, mockService := setupRouterAndHandler(t)
+
+	filter := dto.SubscriptionFilter{Page: 1, PageSize: 500, IncludeTotal: true}
+	mockService.On("ListSubscriptions", mock.Anything, filter).Return(dto.SubscriptionPageDTO{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/?page_size=500", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestSubscriptionHandler_List_RichFilters(t *testing.T) {
+	router, mockService := setupRouterAndHandler(t)
+
+	prefix := "net"
+	priceMin, priceMax := 100, 1000
+	activeAt := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	activeUntil := time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC)
+	expiringBefore := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	openEnded := false
+
+	filter := dto.SubscriptionFilter{
+		ServiceNames:      []string{"Netflix", "Spotify"},
+		ServiceNamePrefix: &prefix,
+		PriceMin:          &priceMin,
+		PriceMax:          &priceMax,
+		ActiveAt:          &activeAt,
+		ActiveUntil:       &activeUntil,
+		ExpiringBefore:    &expiringBefore,
+		OpenEnded:         &openEnded,
+		Page:              1,
+		PageSize:          20,
+		IncludeTotal:      true,
+	}
+
+	mockService.On("ListSubscriptions", mock.Anything, filter).Return(dto.SubscriptionPageDTO{}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/?service_name_in=Netflix,%20Spotify,&service_name_prefix=net"+
+		"&price_min=100&price_max=1000&active_at=08-2025&expiring_before=12-2025&open_ended=false", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSubscriptionHandler_List_InvalidFilters(t *testing.T) {
+	router, _ := setupRouterAndHandler(t)
+
+	tests := []struct {
+		name  string
+		query string
+		code  int
+	}{
+		{"price range inverted", "price_min=1000&price_max=100", http.StatusBadRequest},
+		{"negative price", "price_min=-1", http.StatusUnprocessableEntity},
+		{"invalid active_at", "active_at=2025/08", http.StatusBadRequest},
+		{"invalid expiring_before", "expiring_before=13-2025", http.StatusBadRequest},
+		{"invalid open_ended", "open_ended=maybe", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
 
 func TestSubscriptionHandler_Create_Success(t *testing.T) {
 	router, mockService := setupRouterAndHandler(t)
@@ -147,7 +407,7 @@ func TestSubscriptionHandler_Create_Success(t *testing.T) {
 	)
 
 	newID := uuid.New()
-	mockService.On("CreateSubscription", request).Return(newID, nil)
+	mockService.On("CreateSubscription", mock.Anything, request).Return(newID, nil)
 
 	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
@@ -160,6 +420,37 @@ func TestSubscriptionHandler_Create_Success(t *testing.T) {
 	assert.Contains(t, w.Body.String(), newID.String())
 }
 
+func TestSubscriptionHandler_Create_ISODates(t *testing.T) {
+	router, mockService := setupRouterAndHandler(t)
+
+	endDate := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	request := dto.CreateSubscriptionCommand{
+		ServiceName: "test_service",
+		Price:       100,
+		UserID:      uuid.New(),
+		StartDate:   time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:     &endDate,
+	}
+
+	jsonBody := fmt.Sprintf(
+		`{"service_name":"%v", "price":%v, "user_id":"%v", "start_date":"2025-08", "end_date":"2025-12-31T00:00:00Z"}`,
+		request.ServiceName,
+		request.Price,
+		request.UserID,
+	)
+
+	mockService.On("CreateSubscription", mock.Anything, request).Return(uuid.New(), nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockService.AssertExpectations(t)
+}
+
 func TestSubscriptionHandler_Create_InvalidJSON(t *testing.T) {
 	router, _ := setupRouterAndHandler(t)
 
@@ -172,7 +463,8 @@ func TestSubscriptionHandler_Create_InvalidJSON(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
-	assert.Contains(t, w.Body.String(), "error")
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"code":"request.invalid`)
 }
 
 func TestSubscriptionHandler_Create_InvalidJSON_Validation(t *testing.T) {
@@ -210,15 +502,15 @@ func TestSubscriptionHandler_Create_InvalidJSON_Validation(t *testing.T) {
 		},
 		{
 			name:       "invalid month-year format",
-			jsonBody:   `{"service_name":"Test","price":100,"user_id":"` + uuid.New().String() + `","start_date":"2025-08-01"}`,
+			jsonBody:   `{"service_name":"Test","price":100,"user_id":"` + uuid.New().String() + `","start_date":"2025/08"}`,
 			expectCode: http.StatusBadRequest,
-			expectErr:  "error",
+			expectErr:  `"code":"request.invalid"`,
 		},
 		{
 			name:       "invalid json syntax",
 			jsonBody:   `{invalid_json}`,
 			expectCode: http.StatusBadRequest,
-			expectErr:  "error",
+			expectErr:  `"code":"request.invalid"`,
 		},
 	}
 
@@ -236,6 +528,37 @@ func TestSubscriptionHandler_Create_InvalidJSON_Validation(t *testing.T) {
 	}
 }
 
+func TestSubscriptionHandler_Create_ValidationLocalized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	translator, err := problem.NewTranslator(binding.Validator.Engine().(*validator.Validate))
+	require.NoError(t, err)
+
+	handler := handlersv1.NewSubscriptionHandler(mock_usecase.NewMockSubscriptionService(t), logger)
+	router := gin.New()
+	router.Use(translator.Localize)
+	router.POST("/", handler.Create)
+
+	body := `{"service_name":"Netflix","user_id":"` + uuid.New().String() + `","start_date":"08-2025"}`
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "ru-RU,ru;q=0.9,en;q=0.8")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, "ru", w.Header().Get("Content-Language"))
+	assert.JSONEq(t, `{
+		"type": "urn:problem:validation.failed",
+		"title": "Ошибка валидации",
+		"status": 422,
+		"instance": "/",
+		"code": "validation.failed",
+		"errors": {"Price": "Price обязательное поле"}
+	}`, w.Body.String())
+}
+
 func TestSubscriptionHandler_Update_Success(t *testing.T) {
 	router, mockService := setupRouterAndHandler(t)
 
@@ -254,7 +577,7 @@ func TestSubscriptionHandler_Update_Success(t *testing.T) {
 		request.Price,
 	)
 
-	mockService.On("UpdateSubscription", id, request).Return(nil)
+	mockService.On("UpdateSubscription", mock.Anything, id, request).Return(nil)
 
 	req := httptest.NewRequest(http.MethodPut, "/"+id.String(), strings.NewReader(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
@@ -266,6 +589,41 @@ func TestSubscriptionHandler_Update_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestSubscriptionHandler_Update_Notes(t *testing.T) {
+	empty := ""
+	tests := []struct {
+		name  string
+		body  string
+		notes *string
+	}{
+		{"omitted", `{"service_name":"test_service","price":100,"start_date":"08-2025"}`, nil},
+		{"cleared", `{"service_name":"test_service","price":100,"start_date":"08-2025","notes":""}`, &empty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockService := setupRouterAndHandler(t)
+
+			id := uuid.New()
+			request := dto.UpdateSubscriptionCommand{
+				ServiceName: "test_service",
+				Price:       100,
+				StartDate:   time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+				Notes:       tt.notes,
+			}
+			mockService.On("UpdateSubscription", mock.Anything, id, request).Return(nil)
+
+			req := httptest.NewRequest(http.MethodPut, "/"+id.String(), strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNoContent, w.Code)
+		})
+	}
+}
+
 func TestSubscriptionHandler_Update_InvalidUUID(t *testing.T) {
 	router, _ := setupRouterAndHandler(t)
 
@@ -276,7 +634,8 @@ func TestSubscriptionHandler_Update_InvalidUUID(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
-	assert.Contains(t, w.Body.String(), "error")
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"code":"request.invalid`)
 }
 
 func TestSubscriptionHandler_Update_InvalidJSON(t *testing.T) {
@@ -292,7 +651,8 @@ func TestSubscriptionHandler_Update_InvalidJSON(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
-	assert.Contains(t, w.Body.String(), "error")
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"code":"request.invalid`)
 }
 
 func TestSubscriptionHandler_Update_InvalidJSON_Validation(t *testing.T) {
@@ -324,15 +684,15 @@ func TestSubscriptionHandler_Update_InvalidJSON_Validation(t *testing.T) {
 		},
 		{
 			name:       "invalid month-year format",
-			jsonBody:   `{"service_name":"Test","price":100,"start_date":"2025-08-01"}`,
+			jsonBody:   `{"service_name":"Test","price":100,"start_date":"2025/08"}`,
 			expectCode: http.StatusBadRequest,
-			expectErr:  "error",
+			expectErr:  `"code":"request.invalid"`,
 		},
 		{
 			name:       "invalid json syntax",
 			jsonBody:   `{invalid_json}`,
 			expectCode: http.StatusBadRequest,
-			expectErr:  "error",
+			expectErr:  `"code":"request.invalid"`,
 		},
 	}
 
@@ -355,7 +715,7 @@ func TestSubscriptionHandler_Delete_Success(t *testing.T) {
 	router, mockService := setupRouterAndHandler(t)
 
 	id := uuid.New()
-	mockService.On("DeleteSubscription", id).Return(nil)
+	mockService.On("DeleteSubscription", mock.Anything, id).Return(nil)
 
 	req := httptest.NewRequest(http.MethodDelete, "/"+id.String(), nil)
 	w := httptest.NewRecorder()
@@ -376,14 +736,15 @@ func TestSubscriptionHandler_Delete_InvalidUUID(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
-	assert.Contains(t, w.Body.String(), "error")
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"code":"request.invalid`)
 }
 
 func TestSubscriptionHandler_Get_ServiceError(t *testing.T) {
 	router, mockService := setupRouterAndHandler(t)
 
 	subID := uuid.New()
-	mockService.On("GetSubscription", subID).Return(dto.SubscriptionDTO{}, errors.New("service failure"))
+	mockService.On("GetSubscription", mock.Anything, subID).Return(dto.SubscriptionDTO{}, errors.New("service failure"))
 
 	req := httptest.NewRequest(http.MethodGet, "/"+subID.String(), nil)
 	w := httptest.NewRecorder()
@@ -391,13 +752,15 @@ func TestSubscriptionHandler_Get_ServiceError(t *testing.T) {
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"internal"`)
+	assert.NotContains(t, w.Body.String(), "service failure")
 	mockService.AssertExpectations(t)
 }
 
 func TestSubscriptionHandler_List_ServiceError(t *testing.T) {
 	router, mockService := setupRouterAndHandler(t)
 
-	mockService.On("ListSubscriptions", mock.Anything).Return(nil, errors.New("service failure"))
+	mockService.On("ListSubscriptions", mock.Anything, mock.Anything).Return(dto.SubscriptionPageDTO{}, errors.New("service failure"))
 
 	req := httptest.NewRequest(http.MethodGet, "/", nil)
 	w := httptest.NewRecorder()
@@ -418,7 +781,7 @@ func TestSubscriptionHandler_Create_ServiceError(t *testing.T) {
 		uuid.New().String(),
 	)
 
-	mockService.On("CreateSubscription", mock.Anything).Return(uuid.Nil, errors.New("service failure"))
+	mockService.On("CreateSubscription", mock.Anything, mock.Anything).Return(uuid.Nil, errors.New("service failure"))
 
 	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
@@ -430,12 +793,33 @@ func TestSubscriptionHandler_Create_ServiceError(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestSubscriptionHandler_Create_InvalidPeriod(t *testing.T) {
+	router, mockService := setupRouterAndHandler(t)
+
+	jsonBody := fmt.Sprintf(
+		`{"service_name":"Netflix","price":100,"user_id":"%s","start_date":"08-2025","end_date":"07-2025"}`,
+		uuid.New(),
+	)
+
+	mockService.On("CreateSubscription", mock.Anything, mock.Anything).Return(uuid.Nil, domain.ErrInvalidPeriod)
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"validation.invalid_period"`)
+	mockService.AssertExpectations(t)
+}
+
 func TestSubscriptionHandler_Update_ServiceError(t *testing.T) {
 	router, mockService := setupRouterAndHandler(t)
 
 	subID := uuid.New()
 	jsonBody := `{"service_name":"Updated","price":200,"start_date":"09-2025"}`
-	mockService.On("UpdateSubscription", subID, mock.Anything).Return(errors.New("service failure"))
+	mockService.On("UpdateSubscription", mock.Anything, subID, mock.Anything).Return(errors.New("service failure"))
 
 	req := httptest.NewRequest(http.MethodPut, "/"+subID.String(), strings.NewReader(jsonBody))
 	req.Header.Set("Content-Type", "application/json")
@@ -447,11 +831,162 @@ func TestSubscriptionHandler_Update_ServiceError(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func newPatchRequest(id uuid.UUID, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPatch, "/"+id.String(), strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	return req
+}
+
+func TestSubscriptionHandler_Patch_FreePrice(t *testing.T) {
+	router, mockService := setupRouterAndHandler(t)
+
+	sub := makeTestSubscriptionDTO(t)
+	sub.Price = 0
+	price := 0
+	command := dto.PatchSubscriptionCommand{Price: &price}
+
+	mockService.On("PatchSubscription", mock.Anything, sub.ID, command).Return(sub, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newPatchRequest(sub.ID, `{"price":0}`))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"price":0`)
+	mockService.AssertExpectations(t)
+}
+
+func TestSubscriptionHandler_Patch_ClearEndDate(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "json null", body: `{"end_date":null}`},
+		{name: "legacy null string", body: `{"end_date":"null"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockService := setupRouterAndHandler(t)
+
+			sub := makeTestSubscriptionDTO(t)
+			command := dto.PatchSubscriptionCommand{ClearEndDate: true}
+
+			mockService.On("PatchSubscription", mock.Anything, sub.ID, command).Return(sub, nil)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newPatchRequest(sub.ID, tt.body))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSubscriptionHandler_Patch_Fields(t *testing.T) {
+	router, mockService := setupRouterAndHandler(t)
+
+	sub := makeTestSubscriptionDTO(t)
+	name := "Netflix"
+	endDate := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	notes := ""
+	command := dto.PatchSubscriptionCommand{ServiceName: &name, EndDate: &endDate, Notes: &notes}
+
+	mockService.On("PatchSubscription", mock.Anything, sub.ID, command).Return(sub, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newPatchRequest(sub.ID, `{"service_name":"Netflix","end_date":"2025-12","notes":null}`))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSubscriptionHandler_Patch_UnsupportedMediaType(t *testing.T) {
+	router, _ := setupRouterAndHandler(t)
+
+	id := uuid.New()
+	req := httptest.NewRequest(http.MethodPatch, "/"+id.String(), strings.NewReader(`{"price":0}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	assert.Equal(t, "application/merge-patch+json", w.Header().Get("Accept-Patch"))
+	assert.Contains(t, w.Body.String(), `"code":"request.unsupported_media_type"`)
+}
+
+func TestSubscriptionHandler_Patch_InvalidBody(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		expectCode int
+		expectErr  string
+	}{
+		{
+			name:       "null price",
+			body:       `{"price":null}`,
+			expectCode: http.StatusBadRequest,
+			expectErr:  "price cannot be removed",
+		},
+		{
+			name:       "null service_name",
+			body:       `{"service_name":null}`,
+			expectCode: http.StatusBadRequest,
+			expectErr:  "service_name cannot be removed",
+		},
+		{
+			name:       "null start_date",
+			body:       `{"start_date":"null"}`,
+			expectCode: http.StatusBadRequest,
+			expectErr:  "start_date cannot be removed",
+		},
+		{
+			name:       "empty service_name",
+			body:       `{"service_name":""}`,
+			expectCode: http.StatusUnprocessableEntity,
+			expectErr:  "ServiceName",
+		},
+		{
+			name:       "invalid month",
+			body:       `{"end_date":"2025/08"}`,
+			expectCode: http.StatusBadRequest,
+			expectErr:  `"code":"request.invalid"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, _ := setupRouterAndHandler(t)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newPatchRequest(uuid.New(), tt.body))
+
+			assert.Equal(t, tt.expectCode, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectErr)
+		})
+	}
+}
+
+func TestSubscriptionHandler_Patch_InvalidPeriod(t *testing.T) {
+	router, mockService := setupRouterAndHandler(t)
+
+	id := uuid.New()
+	mockService.On("PatchSubscription", mock.Anything, id, mock.Anything).
+		Return(dto.SubscriptionDTO{}, domain.ErrInvalidPeriod)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newPatchRequest(id, `{"end_date":"07-2025"}`))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"validation.invalid_period"`)
+	mockService.AssertExpectations(t)
+}
+
 func TestSubscriptionHandler_Delete_ServiceError(t *testing.T) {
 	router, mockService := setupRouterAndHandler(t)
 
 	subID := uuid.New()
-	mockService.On("DeleteSubscription", subID).Return(errors.New("service failure"))
+	mockService.On("DeleteSubscription", mock.Anything, subID).Return(errors.New("service failure"))
 
 	req := httptest.NewRequest(http.MethodDelete, "/"+subID.String(), nil)
 	w := httptest.NewRecorder()
@@ -483,6 +1018,7 @@ func TestSubscriptionHandler_TotalCost_Success(t *testing.T) {
 
 	mockService.On(
 		"CalculateTotalCost",
+		mock.Anything,
 		request,
 	).Return(150, nil)
 
@@ -495,6 +1031,31 @@ func TestSubscriptionHandler_TotalCost_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestSubscriptionHandler_TotalCost_TenantCurrency(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := mock_usecase.NewMockSubscriptionService(t)
+	handler := handlersv1.NewSubscriptionHandler(mockService, logger)
+
+	router := gin.New()
+	router.GET("/total", func(c *gin.Context) {
+		tenant := usecase.Tenant{ID: "retail", Currency: "EUR"}
+		c.Request = c.Request.WithContext(usecase.WithTenant(c.Request.Context(), tenant))
+	}, handler.CalculateTotalCost)
+
+	mockService.On("CalculateTotalCost", mock.Anything, mock.Anything).Return(150, nil)
+
+	query := fmt.Sprintf(`/total?user_id=%s&service_name=test_service&period_start="08-2025"&period_end="10-2025"`, uuid.New())
+	req := httptest.NewRequest(http.MethodGet, query, nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"value":150,"currency":"EUR"}`, w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
 func TestSubscriptionHandler_TotalCost_ServiceError(t *testing.T) {
 	router, mockService := setupRouterAndHandler(t)
 
@@ -516,6 +1077,7 @@ func TestSubscriptionHandler_TotalCost_ServiceError(t *testing.T) {
 
 	mockService.On(
 		"CalculateTotalCost",
+		mock.Anything,
 		request,
 	).Return(0, errors.New("service failure"))
 
@@ -526,3 +1088,247 @@ func TestSubscriptionHandler_TotalCost_ServiceError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 	mockService.AssertExpectations(t)
 }
+
+func TestSubscriptionHandler_Search_Success(t *testing.T) {
+	router, mockService := setupRouterAndHandler(t)
+
+	sub := makeTestSubscriptionDTO(t)
+	sub.Notes = "family plan"
+	userID := sub.UserID
+
+	filter := dto.SubscriptionSearchFilter{Query: "family", UserID: &userID, Limit: 20}
+
+	mockService.On("SearchSubscriptions", mock.Anything, filter).Return([]dto.SubscriptionDTO{sub}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/search?q=%20family%20&user_id="+userID.String(), nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+	assert.Contains(t, w.Body.String(), fmt.Sprintf(`"id":"%s"`, sub.ID.String()))
+	assert.Contains(t, w.Body.String(), `"notes":"family plan"`)
+}
+
+func TestSubscriptionHandler_Search_InvalidQuery(t *testing.T) {
+	router, _ := setupRouterAndHandler(t)
+
+	tests := []struct {
+		name  string
+		query string
+		code  int
+	}{
+		{"missing q", "", http.StatusUnprocessableEntity},
+		{"blank q", "q=%20%20", http.StatusBadRequest},
+		{"limit too large", "q=netflix&limit=101", http.StatusUnprocessableEntity},
+		{"invalid user_id", "q=netflix&user_id=123", http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/search?"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestSubscriptionHandler_Search_ServiceError(t *testing.T) {
+	router, mockService := setupRouterAndHandler(t)
+
+	filter := dto.SubscriptionSearchFilter{Query: "netflix", Limit: 20}
+
+	mockService.On("SearchSubscriptions", mock.Anything, filter).Return(nil, usecase.ErrRepository)
+
+	req := httptest.NewRequest(http.MethodGet, "/search?q=netflix", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSubscriptionHandler_Get_Forbidden(t *testing.T) {
+	router, mockService := setupRouterAndHandler(t)
+
+	id := uuid.New()
+
+	mockService.On("GetSubscription", mock.Anything, id).Return(dto.SubscriptionDTO{}, usecase.ErrForbidden)
+
+	req := httptest.NewRequest(http.MethodGet, "/"+id.String(), nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "forbidden")
+	mockService.AssertExpectations(t)
+}
+
+func TestSubscriptionHandler_Update_AccessDenied(t *testing.T) {
+	router, mockService := setupRouterAndHandler(t)
+
+	id := uuid.New()
+	denied := &usecase.AccessDeniedError{
+		Action:   usecase.ActionSubscriptionsUpdate,
+		Resource: "subscriptions/" + id.String(),
+		Roles:    []string{usecase.RoleFinance},
+		Reason:   "no role grants the action",
+	}
+
+	mockService.On("UpdateSubscription", mock.Anything, id, mock.Anything).Return(denied)
+
+	body := `{"service_name":"Netflix","price":100,"start_date":"08-2025"}`
+	req := httptest.NewRequest(http.MethodPut, "/"+id.String(), strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"type": "urn:problem:auth.access_denied",
+		"title": "Access denied",
+		"status": 403,
+		"instance": "/%s",
+		"code": "auth.access_denied",
+		"action": "subscriptions:update",
+		"resource": "subscriptions/%s",
+		"roles": ["finance"],
+		"reason": "no role grants the action"
+	}`, id, id), w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestSubscriptionHandler_Create_Duplicate(t *testing.T) {
+	router, mockService := setupRouterAndHandler(t)
+
+	duplicateID := uuid.New()
+	mockService.On("CreateSubscription", mock.Anything, mock.Anything).
+		Return(uuid.Nil, &usecase.DuplicateSubscriptionError{IDs: []uuid.UUID{duplicateID}})
+
+	body := fmt.Sprintf(`{"service_name":"Netflix","price":100,"user_id":"%s","start_date":"08-2025"}`, uuid.New())
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"type": "urn:problem:subscription.duplicate",
+		"title": "Duplicate subscription",
+		"status": 409,
+		"detail": "subscription duplicates 1 existing one(s)",
+		"instance": "/",
+		"code": "subscription.duplicate",
+		"duplicate_ids": ["%s"]
+	}`, duplicateID), w.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestSubscriptionHandler_Duplicates_Success(t *testing.T) {
+	router, mockService := setupRouterAndHandler(t)
+
+	sub := makeTestSubscriptionDTO(t)
+	other := makeTestSubscriptionDTO(t)
+	other.UserID = sub.UserID
+
+	mockService.On("FindDuplicates", mock.Anything, sub.UserID).Return([]dto.DuplicateGroupDTO{
+		{ServiceName: "test_service", Subscriptions: []dto.SubscriptionDTO{sub, other}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/"+sub.UserID.String()+"/duplicates", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp dtov1.DuplicateGroupsResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	if assert.Len(t, resp.Items, 1) {
+		assert.Equal(t, "test_service", resp.Items[0].ServiceName)
+		assert.Len(t, resp.Items[0].Subscriptions, 2)
+	}
+	mockService.AssertExpectations(t)
+}
+
+func TestSubscriptionHandler_Duplicates_InvalidUUID(t *testing.T) {
+	router, _ := setupRouterAndHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/users/not-uuid/duplicates", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSubscriptionHandler_Merge_Success(t *testing.T) {
+	router, mockService := setupRouterAndHandler(t)
+
+	sub := makeTestSubscriptionDTO(t)
+	sourceID := uuid.New()
+	mergedAt := time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	mockService.On("MergeSubscriptions", mock.Anything, dto.MergeSubscriptionsCommand{
+		TargetID:  sub.ID,
+		SourceIDs: []uuid.UUID{sourceID},
+	}).Return(dto.SubscriptionMergeDTO{
+		Subscription: sub,
+		MergedIDs:    []uuid.UUID{sourceID},
+		MergedBy:     "alice",
+		MergedAt:     mergedAt,
+	}, nil)
+
+	body := fmt.Sprintf(`{"target_id":"%s","source_ids":["%s"]}`, sub.ID, sourceID)
+	req := httptest.NewRequest(http.MethodPost, "/merge", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp dtov1.MergeSubscriptionsResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, sub.ID.String(), resp.Subscription.ID)
+	assert.Equal(t, []string{sourceID.String()}, resp.MergedIDs)
+	assert.Equal(t, "alice", resp.MergedBy)
+	assert.Equal(t, mergedAt, resp.MergedAt)
+	mockService.AssertExpectations(t)
+}
+
+func TestSubscriptionHandler_Merge_NoSources(t *testing.T) {
+	router, _ := setupRouterAndHandler(t)
+
+	body := fmt.Sprintf(`{"target_id":"%s","source_ids":[]}`, uuid.New())
+	req := httptest.NewRequest(http.MethodPost, "/merge", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestSubscriptionHandler_Merge_InvalidMerge(t *testing.T) {
+	router, mockService := setupRouterAndHandler(t)
+
+	mockService.On("MergeSubscriptions", mock.Anything, mock.Anything).
+		Return(dto.SubscriptionMergeDTO{}, domain.ErrInvalidMerge)
+
+	body := fmt.Sprintf(`{"target_id":"%s","source_ids":["%s"]}`, uuid.New(), uuid.New())
+	req := httptest.NewRequest(http.MethodPost, "/merge", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertExpectations(t)
+}
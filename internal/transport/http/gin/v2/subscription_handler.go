@@ -0,0 +1,341 @@
+package v2
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/transport/http/dto"
+	"github.com/MDx3R/ef-test/internal/transport/http/dto/v2"
+	handlers "github.com/MDx3R/ef-test/internal/transport/http/gin"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	usecasedto "github.com/MDx3R/ef-test/internal/usecase/dto"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// SubscriptionHandler serves the subscription routes of the second API version.
+type SubscriptionHandler struct {
+	handler *handlers.SubscriptionHandler
+}
+
+func NewSubscriptionHandler(subService usecase.SubscriptionService, logger *logrus.Logger) *SubscriptionHandler {
+	return &SubscriptionHandler{handler: handlers.NewSubscriptionHandler(subService, view{}, logger)}
+}
+
+// view renders months as YYYY-MM. Clients moving from the first version may
+// still send date_format=iso, other formats are rejected rather than ignored.
+type view struct{}
+
+func (view) Negotiate(ctx *gin.Context) (handlers.SubscriptionRenderer, error) {
+	format, err := dto.ParseDateFormat(ctx.DefaultQuery("date_format", string(dto.DateFormatISO)))
+	if err != nil {
+		return nil, err
+	}
+	if format != dto.DateFormatISO {
+		return nil, fmt.Errorf("unsupported date format: %s, only iso is available", format)
+	}
+	return renderer{}, nil
+}
+
+func (view) BoundsPageSize() bool {
+	return true
+}
+
+type renderer struct{}
+
+func (renderer) Subscription(sub usecasedto.SubscriptionDTO) any {
+	return v2.FromSubscriptionDTO(sub)
+}
+
+func (renderer) Page(page usecasedto.SubscriptionPageDTO, filter usecasedto.SubscriptionFilter) any {
+	return v2.FromSubscriptionPageDTO(page, filter)
+}
+
+func (renderer) Search(subs []usecasedto.SubscriptionDTO) any {
+	return v2.SubscriptionSearchResponse{Items: v2.FromSubscriptionDTOs(subs)}
+}
+
+func (renderer) Duplicates(groups []usecasedto.DuplicateGroupDTO) any {
+	return v2.FromDuplicateGroupDTOs(groups)
+}
+
+func (renderer) Merge(merged usecasedto.SubscriptionMergeDTO) any {
+	return v2.FromSubscriptionMergeDTO(merged)
+}
+
+func (renderer) Event(event usecasedto.SubscriptionEventDTO) any {
+	return v2.FromSubscriptionEventDTO(event)
+}
+
+// List godoc
+// @Summary Список подписок
+// @Description Возвращает страницу подписок с фильтрацией по параметрам.
+// @Description Все заданные фильтры объединяются через AND:
+// @Description service_name_in — список точных названий через запятую, service_name_prefix — начало названия без учёта регистра,
+// @Description price_min и price_max — границы цены включительно, active_at — подписки, действующие в указанном месяце (или в день, если он указан),
+// @Description expiring_before — подписки, заканчивающиеся раньше указанного месяца,
+// @Description open_ended=true — бессрочные подписки (false — только подписки с датой окончания).
+// @Description По умолчанию подписки упорядочены по (start_date, id); порядок меняется параметром sort,
+// @Description например sort=price,-start_date (допустимые поля: service_name, price, start_date, end_date).
+// @Description Для постраничного обхода передайте next_cursor предыдущей страницы в параметре cursor;
+// @Description page и page_size остаются доступны как запасной вариант. Курсор не совместим с sort.
+// @Description page_size — от 1 до 100, по умолчанию 20.
+// @Description Общее количество (total) не считается при include_total=false.
+// @Description Ссылки на первую, предыдущую, следующую и последнюю страницы передаются в заголовке Link.
+// @Tags subscriptions
+// @Produce json
+// @Param filter query dto.SubscriptionQueryRequest false "Фильтры подписок"
+// @Success 200 {object} v2.SubscriptionListResponse
+// @Header 200 {string} Link "Ссылки на страницы (RFC 8288): first, prev, next, last"
+// @Failure 400 {object} dto.ProblemResponse "Ошибка валидации параметров запроса"
+// @Failure 401 {object} dto.ProblemResponse "Требуется аутентификация"
+// @Failure 403 {object} dto.AccessDeniedProblemResponse "Доступ к подписке запрещён политикой"
+// @Failure 429 {object} dto.ProblemResponse "Превышен лимит запросов"
+// @Failure 500 {object} dto.ProblemResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v2/subscriptions [get]
+func (h *SubscriptionHandler) List(ctx *gin.Context) {
+	h.handler.List(ctx)
+}
+
+// Get godoc
+// @Summary Получить подписку по ID
+// @Description Возвращает подписку по заданному UUID
+// @Tags subscriptions
+// @Param id path string true "Subscription ID" Format(uuid)
+// @Produce json
+// @Success 200 {object} v2.SubscriptionResponse "Подписка найдена"
+// @Failure 400 {object} dto.ProblemResponse "Неверный UUID"
+// @Failure 404 {object} dto.ProblemResponse "Подписка не найдена"
+// @Failure 401 {object} dto.ProblemResponse "Требуется аутентификация"
+// @Failure 403 {object} dto.AccessDeniedProblemResponse "Доступ к подписке запрещён политикой"
+// @Failure 429 {object} dto.ProblemResponse "Превышен лимит запросов"
+// @Failure 500 {object} dto.ProblemResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v2/subscriptions/{id} [get]
+func (h *SubscriptionHandler) Get(ctx *gin.Context) {
+	h.handler.Get(ctx)
+}
+
+// Create godoc
+// @Summary Создать подписку
+// @Description Создает новую подписку с данными из JSON. Повтор запроса с тем же заголовком Idempotency-Key
+// @Description возвращает ответ первого запроса (с заголовком Idempotent-Replayed) и не создаёт подписку повторно.
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Ключ идемпотентности, до 255 символов"
+// @Param subscription body dto.CreateSubscriptionRequest true "Данные новой подписки"
+// @Success 201 {object} dto.IDResponse "ID созданной подписки"
+// @Failure 400 {object} dto.ProblemResponse "Неверный запрос"
+// @Failure 409 {object} dto.DuplicateProblemResponse "Подписка дублирует существующие (если включена проверка) или запрос с этим ключом идемпотентности ещё выполняется"
+// @Failure 422 {object} dto.ValidationProblemResponse "Ошибка валидации или ключ идемпотентности использован с другим запросом"
+// @Failure 401 {object} dto.ProblemResponse "Требуется аутентификация"
+// @Failure 403 {object} dto.AccessDeniedProblemResponse "Доступ к подписке запрещён политикой"
+// @Failure 429 {object} dto.ProblemResponse "Превышен лимит запросов"
+// @Failure 500 {object} dto.ProblemResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v2/subscriptions [post]
+func (h *SubscriptionHandler) Create(ctx *gin.Context) {
+	h.handler.Create(ctx)
+}
+
+// Update godoc
+// @Summary Обновить подписку
+// @Description Обновляет подписку по UUID с данными из JSON. Если notes нет в теле, заметки не меняются.
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Param id path string true "Subscription ID" Format(uuid)
+// @Param subscription body dto.UpdateSubscriptionRequest true "Данные обновления подписки"
+// @Success 204 "Подписка обновлена"
+// @Failure 400 {object} dto.ProblemResponse "Неверный UUID или данные запроса"
+// @Failure 422 {object} dto.ValidationProblemResponse "Ошибка валидации"
+// @Failure 404 {object} dto.ProblemResponse "Подписка не найдена"
+// @Failure 401 {object} dto.ProblemResponse "Требуется аутентификация"
+// @Failure 403 {object} dto.AccessDeniedProblemResponse "Доступ к подписке запрещён политикой"
+// @Failure 429 {object} dto.ProblemResponse "Превышен лимит запросов"
+// @Failure 500 {object} dto.ProblemResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v2/subscriptions/{id} [put]
+func (h *SubscriptionHandler) Update(ctx *gin.Context) {
+	h.handler.Update(ctx)
+}
+
+// Patch godoc
+// @Summary Частично обновить подписку
+// @Description Применяет JSON Merge Patch (RFC 7396): отсутствующие поля не меняются,
+// @Description null в end_date делает подписку бессрочной, null в notes удаляет заметки.
+// @Description Цена 0 допустима. Возвращает обновлённую подписку.
+// @Tags subscriptions
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path string true "Subscription ID" Format(uuid)
+// @Param Idempotency-Key header string false "Ключ идемпотентности, до 255 символов"
+// @Param patch body dto.PatchSubscriptionRequest true "Изменяемые поля подписки"
+// @Success 200 {object} v2.SubscriptionResponse "Подписка обновлена"
+// @Failure 400 {object} dto.ProblemResponse "Неверный UUID, данные запроса или период"
+// @Failure 404 {object} dto.ProblemResponse "Подписка не найдена"
+// @Failure 409 {object} dto.ProblemResponse "Запрос с этим ключом идемпотентности ещё выполняется"
+// @Failure 415 {object} dto.ProblemResponse "Тело не в формате application/merge-patch+json"
+// @Failure 422 {object} dto.ValidationProblemResponse "Ошибка валидации или ключ идемпотентности использован с другим запросом"
+// @Failure 401 {object} dto.ProblemResponse "Требуется аутентификация"
+// @Failure 403 {object} dto.AccessDeniedProblemResponse "Доступ к подписке запрещён политикой"
+// @Failure 429 {object} dto.ProblemResponse "Превышен лимит запросов"
+// @Failure 500 {object} dto.ProblemResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v2/subscriptions/{id} [patch]
+func (h *SubscriptionHandler) Patch(ctx *gin.Context) {
+	h.handler.Patch(ctx)
+}
+
+// Delete godoc
+// @Summary Удалить подписку по ID
+// @Description Удаляет подписку по UUID
+// @Tags subscriptions
+// @Param id path string true "Subscription ID" Format(uuid)
+// @Success 204 "Подписка удалена"
+// @Failure 400 {object} dto.ProblemResponse "Неверный UUID"
+// @Failure 404 {object} dto.ProblemResponse "Подписка не найдена"
+// @Failure 401 {object} dto.ProblemResponse "Требуется аутентификация"
+// @Failure 403 {object} dto.AccessDeniedProblemResponse "Доступ к подписке запрещён политикой"
+// @Failure 429 {object} dto.ProblemResponse "Превышен лимит запросов"
+// @Failure 500 {object} dto.ProblemResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v2/subscriptions/{id} [delete]
+func (h *SubscriptionHandler) Delete(ctx *gin.Context) {
+	h.handler.Delete(ctx)
+}
+
+// CalculateTotalCost godoc
+// @Summary Рассчитать общую стоимость подписок
+// @Description Возвращает общую стоимость подписок по фильтру
+// @Tags subscriptions
+// @Produce json
+// @Param filter query dto.TotalCostQueryRequest true "Фильтр для расчета стоимости"
+// @Success 200 {object} dto.TotalCostResponse "Результат расчета стоимости в валюте организации"
+// @Failure 400 {object} dto.ProblemResponse "Ошибка валидации параметров запроса"
+// @Failure 401 {object} dto.ProblemResponse "Требуется аутентификация"
+// @Failure 403 {object} dto.AccessDeniedProblemResponse "Доступ к подписке запрещён политикой"
+// @Failure 429 {object} dto.ProblemResponse "Превышен лимит запросов"
+// @Failure 500 {object} dto.ProblemResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v2/subscriptions/total [get]
+func (h *SubscriptionHandler) CalculateTotalCost(ctx *gin.Context) {
+	h.handler.CalculateTotalCost(ctx)
+}
+
+// Search godoc
+// @Summary Поиск подписок
+// @Description Ищет подписки по названию сервиса и заметкам. Поиск нечёткий: находит слова в любом регистре
+// @Description и названия с опечатками. Результаты упорядочены по релевантности; совпадения в названии
+// @Description важнее совпадений в заметках. Нечёткий поиск доступен только на PostgreSQL и в памяти,
+// @Description остальные драйверы ищут подстроку без учёта регистра.
+// @Tags subscriptions
+// @Produce json
+// @Param query query dto.SubscriptionSearchRequest true "Параметры поиска"
+// @Success 200 {object} v2.SubscriptionSearchResponse
+// @Failure 400 {object} dto.ProblemResponse "Ошибка валидации параметров запроса"
+// @Failure 422 {object} dto.ValidationProblemResponse "Ошибка валидации"
+// @Failure 401 {object} dto.ProblemResponse "Требуется аутентификация"
+// @Failure 403 {object} dto.AccessDeniedProblemResponse "Доступ к подписке запрещён политикой"
+// @Failure 429 {object} dto.ProblemResponse "Превышен лимит запросов"
+// @Failure 500 {object} dto.ProblemResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v2/subscriptions/search [get]
+func (h *SubscriptionHandler) Search(ctx *gin.Context) {
+	h.handler.Search(ctx)
+}
+
+// Merge godoc
+// @Summary Объединить подписки
+// @Description Объединяет исходные подписки с целевой и удаляет их. У целевой подписки сохраняются
+// @Description название и цена, период расширяется до объединения периодов, заметки дописываются.
+// @Description Удалённые подписки сохраняются в журнале объединений вместе с автором и временем.
+// @Description Объединять можно только подписки одного пользователя и одного сервиса.
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "Ключ идемпотентности, до 255 символов"
+// @Param merge body dto.MergeSubscriptionsRequest true "Целевая и исходные подписки"
+// @Success 200 {object} v2.MergeSubscriptionsResponse "Результат объединения"
+// @Failure 400 {object} dto.ProblemResponse "Неверный запрос или подписки нельзя объединить"
+// @Failure 404 {object} dto.ProblemResponse "Подписка не найдена"
+// @Failure 409 {object} dto.ProblemResponse "Запрос с этим ключом идемпотентности ещё выполняется"
+// @Failure 422 {object} dto.ValidationProblemResponse "Ошибка валидации или ключ идемпотентности использован с другим запросом"
+// @Failure 401 {object} dto.ProblemResponse "Требуется аутентификация"
+// @Failure 403 {object} dto.AccessDeniedProblemResponse "Доступ к подписке запрещён политикой"
+// @Failure 429 {object} dto.ProblemResponse "Превышен лимит запросов"
+// @Failure 500 {object} dto.ProblemResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v2/subscriptions/merge [post]
+func (h *SubscriptionHandler) Merge(ctx *gin.Context) {
+	h.handler.Merge(ctx)
+}
+
+// Duplicates godoc
+// @Summary Найти дубликаты подписок пользователя
+// @Description Группирует подписки пользователя, которые, вероятно, внесены дважды: один сервис
+// @Description (название сравнивается без учёта регистра, пробелов и знаков препинания) и пересекающиеся периоды.
+// @Description Подписки без даты окончания считаются бессрочными. Подписки без дубликатов не возвращаются.
+// @Tags subscriptions
+// @Produce json
+// @Param id path string true "User ID" Format(uuid)
+// @Success 200 {object} v2.DuplicateGroupsResponse
+// @Failure 400 {object} dto.ProblemResponse "Неверный UUID"
+// @Failure 401 {object} dto.ProblemResponse "Требуется аутентификация"
+// @Failure 403 {object} dto.AccessDeniedProblemResponse "Доступ к подписке запрещён политикой"
+// @Failure 429 {object} dto.ProblemResponse "Превышен лимит запросов"
+// @Failure 500 {object} dto.ProblemResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v2/users/{id}/duplicates [get]
+func (h *SubscriptionHandler) Duplicates(ctx *gin.Context) {
+	h.handler.Duplicates(ctx)
+}
+
+// SubscriptionEventHandler serves the subscription event stream of the second API version.
+type SubscriptionEventHandler struct {
+	handler *handlers.SubscriptionEventHandler
+}
+
+func NewSubscriptionEventHandler(events usecase.SubscriptionEventService, heartbeat time.Duration, logger *logrus.Logger) *SubscriptionEventHandler {
+	return &SubscriptionEventHandler{handler: handlers.NewSubscriptionEventHandler(events, view{}, heartbeat, logger)}
+}
+
+// Events godoc
+// @Summary Поток изменений подписок
+// @Description Server-Sent Events: событие created, updated или deleted на каждое изменение подписки.
+// @Description Данные события — объект SubscriptionEventResponse, id события передаётся в поле id.
+// @Description При переподключении клиент передаёт заголовок Last-Event-ID (или параметр last_event_id)
+// @Description и получает пропущенные события из журнала последних изменений. Если нужные события
+// @Description уже вытеснены из журнала, приходит событие reset: подписки нужно загрузить заново.
+// @Description Пустые комментарии периодически отправляются, чтобы прокси не закрывали соединение.
+// @Tags subscriptions
+// @Produce text/event-stream
+// @Param user_id query string false "Только подписки пользователя" Format(uuid)
+// @Param service_name query string false "Только подписки сервиса, без учёта регистра"
+// @Param last_event_id query int false "ID последнего полученного события"
+// @Param Last-Event-ID header int false "ID последнего полученного события, важнее параметра"
+// @Success 200 {object} v2.SubscriptionEventResponse "Поток событий"
+// @Failure 400 {object} dto.ProblemResponse "Неверные параметры запроса"
+// @Failure 422 {object} dto.ValidationProblemResponse "Ошибка валидации"
+// @Failure 401 {object} dto.ProblemResponse "Требуется аутентификация"
+// @Failure 403 {object} dto.AccessDeniedProblemResponse "Доступ к подпискам запрещён политикой"
+// @Failure 429 {object} dto.ProblemResponse "Превышен лимит запросов"
+// @Failure 500 {object} dto.ProblemResponse "Внутренняя ошибка сервера"
+// @Security BearerAuth
+// @Security APIKeyAuth
+// @Router /v2/subscriptions/events [get]
+func (h *SubscriptionEventHandler) Events(ctx *gin.Context) {
+	h.handler.Events(ctx)
+}
@@ -0,0 +1,144 @@
+package v2_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	logruslogger "github.com/MDx3R/ef-test/internal/infra/logger"
+	handlersv2 "github.com/MDx3R/ef-test/internal/transport/http/gin/v2"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	mock_usecase "github.com/MDx3R/ef-test/internal/usecase/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var logger = logruslogger.NewLogger()
+
+func setupRouterAndHandler(t *testing.T) (*gin.Engine, *mock_usecase.MockSubscriptionService) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := mock_usecase.NewMockSubscriptionService(t)
+	handler := handlersv2.NewSubscriptionHandler(mockService, logger)
+
+	r := gin.New()
+	r.GET("", handler.List)
+	r.GET("/:id", handler.Get)
+
+	return r, mockService
+}
+
+func makeTestSubscriptionDTO(endDate *time.Time) dto.SubscriptionDTO {
+	return dto.SubscriptionDTO{
+		ID:          uuid.New(),
+		ServiceName: "test_service",
+		Price:       100,
+		UserID:      uuid.New(),
+		StartDate:   time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:     endDate,
+	}
+}
+
+func TestSubscriptionHandler_Get(t *testing.T) {
+	endDate := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		endDate  *time.Time
+		expected string
+	}{
+		{"bounded", &endDate, `"2025-12"`},
+		{"open-ended", nil, `null`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockService := setupRouterAndHandler(t)
+			sub := makeTestSubscriptionDTO(tt.endDate)
+			mockService.On("GetSubscription", mock.Anything, sub.ID).Return(sub, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/"+sub.ID.String(), nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, fmt.Sprintf(`{
+				"id": "%s",
+				"service_name": "test_service",
+				"price": 100,
+				"user_id": "%s",
+				"start_date": "2025-08",
+				"end_date": %s,
+				"notes": ""
+			}`, sub.ID, sub.UserID, tt.expected), w.Body.String())
+		})
+	}
+}
+
+func TestSubscriptionHandler_List_DateFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		code  int
+	}{
+		{"default", "", http.StatusOK},
+		{"iso", "?date_format=iso", http.StatusOK},
+		{"legacy", "?date_format=legacy", http.StatusBadRequest},
+		{"unsupported", "?date_format=unix", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, mockService := setupRouterAndHandler(t)
+			if tt.code == http.StatusOK {
+				mockService.On("ListSubscriptions", mock.Anything, mock.Anything).Return(dto.SubscriptionPageDTO{
+					Items: []dto.SubscriptionDTO{makeTestSubscriptionDTO(nil)},
+				}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.code, w.Code)
+			if tt.code == http.StatusOK {
+				assert.Contains(t, w.Body.String(), `"start_date":"2025-08","end_date":null`)
+			}
+		})
+	}
+}
+
+func TestSubscriptionHandler_List_PageSize(t *testing.T) {
+	tests := []struct {
+		pageSize string
+		code     int
+	}{
+		{"100", http.StatusOK},
+		{"101", http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pageSize, func(t *testing.T) {
+			router, mockService := setupRouterAndHandler(t)
+			if tt.code == http.StatusOK {
+				mockService.On("ListSubscriptions", mock.Anything, mock.Anything).Return(dto.SubscriptionPageDTO{}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/?page_size="+tt.pageSize, nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.code, w.Code)
+			if tt.code != http.StatusOK {
+				assert.Contains(t, w.Body.String(), "PageSize")
+			}
+		})
+	}
+}
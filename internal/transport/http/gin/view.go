@@ -0,0 +1,24 @@
+package gin
+
+import (
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	"github.com/gin-gonic/gin"
+)
+
+// SubscriptionView renders subscriptions in the response bodies of an API version.
+type SubscriptionView interface {
+	// Negotiate returns the renderer of the representation asked for by the request.
+	Negotiate(ctx *gin.Context) (SubscriptionRenderer, error)
+	// BoundsPageSize tells whether list pages are bounded by PageSizeLimit.
+	BoundsPageSize() bool
+}
+
+// SubscriptionRenderer builds the response bodies that carry subscriptions.
+type SubscriptionRenderer interface {
+	Subscription(sub dto.SubscriptionDTO) any
+	Page(page dto.SubscriptionPageDTO, filter dto.SubscriptionFilter) any
+	Search(subs []dto.SubscriptionDTO) any
+	Duplicates(groups []dto.DuplicateGroupDTO) any
+	Merge(merged dto.SubscriptionMergeDTO) any
+	Event(event dto.SubscriptionEventDTO) any
+}
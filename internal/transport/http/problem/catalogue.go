@@ -0,0 +1,138 @@
+package problem
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/MDx3R/ef-test/internal/domain"
+	"github.com/MDx3R/ef-test/internal/usecase"
+)
+
+// Code identifies a kind of error. Codes are part of the API:
+// clients may rely on them, so they are never changed or reused.
+type Code string
+
+const (
+	CodeInvalidRequest       = Code("request.invalid")
+	CodeInvalidID            = Code("request.invalid_id")
+	CodeUnsupportedMediaType = Code("request.unsupported_media_type")
+	CodeRequestTooLarge      = Code("request.too_large")
+
+	CodeValidationFailed = Code("validation.failed")
+	CodeInvalidPeriod    = Code("validation.invalid_period")
+	CodeInvalidScope     = Code("validation.invalid_scope")
+	CodeInvalidExpiry    = Code("validation.invalid_expiry")
+	CodeInvalidMerge     = Code("validation.invalid_merge")
+	CodeInvariant        = Code("validation.invariant")
+
+	CodeNotFound              = Code("resource.not_found")
+	CodeConflict              = Code("resource.conflict")
+	CodeSubscriptionNotFound  = Code("subscription.not_found")
+	CodeSubscriptionDuplicate = Code("subscription.duplicate")
+	CodeAPIKeyNotFound        = Code("api_key.not_found")
+
+	CodeUnauthenticated = Code("auth.unauthenticated")
+	CodeInvalidAPIKey   = Code("auth.invalid_api_key")
+	CodeForbidden       = Code("auth.forbidden")
+	CodeAccessDenied    = Code("auth.access_denied")
+	CodeMissingScope    = Code("auth.missing_scope")
+
+	CodeInvalidTenant   = Code("tenant.invalid")
+	CodeTenantForbidden = Code("tenant.forbidden")
+
+	CodeRateLimited = Code("rate_limit.exceeded")
+
+	CodeIdempotencyKeyTooLong = Code("idempotency.key_too_long")
+	CodeIdempotencyKeyReused  = Code("idempotency.key_reused")
+	CodeIdempotencyInProgress = Code("idempotency.in_progress")
+
+	CodeInternal = Code("internal")
+)
+
+// statuses are the HTTP statuses of the codes. Their texts are in messages.go.
+var statuses = map[Code]int{
+	CodeInvalidRequest:       http.StatusBadRequest,
+	CodeInvalidID:            http.StatusBadRequest,
+	CodeUnsupportedMediaType: http.StatusUnsupportedMediaType,
+	CodeRequestTooLarge:      http.StatusRequestEntityTooLarge,
+
+	CodeValidationFailed: http.StatusUnprocessableEntity,
+	CodeInvalidPeriod:    http.StatusBadRequest,
+	CodeInvalidScope:     http.StatusBadRequest,
+	CodeInvalidExpiry:    http.StatusBadRequest,
+	CodeInvalidMerge:     http.StatusBadRequest,
+	CodeInvariant:        http.StatusBadRequest,
+
+	CodeNotFound:              http.StatusNotFound,
+	CodeConflict:              http.StatusConflict,
+	CodeSubscriptionNotFound:  http.StatusNotFound,
+	CodeSubscriptionDuplicate: http.StatusConflict,
+	CodeAPIKeyNotFound:        http.StatusNotFound,
+
+	CodeUnauthenticated: http.StatusUnauthorized,
+	CodeInvalidAPIKey:   http.StatusUnauthorized,
+	CodeForbidden:       http.StatusForbidden,
+	CodeAccessDenied:    http.StatusForbidden,
+	CodeMissingScope:    http.StatusForbidden,
+
+	CodeInvalidTenant:   http.StatusBadRequest,
+	CodeTenantForbidden: http.StatusForbidden,
+
+	CodeRateLimited: http.StatusTooManyRequests,
+
+	CodeIdempotencyKeyTooLong: http.StatusBadRequest,
+	CodeIdempotencyKeyReused:  http.StatusUnprocessableEntity,
+	CodeIdempotencyInProgress: http.StatusConflict,
+
+	CodeInternal: http.StatusInternalServerError,
+}
+
+// Status is the HTTP status of responses with the code.
+func (c Code) Status() int {
+	return statuses[c]
+}
+
+// Type is the URI of the problem type, which RFC 7807 requires.
+func (c Code) Type() string {
+	return "urn:problem:" + string(c)
+}
+
+// errorCodes are checked in order, so more specific errors come first.
+var errorCodes = []struct {
+	err  error
+	code Code
+}{
+	{domain.ErrInvalidPeriod, CodeInvalidPeriod},
+	{domain.ErrInvalidScope, CodeInvalidScope},
+	{domain.ErrInvalidExpiry, CodeInvalidExpiry},
+	{domain.ErrInvalidMerge, CodeInvalidMerge},
+	{domain.ErrInvariant, CodeInvariant},
+	{usecase.ErrNotFound, CodeNotFound},
+	{usecase.ErrConflict, CodeConflict},
+	{usecase.ErrUnauthorized, CodeUnauthenticated},
+	{usecase.ErrForbidden, CodeForbidden},
+	{usecase.ErrIdempotencyKeyReused, CodeIdempotencyKeyReused},
+	{usecase.ErrIdempotencyKeyInProcess, CodeIdempotencyInProgress},
+}
+
+// CodeOf returns the code of a domain or usecase error, or CodeInternal
+// for errors clients can't do anything about, such as repository errors.
+func CodeOf(err error) Code {
+	var (
+		denied    *usecase.AccessDeniedError
+		duplicate *usecase.DuplicateSubscriptionError
+	)
+	switch {
+	case errors.As(err, &denied):
+		return CodeAccessDenied
+	case errors.As(err, &duplicate):
+		return CodeSubscriptionDuplicate
+	}
+
+	for _, ec := range errorCodes {
+		if errors.Is(err, ec.err) {
+			return ec.code
+		}
+	}
+	return CodeInternal
+}
@@ -0,0 +1,82 @@
+package problem
+
+// message is the text of a code in one language. Detail explains domain
+// errors, whose own messages are meant for developers rather than users.
+type message struct {
+	title  string
+	detail string
+}
+
+var messagesEN = map[Code]message{
+	CodeInvalidRequest:       {title: "Invalid request"},
+	CodeInvalidID:            {title: "Invalid identifier"},
+	CodeUnsupportedMediaType: {title: "Unsupported media type"},
+	CodeRequestTooLarge:      {title: "Request body too large"},
+
+	CodeValidationFailed: {title: "Validation failed"},
+	CodeInvalidPeriod:    {"Invalid subscription period", "The end date must not be earlier than the start date."},
+	CodeInvalidScope:     {"Invalid API key scope", "The API key must be granted at least one known scope."},
+	CodeInvalidExpiry:    {"Invalid API key expiry", "The API key must expire in the future."},
+	CodeInvalidMerge:     {"Invalid subscription merge", "Only distinct subscriptions of one user and service with overlapping periods can be merged."},
+	CodeInvariant:        {title: "Invalid data"},
+
+	CodeNotFound:              {title: "Resource not found"},
+	CodeConflict:              {title: "Conflict"},
+	CodeSubscriptionNotFound:  {title: "Subscription not found"},
+	CodeSubscriptionDuplicate: {title: "Duplicate subscription"},
+	CodeAPIKeyNotFound:        {title: "API key not found"},
+
+	CodeUnauthenticated: {title: "Authentication required"},
+	CodeInvalidAPIKey:   {title: "Invalid API key"},
+	CodeForbidden:       {title: "Forbidden"},
+	CodeAccessDenied:    {title: "Access denied"},
+	CodeMissingScope:    {title: "Missing API key scope"},
+
+	CodeInvalidTenant:   {title: "Invalid tenant"},
+	CodeTenantForbidden: {title: "Access to tenant is forbidden"},
+
+	CodeRateLimited: {title: "Rate limit exceeded"},
+
+	CodeIdempotencyKeyTooLong: {title: "Idempotency key is too long"},
+	CodeIdempotencyKeyReused:  {title: "Idempotency key reused"},
+	CodeIdempotencyInProgress: {title: "Request is in progress"},
+
+	CodeInternal: {title: "Internal server error"},
+}
+
+var messagesRU = map[Code]message{
+	CodeInvalidRequest:       {title: "Неверный запрос"},
+	CodeInvalidID:            {title: "Неверный идентификатор"},
+	CodeUnsupportedMediaType: {title: "Неподдерживаемый тип содержимого"},
+	CodeRequestTooLarge:      {title: "Слишком большое тело запроса"},
+
+	CodeValidationFailed: {title: "Ошибка валидации"},
+	CodeInvalidPeriod:    {"Неверный период подписки", "Дата окончания не может быть раньше даты начала."},
+	CodeInvalidScope:     {"Неверные права API-ключа", "API-ключу нужно выдать хотя бы одно из известных прав."},
+	CodeInvalidExpiry:    {"Неверный срок действия API-ключа", "Срок действия API-ключа должен истекать в будущем."},
+	CodeInvalidMerge:     {"Неверное объединение подписок", "Объединять можно только разные подписки одного пользователя на один сервис с пересекающимися периодами."},
+	CodeInvariant:        {title: "Неверные данные"},
+
+	CodeNotFound:              {title: "Ресурс не найден"},
+	CodeConflict:              {title: "Конфликт"},
+	CodeSubscriptionNotFound:  {title: "Подписка не найдена"},
+	CodeSubscriptionDuplicate: {title: "Подписка уже существует"},
+	CodeAPIKeyNotFound:        {title: "API-ключ не найден"},
+
+	CodeUnauthenticated: {title: "Требуется аутентификация"},
+	CodeInvalidAPIKey:   {title: "Неверный API-ключ"},
+	CodeForbidden:       {title: "Доступ запрещён"},
+	CodeAccessDenied:    {title: "Доступ запрещён политикой"},
+	CodeMissingScope:    {title: "У API-ключа нет нужных прав"},
+
+	CodeInvalidTenant:   {title: "Неверная организация"},
+	CodeTenantForbidden: {title: "Нет доступа к организации"},
+
+	CodeRateLimited: {title: "Превышен лимит запросов"},
+
+	CodeIdempotencyKeyTooLong: {title: "Слишком длинный ключ идемпотентности"},
+	CodeIdempotencyKeyReused:  {title: "Ключ идемпотентности использован с другим запросом"},
+	CodeIdempotencyInProgress: {title: "Запрос ещё выполняется"},
+
+	CodeInternal: {title: "Внутренняя ошибка сервера"},
+}
@@ -0,0 +1,104 @@
+// Package problem writes error responses in the format of RFC 7807
+// with the stable error codes of its catalogue.
+package problem
+
+import (
+	"errors"
+
+	"github.com/MDx3R/ef-test/internal/transport/http/dto"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+)
+
+const ContentType = "application/problem+json"
+
+// exposeInternalKey marks requests whose internal errors may show their cause.
+const exposeInternalKey = "problem.expose_internal"
+
+// ExposeInternal is a middleware making internal errors show their cause
+// in the detail. Causes may reveal the database and queries, so it is only
+// meant for local development.
+func ExposeInternal(c *gin.Context) {
+	c.Set(exposeInternalKey, true)
+}
+
+// New returns the problem of code that occurred while handling the request,
+// titled in the language of the request.
+func New(c *gin.Context, code Code, detail string) dto.ProblemResponse {
+	return dto.ProblemResponse{
+		Type:     code.Type(),
+		Title:    title(TranslatorFrom(c), code),
+		Status:   code.Status(),
+		Detail:   detail,
+		Instance: c.Request.URL.Path,
+		Code:     string(code),
+	}
+}
+
+// Respond aborts the request with the problem of code.
+func Respond(c *gin.Context, code Code, detail string) {
+	Write(c, code.Status(), New(c, code, detail))
+}
+
+// Write aborts the request with body, a problem extended with more members.
+func Write(c *gin.Context, status int, body any) {
+	c.Header("Content-Type", ContentType)
+	c.Header("Content-Language", TranslatorFrom(c).Locale())
+	c.AbortWithStatusJSON(status, body)
+}
+
+// RespondError aborts the request with the problem of err. Domain errors
+// are explained in the language of the request. Internal errors have
+// no detail unless exposed, see ExposeInternal.
+func RespondError(c *gin.Context, err error) {
+	var (
+		denied    *usecase.AccessDeniedError
+		duplicate *usecase.DuplicateSubscriptionError
+	)
+	switch {
+	case errors.As(err, &denied):
+		RespondAccessDenied(c, denied)
+	case errors.As(err, &duplicate):
+		RespondDuplicate(c, duplicate)
+	default:
+		code := CodeOf(err)
+		if text, ok := detail(TranslatorFrom(c), code); ok {
+			Respond(c, code, text)
+			return
+		}
+		if code == CodeInternal && !c.GetBool(exposeInternalKey) {
+			Respond(c, code, "")
+			return
+		}
+		Respond(c, code, err.Error())
+	}
+}
+
+// RespondValidation aborts the request with the invalid fields of its body or query.
+func RespondValidation(c *gin.Context, fields map[string]string) {
+	Write(c, CodeValidationFailed.Status(), dto.ValidationProblemResponse{
+		ProblemResponse: New(c, CodeValidationFailed, ""),
+		Errors:          fields,
+	})
+}
+
+func RespondAccessDenied(c *gin.Context, err *usecase.AccessDeniedError) {
+	roles := err.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+	Write(c, CodeAccessDenied.Status(), dto.AccessDeniedProblemResponse{
+		ProblemResponse: New(c, CodeAccessDenied, ""),
+		Action:          string(err.Action),
+		Resource:        err.Resource,
+		Roles:           roles,
+		Reason:          err.Reason,
+	})
+}
+
+func RespondDuplicate(c *gin.Context, err *usecase.DuplicateSubscriptionError) {
+	Write(c, CodeSubscriptionDuplicate.Status(), dto.DuplicateProblemResponse{
+		ProblemResponse: New(c, CodeSubscriptionDuplicate, err.Error()),
+		DuplicateIDs:    dto.FromUUIDs(err.IDs),
+	})
+}
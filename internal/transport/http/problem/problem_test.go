@@ -0,0 +1,150 @@
+package problem_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MDx3R/ef-test/internal/domain"
+	"github.com/MDx3R/ef-test/internal/transport/http/problem"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// respond serves err from a route whose middleware may expose internal errors.
+func respond(err error, middleware ...gin.HandlerFunc) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(middleware...)
+	r.GET("/subscriptions", func(c *gin.Context) {
+		problem.RespondError(c, err)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/subscriptions", nil))
+	return w
+}
+
+func newTranslator(t *testing.T) *problem.Translator {
+	translator, err := problem.NewTranslator(validator.New())
+	require.NoError(t, err)
+	return translator
+}
+
+func decode(t *testing.T, w *httptest.ResponseRecorder) map[string]any {
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	return body
+}
+
+func TestCodeOf(t *testing.T) {
+	repoErr := fmt.Errorf("%w: pq: connection refused", usecase.ErrRepository)
+
+	tests := []struct {
+		err  error
+		code problem.Code
+	}{
+		{domain.ErrInvalidPeriod, problem.CodeInvalidPeriod},
+		{fmt.Errorf("update: %w", domain.ErrInvalidMerge), problem.CodeInvalidMerge},
+		{domain.ErrInvariant, problem.CodeInvariant},
+		{usecase.ErrNotFound, problem.CodeNotFound},
+		{usecase.ErrIdempotencyKeyInProcess, problem.CodeIdempotencyInProgress},
+		{&usecase.AccessDeniedError{}, problem.CodeAccessDenied},
+		{&usecase.DuplicateSubscriptionError{}, problem.CodeSubscriptionDuplicate},
+		{repoErr, problem.CodeInternal},
+		{errors.New("unexpected"), problem.CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.code), func(t *testing.T) {
+			assert.Equal(t, tt.code, problem.CodeOf(tt.err))
+		})
+	}
+}
+
+func TestRespondError(t *testing.T) {
+	w := respond(domain.ErrInvalidPeriod)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"type": "urn:problem:validation.invalid_period",
+		"title": "Invalid subscription period",
+		"status": 400,
+		"detail": "The end date must not be earlier than the start date.",
+		"instance": "/subscriptions",
+		"code": "validation.invalid_period"
+	}`, w.Body.String())
+}
+
+func TestRespondError_HidesInternal(t *testing.T) {
+	w := respond(fmt.Errorf("%w: pq: password authentication failed", usecase.ErrRepository))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	body := decode(t, w)
+	assert.Equal(t, "internal", body["code"])
+	assert.NotContains(t, body, "detail")
+	assert.NotContains(t, w.Body.String(), "pq:")
+}
+
+func TestRespondError_ExposesInternal(t *testing.T) {
+	w := respond(fmt.Errorf("%w: pq: password authentication failed", usecase.ErrRepository), problem.ExposeInternal)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "repository error: pq: password authentication failed", decode(t, w)["detail"])
+}
+
+func TestRespondValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.POST("/subscriptions", func(c *gin.Context) {
+		problem.RespondValidation(c, map[string]string{"Price": "Price is required"})
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/subscriptions", nil))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.JSONEq(t, `{
+		"type": "urn:problem:validation.failed",
+		"title": "Validation failed",
+		"status": 422,
+		"instance": "/subscriptions",
+		"code": "validation.failed",
+		"errors": {"Price": "Price is required"}
+	}`, w.Body.String())
+}
+
+func TestCatalogue_Complete(t *testing.T) {
+	codes := []problem.Code{
+		problem.CodeInvalidRequest, problem.CodeInvalidID, problem.CodeRequestTooLarge, problem.CodeValidationFailed,
+		problem.CodeInvalidPeriod, problem.CodeInvalidScope, problem.CodeInvalidExpiry,
+		problem.CodeInvalidMerge, problem.CodeInvariant, problem.CodeNotFound, problem.CodeConflict,
+		problem.CodeSubscriptionNotFound, problem.CodeSubscriptionDuplicate, problem.CodeAPIKeyNotFound,
+		problem.CodeUnauthenticated, problem.CodeInvalidAPIKey, problem.CodeForbidden,
+		problem.CodeAccessDenied, problem.CodeMissingScope, problem.CodeInvalidTenant,
+		problem.CodeTenantForbidden, problem.CodeRateLimited, problem.CodeIdempotencyKeyTooLong,
+		problem.CodeIdempotencyKeyReused, problem.CodeIdempotencyInProgress, problem.CodeInternal,
+	}
+	translator := newTranslator(t)
+
+	for _, lang := range []string{"en", "ru"} {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		c.Request.Header.Set("Accept-Language", lang)
+		translator.Localize(c)
+
+		for _, code := range codes {
+			assert.NotZero(t, code.Status(), code)
+			assert.NotEqual(t, string(code), problem.New(c, code, "").Title, "%s has no %s title", code, lang)
+		}
+	}
+}
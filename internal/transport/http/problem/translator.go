@@ -0,0 +1,179 @@
+package problem
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/locales"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ru"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	entranslations "github.com/go-playground/validator/v10/translations/en"
+	rutranslations "github.com/go-playground/validator/v10/translations/ru"
+)
+
+// translatorKey holds the translator chosen for the request.
+const translatorKey = "problem.translator"
+
+// language is a language the service speaks.
+type language struct {
+	locale       locales.Translator
+	messages     map[Code]message
+	registerTags func(*validator.Validate, ut.Translator) error
+}
+
+// languages come in order of preference, the first one is the fallback.
+var languages = []language{
+	{en.New(), messagesEN, entranslations.RegisterDefaultTranslations},
+	{ru.New(), messagesRU, rutranslations.RegisterDefaultTranslations},
+}
+
+// fallback speaks English to requests that weren't localized.
+var fallback = mustNewUniversalTranslator()
+
+// Translator renders problems and validation errors in the language
+// of the request.
+type Translator struct {
+	uni *ut.UniversalTranslator
+}
+
+// NewTranslator adds the messages of the validation errors of v in every
+// language. Requests in languages the service doesn't speak get English.
+func NewTranslator(v *validator.Validate) (*Translator, error) {
+	uni, err := newUniversalTranslator()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, lang := range languages {
+		trans, _ := uni.GetTranslator(lang.locale.Locale())
+		if err := lang.registerTags(v, trans); err != nil {
+			return nil, fmt.Errorf("failed to register %s validation messages: %w", lang.locale.Locale(), err)
+		}
+	}
+	return &Translator{uni: uni}, nil
+}
+
+// Localize is a middleware choosing the language of the problems of the
+// request by its Accept-Language header.
+func (t *Translator) Localize(c *gin.Context) {
+	c.Set(translatorKey, t.For(c.GetHeader("Accept-Language")))
+	c.Header("Vary", "Accept-Language")
+}
+
+// For returns the translator of the most preferred language of an
+// Accept-Language header, such as "ru-RU,ru;q=0.9,en;q=0.8".
+func (t *Translator) For(acceptLanguage string) ut.Translator {
+	trans, _ := t.uni.FindTranslator(parseAcceptLanguage(acceptLanguage)...)
+	return trans
+}
+
+// TranslatorFrom returns the translator chosen for the request, see Localize.
+func TranslatorFrom(c *gin.Context) ut.Translator {
+	if trans, ok := c.Get(translatorKey); ok {
+		return trans.(ut.Translator)
+	}
+	return fallback.GetFallback()
+}
+
+// title is a short summary of the problem that doesn't change between occurrences.
+func title(trans ut.Translator, code Code) string {
+	if text, err := trans.T(titleKey(code)); err == nil {
+		return text
+	}
+	return string(code)
+}
+
+// detail explains a domain error to users, if there is an explanation.
+func detail(trans ut.Translator, code Code) (string, bool) {
+	text, err := trans.T(detailKey(code))
+	return text, err == nil
+}
+
+func titleKey(code Code) string {
+	return "problem.title." + string(code)
+}
+
+func detailKey(code Code) string {
+	return "problem.detail." + string(code)
+}
+
+func newUniversalTranslator() (*ut.UniversalTranslator, error) {
+	uni := ut.New(languages[0].locale)
+	for _, lang := range languages {
+		if err := uni.AddTranslator(lang.locale, true); err != nil {
+			return nil, err
+		}
+		trans, _ := uni.GetTranslator(lang.locale.Locale())
+		for code, msg := range lang.messages {
+			if err := trans.Add(titleKey(code), msg.title, false); err != nil {
+				return nil, err
+			}
+			if msg.detail == "" {
+				continue
+			}
+			if err := trans.Add(detailKey(code), msg.detail, false); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return uni, nil
+}
+
+func mustNewUniversalTranslator() *ut.UniversalTranslator {
+	uni, err := newUniversalTranslator()
+	if err != nil {
+		panic(err)
+	}
+	return uni
+}
+
+// parseAcceptLanguage returns the primary subtags of the languages
+// in a header, most preferred first.
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		lang string
+		q    float64
+	}
+
+	var langs []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if lang == "" || lang == "*" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > 0 {
+			langs = append(langs, weighted{lang, q})
+		}
+	}
+	slices.SortStableFunc(langs, func(a, b weighted) int {
+		switch {
+		case a.q > b.q:
+			return -1
+		case a.q < b.q:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	result := make([]string, len(langs))
+	for i, l := range langs {
+		result[i] = l.lang
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+package problem_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/MDx3R/ef-test/internal/domain"
+	"github.com/MDx3R/ef-test/internal/transport/http/problem"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslator_For(t *testing.T) {
+	translator := newTranslator(t)
+
+	tests := []struct {
+		header string
+		locale string
+	}{
+		{"", "en"},
+		{"ru", "ru"},
+		{"ru-RU,ru;q=0.9,en;q=0.8", "ru"},
+		{"en-US,en;q=0.9,ru;q=0.8", "en"},
+		{"en;q=0.5, ru", "ru"},
+		{"fr-FR, ru;q=0.3", "ru"},
+		{"fr", "en"},
+		{"ru;q=0, en", "en"},
+		{"*", "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.locale, translator.For(tt.header).Locale())
+		})
+	}
+}
+
+// localizedRouter responds with problem to requests localized by translator.
+func localizedRouter(translator *problem.Translator, respond gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(translator.Localize)
+	r.POST("/subscriptions", respond)
+	return r
+}
+
+func doLocalized(r *gin.Engine, acceptLanguage string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/subscriptions", nil)
+	req.Header.Set("Accept-Language", acceptLanguage)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestTranslator_DomainError(t *testing.T) {
+	router := localizedRouter(newTranslator(t), func(c *gin.Context) {
+		problem.RespondError(c, domain.ErrInvalidPeriod)
+	})
+
+	ru := doLocalized(router, "ru-RU")
+	en := doLocalized(router, "en")
+
+	assert.Equal(t, http.StatusBadRequest, ru.Code)
+	assert.Equal(t, "ru", ru.Header().Get("Content-Language"))
+	assert.Equal(t, "Accept-Language", ru.Header().Get("Vary"))
+	assert.Equal(t, "Неверный период подписки", decode(t, ru)["title"])
+	assert.Equal(t, "Дата окончания не может быть раньше даты начала.", decode(t, ru)["detail"])
+
+	assert.Equal(t, "en", en.Header().Get("Content-Language"))
+	assert.Equal(t, "Invalid subscription period", decode(t, en)["title"])
+	assert.Equal(t, "The end date must not be earlier than the start date.", decode(t, en)["detail"])
+}
+
+func TestTranslator_ValidationErrors(t *testing.T) {
+	validate := validator.New()
+	translator, err := problem.NewTranslator(validate)
+	require.NoError(t, err)
+
+	type request struct {
+		ServiceName string `validate:"required"`
+		Price       int    `validate:"gte=0"`
+	}
+	router := localizedRouter(translator, func(c *gin.Context) {
+		verr := validate.Struct(request{Price: -1}).(validator.ValidationErrors)
+		trans := problem.TranslatorFrom(c)
+
+		fields := make(map[string]string)
+		for _, fe := range verr {
+			fields[fe.Field()] = fe.Translate(trans)
+		}
+		problem.RespondValidation(c, fields)
+	})
+
+	ru := doLocalized(router, "ru")
+	en := doLocalized(router, "en")
+
+	assert.Equal(t, http.StatusUnprocessableEntity, ru.Code)
+	assert.Equal(t, map[string]any{
+		"ServiceName": "ServiceName обязательное поле",
+		"Price":       "Price должен быть больше или равно 0",
+	}, decode(t, ru)["errors"])
+	assert.Equal(t, "Ошибка валидации", decode(t, ru)["title"])
+
+	assert.Equal(t, map[string]any{
+		"ServiceName": "ServiceName is a required field",
+		"Price":       "Price must be 0 or greater",
+	}, decode(t, en)["errors"])
+}
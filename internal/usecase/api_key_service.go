@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	"github.com/google/uuid"
+)
+
+const (
+	apiKeyPrefix = "sk_"
+	// apiKeyShownLength is how much of the key is kept in plain text to tell keys apart.
+	apiKeyShownLength = len(apiKeyPrefix) + 8
+	// lastUsedResolution limits how often authentication writes last usage time.
+	lastUsedResolution = time.Minute
+)
+
+// APIKeyService issues and checks API keys of service-to-service clients.
+// Managing keys requires a Principal with admin role in ctx.
+type APIKeyService interface {
+	IssueAPIKey(ctx context.Context, request dto.IssueAPIKeyCommand) (dto.IssuedAPIKeyDTO, error)
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) error
+	ListAPIKeys(ctx context.Context) ([]dto.APIKeyDTO, error)
+	// Authenticate returns the client principal of an active key or ErrUnauthorized.
+	Authenticate(ctx context.Context, key string) (Principal, error)
+}
+
+type apiKeyService struct {
+	keyRepo APIKeyRepository
+}
+
+func NewAPIKeyService(keyRepo APIKeyRepository) APIKeyService {
+	return &apiKeyService{keyRepo: keyRepo}
+}
+
+func (s *apiKeyService) IssueAPIKey(ctx context.Context, request dto.IssueAPIKeyCommand) (dto.IssuedAPIKeyDTO, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return dto.IssuedAPIKeyDTO{}, err
+	}
+
+	plain, err := generateAPIKey()
+	if err != nil {
+		return dto.IssuedAPIKeyDTO{}, err
+	}
+
+	key, err := entity.NewAPIKey(
+		TenantFrom(ctx).ID,
+		request.Name,
+		HashAPIKey(plain),
+		plain[:apiKeyShownLength],
+		request.Scopes,
+		time.Now().UTC(),
+		request.ExpiresAt,
+	)
+	if err != nil {
+		return dto.IssuedAPIKeyDTO{}, err
+	}
+
+	if err := s.keyRepo.Add(ctx, key); err != nil {
+		return dto.IssuedAPIKeyDTO{}, err
+	}
+	return dto.IssuedAPIKeyDTO{APIKeyDTO: dto.FromAPIKey(key), Key: plain}, nil
+}
+
+func (s *apiKeyService) RevokeAPIKey(ctx context.Context, id uuid.UUID) error {
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	key, err := s.keyRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	key.Revoke(time.Now().UTC())
+	return s.keyRepo.Update(ctx, key)
+}
+
+func (s *apiKeyService) ListAPIKeys(ctx context.Context) ([]dto.APIKeyDTO, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	keys, err := s.keyRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.APIKeyDTO, len(keys))
+	for i, key := range keys {
+		result[i] = dto.FromAPIKey(key)
+	}
+	return result, nil
+}
+
+func (s *apiKeyService) Authenticate(ctx context.Context, plain string) (Principal, error) {
+	key, err := s.keyRepo.GetByHash(ctx, HashAPIKey(plain))
+	if errors.Is(err, ErrNotFound) {
+		return Principal{}, ErrUnauthorized
+	}
+	if err != nil {
+		return Principal{}, err
+	}
+
+	now := time.Now().UTC()
+	if !key.IsActive(now) {
+		return Principal{}, ErrUnauthorized
+	}
+
+	if last := key.LastUsedAt(); last == nil || now.Sub(*last) >= lastUsedResolution {
+		err := s.keyRepo.TouchLastUsed(ctx, key.TenantID(), key.ID(), now)
+		// The key was revoked since it was read.
+		if errors.Is(err, ErrNotFound) {
+			return Principal{}, ErrUnauthorized
+		}
+		if err != nil {
+			return Principal{}, err
+		}
+	}
+
+	return Principal{
+		Subject: key.ID().String(),
+		Tenant:  key.TenantID(),
+		Client:  true,
+		Scopes:  key.Scopes(),
+	}, nil
+}
+
+// HashAPIKey returns the form in which keys are stored.
+// Keys are random, so a plain SHA-256 is enough and allows lookup by hash.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateAPIKey() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+func requireAdmin(ctx context.Context) error {
+	if p, ok := PrincipalFrom(ctx); !ok || !p.IsAdmin() {
+		return ErrForbidden
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/domain"
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	mock_usecase "github.com/MDx3R/ef-test/internal/usecase/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAPIKeyService(t *testing.T) (*mock_usecase.MockAPIKeyRepository, usecase.APIKeyService) {
+	mockRepo := mock_usecase.NewMockAPIKeyRepository(t)
+	service := usecase.NewAPIKeyService(mockRepo)
+	return mockRepo, service
+}
+
+func makeTestAPIKey(t *testing.T, plain string, expiresAt *time.Time) *entity.APIKey {
+	key, err := entity.NewAPIKeyWithID(
+		uuid.New(),
+		usecase.DefaultTenantID,
+		"billing-export",
+		usecase.HashAPIKey(plain),
+		"sk_abcdefgh",
+		[]string{entity.ScopeSubscriptionsRead},
+		time.Now().UTC().Add(-time.Hour),
+		expiresAt,
+	)
+	require.NoError(t, err)
+	return key
+}
+
+func TestAPIKeyService_IssueAPIKey(t *testing.T) {
+	mockRepo, service := setupAPIKeyService(t)
+
+	var stored *entity.APIKey
+	mockRepo.On("Add", mock.Anything, mock.AnythingOfType("*entity.APIKey")).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*entity.APIKey) }).
+		Return(nil)
+
+	issued, err := service.IssueAPIKey(adminContext(), dto.IssueAPIKeyCommand{
+		Name:   "billing-export",
+		Scopes: []string{entity.ScopeReportsRead},
+	})
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(issued.Key, "sk_"))
+	assert.True(t, strings.HasPrefix(issued.Key, issued.Prefix))
+	assert.Equal(t, []string{entity.ScopeReportsRead}, issued.Scopes)
+	assert.Equal(t, usecase.HashAPIKey(issued.Key), stored.Hash())
+	assert.NotContains(t, stored.Hash(), issued.Key)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAPIKeyService_IssueAPIKey_TenantOfContext(t *testing.T) {
+	mockRepo, service := setupAPIKeyService(t)
+
+	mockRepo.On("Add", mock.Anything, mock.MatchedBy(func(k *entity.APIKey) bool {
+		return k.TenantID() == "retail"
+	})).Return(nil)
+
+	ctx := usecase.WithTenant(adminContext(), usecase.Tenant{ID: "retail"})
+	issued, err := service.IssueAPIKey(ctx, dto.IssueAPIKeyCommand{
+		Name:   "billing-export",
+		Scopes: []string{entity.ScopeReportsRead},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "retail", issued.TenantID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAPIKeyService_IssueAPIKey_InvalidScope(t *testing.T) {
+	_, service := setupAPIKeyService(t)
+
+	_, err := service.IssueAPIKey(adminContext(), dto.IssueAPIKeyCommand{
+		Name:   "billing-export",
+		Scopes: []string{"subscriptions:admin"},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidScope)
+}
+
+func TestAPIKeyService_IssueAPIKey_NotAdmin_Forbidden(t *testing.T) {
+	_, service := setupAPIKeyService(t)
+
+	for name, ctx := range map[string]context.Context{
+		"no principal": context.Background(),
+		"user":         userContext(uuid.New()),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := service.IssueAPIKey(ctx, dto.IssueAPIKeyCommand{
+				Name:   "billing-export",
+				Scopes: []string{entity.ScopeReportsRead},
+			})
+
+			assert.ErrorIs(t, err, usecase.ErrForbidden)
+		})
+	}
+}
+
+func TestAPIKeyService_RevokeAPIKey(t *testing.T) {
+	mockRepo, service := setupAPIKeyService(t)
+
+	key := makeTestAPIKey(t, "sk_secret", nil)
+
+	mockRepo.On("Get", mock.Anything, key.ID()).Return(key, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(k *entity.APIKey) bool {
+		return k.RevokedAt() != nil
+	})).Return(nil)
+
+	err := service.RevokeAPIKey(adminContext(), key.ID())
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAPIKeyService_RevokeAPIKey_NotFound(t *testing.T) {
+	mockRepo, service := setupAPIKeyService(t)
+
+	id := uuid.New()
+	mockRepo.On("Get", mock.Anything, id).Return(nil, usecase.ErrNotFound)
+
+	err := service.RevokeAPIKey(adminContext(), id)
+
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAPIKeyService_ListAPIKeys(t *testing.T) {
+	mockRepo, service := setupAPIKeyService(t)
+
+	key := makeTestAPIKey(t, "sk_secret", nil)
+	mockRepo.On("List", mock.Anything).Return([]*entity.APIKey{key}, nil)
+
+	keys, err := service.ListAPIKeys(adminContext())
+
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, key.ID(), keys[0].ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	mockRepo, service := setupAPIKeyService(t)
+
+	key := makeTestAPIKey(t, "sk_secret", nil)
+
+	mockRepo.On("GetByHash", mock.Anything, usecase.HashAPIKey("sk_secret")).Return(key, nil)
+	mockRepo.On("TouchLastUsed", mock.Anything, usecase.DefaultTenantID, key.ID(), mock.Anything).Return(nil)
+
+	principal, err := service.Authenticate(context.Background(), "sk_secret")
+
+	require.NoError(t, err)
+	assert.Equal(t, key.ID().String(), principal.Subject)
+	assert.Equal(t, usecase.DefaultTenantID, principal.Tenant)
+	assert.True(t, principal.Client)
+	assert.True(t, principal.HasScope(entity.ScopeSubscriptionsRead))
+	assert.False(t, principal.HasScope(entity.ScopeSubscriptionsWrite))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAPIKeyService_Authenticate_RecentlyUsed_SkipsUpdate(t *testing.T) {
+	mockRepo, service := setupAPIKeyService(t)
+
+	key := makeTestAPIKey(t, "sk_secret", nil)
+	key.Touch(time.Now().UTC())
+
+	mockRepo.On("GetByHash", mock.Anything, usecase.HashAPIKey("sk_secret")).Return(key, nil)
+
+	_, err := service.Authenticate(context.Background(), "sk_secret")
+
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyService_Authenticate_RevokedWhileTouching(t *testing.T) {
+	mockRepo, service := setupAPIKeyService(t)
+
+	key := makeTestAPIKey(t, "sk_secret", nil)
+
+	mockRepo.On("GetByHash", mock.Anything, usecase.HashAPIKey("sk_secret")).Return(key, nil)
+	mockRepo.On("TouchLastUsed", mock.Anything, usecase.DefaultTenantID, key.ID(), mock.Anything).Return(usecase.ErrNotFound)
+
+	_, err := service.Authenticate(context.Background(), "sk_secret")
+
+	assert.ErrorIs(t, err, usecase.ErrUnauthorized)
+}
+
+func TestAPIKeyService_Authenticate_Rejects(t *testing.T) {
+	expired := time.Now().UTC().Add(-time.Minute)
+	revoked := makeTestAPIKey(t, "sk_secret", nil)
+	revoked.Revoke(time.Now().UTC())
+
+	tests := []struct {
+		name string
+		key  *entity.APIKey
+		err  error
+	}{
+		{"unknown", nil, usecase.ErrNotFound},
+		{"expired", makeTestAPIKey(t, "sk_secret", &expired), nil},
+		{"revoked", revoked, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo, service := setupAPIKeyService(t)
+			mockRepo.On("GetByHash", mock.Anything, usecase.HashAPIKey("sk_secret")).Return(tt.key, tt.err)
+
+			_, err := service.Authenticate(context.Background(), "sk_secret")
+
+			assert.ErrorIs(t, err, usecase.ErrUnauthorized)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"context"
+	"time"
+)
+
+// Cache keeps encoded values for a while, such as an LRU or Redis.
+// Values may be evicted before they expire.
+type Cache interface {
+	// Get reports false if the key is missing or expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// Caches whose lookups are recorded.
+const (
+	CacheSubscription = "subscription"
+	CacheTotalCost    = "total_cost"
+)
+
+// CacheRecorder counts cache lookups, such as a metrics registry.
+type CacheRecorder interface {
+	RecordCacheLookup(cache string, hit bool)
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
+)
+
+// generationTTL outlives the cached values, so that a generation
+// only expires once nothing is cached under it anymore.
+const generationTTL = 24 * time.Hour
+
+// cachingSubscriptionService serves subscriptions and total costs from the cache.
+// Values of a tenant are cached under its generation, which every change made
+// through the service replaces, so that no value cached before a change is read
+// after it. Changes made elsewhere, such as by instances that don't share the
+// cache, are seen once the values expire.
+type cachingSubscriptionService struct {
+	SubscriptionService
+	cache    Cache
+	ttl      time.Duration
+	recorder CacheRecorder
+	// totals collapses concurrent computations of the same total cost.
+	totals singleflight.Group
+}
+
+// NewCachingSubscriptionService caches values for ttl. The recorder may be nil.
+// Reads fall through to next while the cache fails.
+func NewCachingSubscriptionService(next SubscriptionService, cache Cache, ttl time.Duration, recorder CacheRecorder) SubscriptionService {
+	return &cachingSubscriptionService{SubscriptionService: next, cache: cache, ttl: ttl, recorder: recorder}
+}
+
+func (s *cachingSubscriptionService) GetSubscription(ctx context.Context, id uuid.UUID) (dto.SubscriptionDTO, error) {
+	generation, ok := s.generation(ctx)
+	if !ok {
+		return s.SubscriptionService.GetSubscription(ctx, id)
+	}
+
+	key := fmt.Sprintf("%s:%s:%s:%s", CacheSubscription, TenantFrom(ctx).ID, generation, id)
+	var sub dto.SubscriptionDTO
+	if s.lookup(ctx, CacheSubscription, key, &sub) {
+		return sub, nil
+	}
+
+	sub, err := s.SubscriptionService.GetSubscription(ctx, id)
+	if err != nil {
+		return dto.SubscriptionDTO{}, err
+	}
+	s.store(ctx, key, sub)
+	return sub, nil
+}
+
+func (s *cachingSubscriptionService) CalculateTotalCost(ctx context.Context, filter dto.TotalCostFilter) (int, error) {
+	generation, ok := s.generation(ctx)
+	if !ok {
+		return s.SubscriptionService.CalculateTotalCost(ctx, filter)
+	}
+
+	// The service name goes last, so that its colons can't make keys ambiguous.
+	key := fmt.Sprintf("%s:%s:%s:%s:%s:%s:%s", CacheTotalCost, TenantFrom(ctx).ID, generation, filter.UserID,
+		filter.PeriodStart.Format(time.DateOnly), filter.PeriodEnd.Format(time.DateOnly), filter.ServiceName)
+	var total int
+	if s.lookup(ctx, CacheTotalCost, key, &total) {
+		return total, nil
+	}
+
+	value, err, _ := s.totals.Do(key, func() (any, error) {
+		// The result is shared by every caller waiting for the key,
+		// so the one computing it mustn't fail the others by leaving.
+		ctx := context.WithoutCancel(ctx)
+		total, err := s.SubscriptionService.CalculateTotalCost(ctx, filter)
+		if err != nil {
+			return 0, err
+		}
+		s.store(ctx, key, total)
+		return total, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return value.(int), nil
+}
+
+func (s *cachingSubscriptionService) CreateSubscription(ctx context.Context, request dto.CreateSubscriptionCommand) (uuid.UUID, error) {
+	defer s.invalidate(ctx)
+	return s.SubscriptionService.CreateSubscription(ctx, request)
+}
+
+func (s *cachingSubscriptionService) UpdateSubscription(ctx context.Context, id uuid.UUID, request dto.UpdateSubscriptionCommand) error {
+	defer s.invalidate(ctx)
+	return s.SubscriptionService.UpdateSubscription(ctx, id, request)
+}
+
+func (s *cachingSubscriptionService) PatchSubscription(ctx context.Context, id uuid.UUID, request dto.PatchSubscriptionCommand) (dto.SubscriptionDTO, error) {
+	defer s.invalidate(ctx)
+	return s.SubscriptionService.PatchSubscription(ctx, id, request)
+}
+
+func (s *cachingSubscriptionService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	defer s.invalidate(ctx)
+	return s.SubscriptionService.DeleteSubscription(ctx, id)
+}
+
+func (s *cachingSubscriptionService) MergeSubscriptions(ctx context.Context, request dto.MergeSubscriptionsCommand) (dto.SubscriptionMergeDTO, error) {
+	defer s.invalidate(ctx)
+	return s.SubscriptionService.MergeSubscriptions(ctx, request)
+}
+
+// generation returns the generation values of the tenant are cached under.
+// It reports false if the cache can't be used.
+func (s *cachingSubscriptionService) generation(ctx context.Context) (string, bool) {
+	value, ok, err := s.cache.Get(ctx, generationKey(ctx))
+	if err != nil {
+		requestLogger(ctx).WithError(err).Warn("failed to read cache generation")
+		return "", false
+	}
+	if ok {
+		return string(value), true
+	}
+	// A fresh generation, since values of an evicted one may still be cached.
+	return s.renew(ctx)
+}
+
+// invalidate runs even after failed changes, since they may have been applied
+// before failing, and even if the request is cancelled.
+func (s *cachingSubscriptionService) invalidate(ctx context.Context) {
+	s.renew(context.WithoutCancel(ctx))
+}
+
+func (s *cachingSubscriptionService) renew(ctx context.Context) (string, bool) {
+	generation := uuid.NewString()
+	if err := s.cache.Set(ctx, generationKey(ctx), []byte(generation), generationTTL); err != nil {
+		requestLogger(ctx).WithError(err).Warn("failed to renew cache generation")
+		return "", false
+	}
+	return generation, true
+}
+
+func generationKey(ctx context.Context) string {
+	return "generation:" + TenantFrom(ctx).ID
+}
+
+// lookup decodes the cached value of key into target and reports whether there was one.
+func (s *cachingSubscriptionService) lookup(ctx context.Context, cache, key string, target any) bool {
+	value, ok, err := s.cache.Get(ctx, key)
+	if err != nil {
+		requestLogger(ctx).WithError(err).WithField("cache", cache).Warn("failed to read cache")
+	}
+	hit := ok && err == nil && json.Unmarshal(value, target) == nil
+	if s.recorder != nil {
+		s.recorder.RecordCacheLookup(cache, hit)
+	}
+	return hit
+}
+
+func (s *cachingSubscriptionService) store(ctx context.Context, key string, value any) {
+	encoded, err := json.Marshal(value)
+	if err == nil {
+		err = s.cache.Set(ctx, key, encoded, s.ttl)
+	}
+	if err != nil {
+		requestLogger(ctx).WithError(err).Warn("failed to write cache")
+	}
+}
@@ -0,0 +1,244 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	mock_usecase "github.com/MDx3R/ef-test/internal/usecase/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCache struct {
+	mu     sync.Mutex
+	values map[string][]byte
+	err    error
+}
+
+func newFakeCache() *fakeCache {
+	return &fakeCache{values: make(map[string][]byte)}
+}
+
+func (c *fakeCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.values[key]
+	return value, ok, c.err
+}
+
+func (c *fakeCache) Set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	c.values[key] = value
+	return nil
+}
+
+type cacheLookup struct {
+	cache string
+	hit   bool
+}
+
+type fakeCacheRecorder struct {
+	mu      sync.Mutex
+	lookups []cacheLookup
+}
+
+func (r *fakeCacheRecorder) RecordCacheLookup(cache string, hit bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lookups = append(r.lookups, cacheLookup{cache, hit})
+}
+
+func TestCachingSubscriptionService_GetSubscription_Cached(t *testing.T) {
+	next := mock_usecase.NewMockSubscriptionService(t)
+	recorder := &fakeCacheRecorder{}
+	service := usecase.NewCachingSubscriptionService(next, newFakeCache(), time.Minute, recorder)
+
+	sub := dto.SubscriptionDTO{ID: uuid.New(), ServiceName: "Netflix", Price: 500}
+	next.On("GetSubscription", mock.Anything, sub.ID).Return(sub, nil).Once()
+
+	first, err := service.GetSubscription(context.Background(), sub.ID)
+	require.NoError(t, err)
+	second, err := service.GetSubscription(context.Background(), sub.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, sub, first)
+	assert.Equal(t, sub, second)
+	assert.Equal(t, []cacheLookup{
+		{usecase.CacheSubscription, false},
+		{usecase.CacheSubscription, true},
+	}, recorder.lookups)
+}
+
+func TestCachingSubscriptionService_GetSubscription_ErrorNotCached(t *testing.T) {
+	next := mock_usecase.NewMockSubscriptionService(t)
+	service := usecase.NewCachingSubscriptionService(next, newFakeCache(), time.Minute, nil)
+
+	id := uuid.New()
+	next.On("GetSubscription", mock.Anything, id).Return(dto.SubscriptionDTO{}, usecase.ErrNotFound).Twice()
+
+	_, err := service.GetSubscription(context.Background(), id)
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+	_, err = service.GetSubscription(context.Background(), id)
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+}
+
+func TestCachingSubscriptionService_SeparatesTenants(t *testing.T) {
+	next := mock_usecase.NewMockSubscriptionService(t)
+	service := usecase.NewCachingSubscriptionService(next, newFakeCache(), time.Minute, nil)
+
+	id := uuid.New()
+	next.On("GetSubscription", mock.Anything, id).Return(dto.SubscriptionDTO{ID: id}, nil).Twice()
+
+	_, err := service.GetSubscription(usecase.WithTenant(context.Background(), usecase.Tenant{ID: "a"}), id)
+	require.NoError(t, err)
+	_, err = service.GetSubscription(usecase.WithTenant(context.Background(), usecase.Tenant{ID: "b"}), id)
+	require.NoError(t, err)
+}
+
+func TestCachingSubscriptionService_ChangesInvalidate(t *testing.T) {
+	id := uuid.New()
+	changes := map[string]func(s usecase.SubscriptionService, next *mock_usecase.MockSubscriptionService){
+		"create": func(s usecase.SubscriptionService, next *mock_usecase.MockSubscriptionService) {
+			next.On("CreateSubscription", mock.Anything, mock.Anything).Return(uuid.New(), nil)
+			_, _ = s.CreateSubscription(context.Background(), dto.CreateSubscriptionCommand{})
+		},
+		"update": func(s usecase.SubscriptionService, next *mock_usecase.MockSubscriptionService) {
+			next.On("UpdateSubscription", mock.Anything, id, mock.Anything).Return(nil)
+			_ = s.UpdateSubscription(context.Background(), id, dto.UpdateSubscriptionCommand{})
+		},
+		"patch": func(s usecase.SubscriptionService, next *mock_usecase.MockSubscriptionService) {
+			next.On("PatchSubscription", mock.Anything, id, mock.Anything).Return(dto.SubscriptionDTO{ID: id}, nil)
+			_, _ = s.PatchSubscription(context.Background(), id, dto.PatchSubscriptionCommand{})
+		},
+		"delete": func(s usecase.SubscriptionService, next *mock_usecase.MockSubscriptionService) {
+			next.On("DeleteSubscription", mock.Anything, id).Return(nil)
+			_ = s.DeleteSubscription(context.Background(), id)
+		},
+		"merge": func(s usecase.SubscriptionService, next *mock_usecase.MockSubscriptionService) {
+			next.On("MergeSubscriptions", mock.Anything, mock.Anything).Return(dto.SubscriptionMergeDTO{}, nil)
+			_, _ = s.MergeSubscriptions(context.Background(), dto.MergeSubscriptionsCommand{})
+		},
+		"failed change": func(s usecase.SubscriptionService, next *mock_usecase.MockSubscriptionService) {
+			next.On("DeleteSubscription", mock.Anything, id).Return(errors.New("failure"))
+			_ = s.DeleteSubscription(context.Background(), id)
+		},
+	}
+
+	for name, change := range changes {
+		t.Run(name, func(t *testing.T) {
+			next := mock_usecase.NewMockSubscriptionService(t)
+			service := usecase.NewCachingSubscriptionService(next, newFakeCache(), time.Minute, nil)
+
+			filter := dto.TotalCostFilter{UserID: uuid.New()}
+			next.On("GetSubscription", mock.Anything, id).Return(dto.SubscriptionDTO{ID: id}, nil).Twice()
+			next.On("CalculateTotalCost", mock.Anything, filter).Return(100, nil).Twice()
+
+			_, err := service.GetSubscription(context.Background(), id)
+			require.NoError(t, err)
+			_, err = service.CalculateTotalCost(context.Background(), filter)
+			require.NoError(t, err)
+
+			change(service, next)
+
+			_, err = service.GetSubscription(context.Background(), id)
+			require.NoError(t, err)
+			_, err = service.CalculateTotalCost(context.Background(), filter)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCachingSubscriptionService_CalculateTotalCost_Cached(t *testing.T) {
+	next := mock_usecase.NewMockSubscriptionService(t)
+	recorder := &fakeCacheRecorder{}
+	service := usecase.NewCachingSubscriptionService(next, newFakeCache(), time.Minute, recorder)
+
+	filter := dto.TotalCostFilter{
+		UserID:      uuid.New(),
+		PeriodStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
+	}
+	other := filter
+	other.ServiceName = "Netflix"
+	next.On("CalculateTotalCost", mock.Anything, filter).Return(1200, nil).Once()
+	next.On("CalculateTotalCost", mock.Anything, other).Return(600, nil).Once()
+
+	first, err := service.CalculateTotalCost(context.Background(), filter)
+	require.NoError(t, err)
+	second, err := service.CalculateTotalCost(context.Background(), filter)
+	require.NoError(t, err)
+	third, err := service.CalculateTotalCost(context.Background(), other)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1200, first)
+	assert.Equal(t, 1200, second)
+	assert.Equal(t, 600, third)
+	assert.Equal(t, []cacheLookup{
+		{usecase.CacheTotalCost, false},
+		{usecase.CacheTotalCost, true},
+		{usecase.CacheTotalCost, false},
+	}, recorder.lookups)
+}
+
+func TestCachingSubscriptionService_CalculateTotalCost_CollapsesConcurrentCalls(t *testing.T) {
+	next := mock_usecase.NewMockSubscriptionService(t)
+	service := usecase.NewCachingSubscriptionService(next, newFakeCache(), time.Minute, nil)
+
+	const callers = 5
+	filter := dto.TotalCostFilter{UserID: uuid.New()}
+	release := make(chan struct{})
+	next.On("CalculateTotalCost", mock.Anything, filter).
+		Run(func(mock.Arguments) { <-release }).
+		Return(1200, nil).Once()
+
+	var wg sync.WaitGroup
+	totals := make([]int, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			totals[i], _ = service.CalculateTotalCost(context.Background(), filter)
+		}()
+	}
+	// Callers that miss the computation in flight find the total in the cache.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, []int{1200, 1200, 1200, 1200, 1200}, totals)
+}
+
+func TestCachingSubscriptionService_CacheFailing(t *testing.T) {
+	next := mock_usecase.NewMockSubscriptionService(t)
+	cache := newFakeCache()
+	cache.err = errors.New("unavailable")
+	service := usecase.NewCachingSubscriptionService(next, cache, time.Minute, nil)
+
+	id := uuid.New()
+	filter := dto.TotalCostFilter{UserID: uuid.New()}
+	next.On("GetSubscription", mock.Anything, id).Return(dto.SubscriptionDTO{ID: id}, nil).Twice()
+	next.On("CalculateTotalCost", mock.Anything, filter).Return(100, nil).Twice()
+	next.On("DeleteSubscription", mock.Anything, id).Return(nil)
+
+	for range 2 {
+		sub, err := service.GetSubscription(context.Background(), id)
+		require.NoError(t, err)
+		assert.Equal(t, id, sub.ID)
+
+		total, err := service.CalculateTotalCost(context.Background(), filter)
+		require.NoError(t, err)
+		assert.Equal(t, 100, total)
+	}
+	assert.NoError(t, service.DeleteSubscription(context.Background(), id))
+}
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+type APIKeyDTO struct {
+	ID         uuid.UUID
+	TenantID   string
+	Name       string
+	Prefix     string
+	Scopes     []string
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// IssuedAPIKeyDTO carries the plain key, which is only available right after issuing.
+type IssuedAPIKeyDTO struct {
+	APIKeyDTO
+	Key string
+}
+
+type IssueAPIKeyCommand struct {
+	Name      string
+	Scopes    []string
+	ExpiresAt *time.Time
+}
+
+func FromAPIKey(key *entity.APIKey) APIKeyDTO {
+	return APIKeyDTO{
+		ID:         key.ID(),
+		TenantID:   key.TenantID(),
+		Name:       key.Name(),
+		Prefix:     key.Prefix(),
+		Scopes:     key.Scopes(),
+		CreatedAt:  key.CreatedAt(),
+		ExpiresAt:  key.ExpiresAt(),
+		LastUsedAt: key.LastUsedAt(),
+		RevokedAt:  key.RevokedAt(),
+	}
+}
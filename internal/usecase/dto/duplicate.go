@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// DuplicateGroupDTO lists subscriptions of one user and service
+// whose periods overlap, earliest first.
+type DuplicateGroupDTO struct {
+	ServiceName   string
+	Subscriptions []SubscriptionDTO
+}
+
+type MergeSubscriptionsCommand struct {
+	// TargetID is the subscription that remains; its name and price are kept.
+	TargetID  uuid.UUID
+	SourceIDs []uuid.UUID
+}
+
+// SubscriptionMerge is a merge to store: Target holds the merged values
+// and Sources are removed.
+type SubscriptionMerge struct {
+	Target   *entity.Subscription
+	Sources  []*entity.Subscription
+	MergedBy string
+	MergedAt time.Time
+}
+
+type SubscriptionMergeDTO struct {
+	Subscription SubscriptionDTO
+	MergedIDs    []uuid.UUID
+	MergedBy     string
+	MergedAt     time.Time
+}
@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SubscriptionEventType is the kind of change an event reports.
+type SubscriptionEventType string
+
+const (
+	SubscriptionCreated SubscriptionEventType = "created"
+	SubscriptionUpdated SubscriptionEventType = "updated"
+	SubscriptionDeleted SubscriptionEventType = "deleted"
+	// SubscriptionEventsReset tells watchers that events after the one they
+	// resumed from were discarded, so they have to reload subscriptions.
+	SubscriptionEventsReset SubscriptionEventType = "reset"
+)
+
+// SubscriptionEventDTO is a change of a subscription. Deleted subscriptions
+// are reported with the values they had before removal.
+type SubscriptionEventDTO struct {
+	// ID grows with every event, so watchers can resume after it.
+	ID           uint64
+	Type         SubscriptionEventType
+	TenantID     string
+	Subscription SubscriptionDTO
+	OccurredAt   time.Time
+}
+
+// SubscriptionEventFilter selects the events to watch. Unset fields match every event.
+type SubscriptionEventFilter struct {
+	UserID *uuid.UUID
+	// ServiceName matches regardless of case.
+	ServiceName *string
+	// LastEventID resumes watching after the event, or from now if it is unset.
+	LastEventID *uint64
+}
+
+// Matches reports whether the event is about a subscription the filter selects.
+func (f SubscriptionEventFilter) Matches(event SubscriptionEventDTO) bool {
+	if event.Type == SubscriptionEventsReset {
+		return true
+	}
+	if f.UserID != nil && event.Subscription.UserID != *f.UserID {
+		return false
+	}
+	if f.ServiceName != nil && !strings.EqualFold(event.Subscription.ServiceName, *f.ServiceName) {
+		return false
+	}
+	return true
+}
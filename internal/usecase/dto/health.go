@@ -0,0 +1,22 @@
+package dto
+
+type HealthStatus string
+
+const (
+	HealthStatusUp   HealthStatus = "up"
+	HealthStatusDown HealthStatus = "down"
+)
+
+// ComponentHealth is the result of checking one dependency of the service.
+type ComponentHealth struct {
+	Name   string
+	Status HealthStatus
+	// Error says why the component is down.
+	Error string
+}
+
+// HealthReport is down if any of its components is.
+type HealthReport struct {
+	Status     HealthStatus
+	Components []ComponentHealth
+}
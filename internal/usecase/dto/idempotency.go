@@ -0,0 +1,23 @@
+package dto
+
+import "time"
+
+// IdempotentResponse is the response to a request made with an idempotency key.
+type IdempotentResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// IdempotencyRecord reserves an idempotency key of Owner for a request
+// and, once the request is handled, holds its response.
+type IdempotencyRecord struct {
+	Owner string
+	Key   string
+	// RequestHash tells whether a retry repeats the original request.
+	RequestHash string
+	// Response is nil while the request is being handled.
+	Response  *IdempotentResponse
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
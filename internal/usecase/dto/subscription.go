@@ -14,6 +14,7 @@ type SubscriptionDTO struct {
 	UserID      uuid.UUID
 	StartDate   time.Time
 	EndDate     *time.Time
+	Notes       string
 }
 
 type CreateSubscriptionCommand struct {
@@ -22,6 +23,7 @@ type CreateSubscriptionCommand struct {
 	UserID      uuid.UUID
 	StartDate   time.Time
 	EndDate     *time.Time
+	Notes       string
 }
 
 type UpdateSubscriptionCommand struct {
@@ -29,16 +31,122 @@ type UpdateSubscriptionCommand struct {
 	Price       int
 	StartDate   time.Time
 	EndDate     *time.Time
+	// Notes are kept when nil.
+	Notes *string
 }
 
+// PatchSubscriptionCommand changes only the fields that are set.
+type PatchSubscriptionCommand struct {
+	ServiceName *string
+	Price       *int
+	StartDate   *time.Time
+	EndDate     *time.Time
+	// ClearEndDate makes the subscription open-ended, EndDate is ignored then.
+	ClearEndDate bool
+	Notes        *string
+}
+
+// Cursor is a position in the (start_date, id) ordering of subscriptions.
+type Cursor struct {
+	StartDate time.Time
+	ID        uuid.UUID
+}
+
+// SortField is a field subscriptions can be ordered by.
+type SortField string
+
+const (
+	SortByServiceName SortField = "service_name"
+	SortByPrice       SortField = "price"
+	SortByStartDate   SortField = "start_date"
+	SortByEndDate     SortField = "end_date"
+)
+
+var sortFields = map[SortField]struct{}{
+	SortByServiceName: {},
+	SortByPrice:       {},
+	SortByStartDate:   {},
+	SortByEndDate:     {},
+}
+
+func (f SortField) IsValid() bool {
+	_, ok := sortFields[f]
+	return ok
+}
+
+// SortOrder orders subscriptions by a single field.
+// Subscriptions without end date are treated as ending last.
+type SortOrder struct {
+	Field SortField
+	Desc  bool
+}
+
+// SubscriptionFilter selects subscriptions matching every set condition.
 type SubscriptionFilter struct {
 	UserID      *uuid.UUID
 	ServiceName *string
 	StartDate   *time.Time
-	EndDate     *time.Time
+	// EndDate matches subscriptions ending on or before it, or without end date.
+	EndDate *time.Time
+
+	// ServiceNames matches any of the listed service names exactly.
+	ServiceNames []string
+	// ServiceNamePrefix matches service names starting with it, ignoring case.
+	ServiceNamePrefix *string
+
+	// PriceMin and PriceMax are inclusive bounds.
+	PriceMin *int
+	PriceMax *int
 
+	// ActiveAt matches subscriptions active on it, or on any day from it to
+	// ActiveUntil when that is set. The days are compared as stored.
+	ActiveAt    *time.Time
+	ActiveUntil *time.Time
+	// ExpiringBefore matches subscriptions with an end date strictly before it.
+	ExpiringBefore *time.Time
+	// OpenEnded matches subscriptions without end date when true, and with one when false.
+	OpenEnded *bool
+
+	// Sort overrides the default (start_date, id) order.
+	// The id is always appended as the last key to keep the order stable.
+	Sort []SortOrder
+
+	// After switches to keyset pagination: only subscriptions ordered
+	// after the cursor are returned and Page is ignored.
+	// Keyset pagination is only supported with the default order.
+	After    *Cursor
 	Page     int
 	PageSize int
+
+	// IncludeTotal requests the number of subscriptions matching the filter,
+	// regardless of pagination. It costs an extra COUNT query.
+	IncludeTotal bool
+}
+
+// SubscriptionPage is a page of subscriptions ordered by (start_date, id)
+// unless the filter asks for another order.
+type SubscriptionPage struct {
+	Subscriptions []*entity.Subscription
+	HasMore       bool
+	// Total is set only when the filter requests it.
+	Total *int
+}
+
+type SubscriptionPageDTO struct {
+	Items []SubscriptionDTO
+	// NextCursor points at the last item and is set only when HasMore is true
+	// and the default order is used.
+	NextCursor *Cursor
+	HasMore    bool
+	Total      *int
+}
+
+// SubscriptionSearchFilter selects subscriptions whose service name or notes
+// match Query, fuzzily where the backend supports it.
+type SubscriptionSearchFilter struct {
+	Query  string
+	UserID *uuid.UUID
+	Limit  int
 }
 
 type TotalCostFilter struct {
@@ -48,6 +156,10 @@ type TotalCostFilter struct {
 	PeriodEnd   time.Time
 }
 
+func CursorOf(sub SubscriptionDTO) Cursor {
+	return Cursor{StartDate: sub.StartDate, ID: sub.ID}
+}
+
 func FromSubscription(sub *entity.Subscription) SubscriptionDTO {
 	return SubscriptionDTO{
 		ID:          sub.ID(),
@@ -56,5 +168,6 @@ func FromSubscription(sub *entity.Subscription) SubscriptionDTO {
 		UserID:      sub.UserID(),
 		StartDate:   sub.StartDate(),
 		EndDate:     sub.EndDate(),
+		Notes:       sub.Notes(),
 	}
 }
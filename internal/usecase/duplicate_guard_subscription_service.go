@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	"github.com/google/uuid"
+)
+
+// duplicateGuardSubscriptionService rejects new subscriptions that duplicate
+// existing ones. The check and the insert are not atomic, so concurrent
+// requests may still create duplicates; FindDuplicates reports them.
+type duplicateGuardSubscriptionService struct {
+	SubscriptionService
+	subRepo SubscriptionRepository
+}
+
+func NewDuplicateGuardSubscriptionService(next SubscriptionService, subRepo SubscriptionRepository) SubscriptionService {
+	return &duplicateGuardSubscriptionService{SubscriptionService: next, subRepo: subRepo}
+}
+
+func (s *duplicateGuardSubscriptionService) CreateSubscription(ctx context.Context, request dto.CreateSubscriptionCommand) (uuid.UUID, error) {
+	candidate, err := entity.NewSubscription(
+		request.ServiceName,
+		request.UserID,
+		request.Price,
+		request.StartDate,
+		request.EndDate,
+	)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	existing, err := s.subRepo.ListByUser(ctx, request.UserID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	var duplicates []uuid.UUID
+	for _, sub := range existing {
+		if candidate.IsDuplicateOf(sub) {
+			duplicates = append(duplicates, sub.ID())
+		}
+	}
+	if len(duplicates) > 0 {
+		return uuid.Nil, &DuplicateSubscriptionError{IDs: duplicates}
+	}
+
+	return s.SubscriptionService.CreateSubscription(ctx, request)
+}
+
+// DuplicateSubscriptionError lists existing subscriptions a new one would duplicate.
+type DuplicateSubscriptionError struct {
+	IDs []uuid.UUID
+}
+
+func (e *DuplicateSubscriptionError) Error() string {
+	return fmt.Sprintf("subscription duplicates %d existing one(s)", len(e.IDs))
+}
+
+func (e *DuplicateSubscriptionError) Unwrap() error {
+	return ErrConflict
+}
@@ -0,0 +1,64 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	mock_usecase "github.com/MDx3R/ef-test/internal/usecase/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupDuplicateGuardService(t *testing.T) (*mock_usecase.MockSubscriptionRepository, usecase.SubscriptionService) {
+	mockRepo := mock_usecase.NewMockSubscriptionRepository(t)
+	service := usecase.NewDuplicateGuardSubscriptionService(usecase.NewSubscriptionService(mockRepo), mockRepo)
+	return mockRepo, service
+}
+
+func TestDuplicateGuardSubscriptionService_CreateSubscription_Duplicate(t *testing.T) {
+	mockRepo, service := setupDuplicateGuardService(t)
+
+	userID := uuid.New()
+	existing := makeUserSubscription(t, "Yandex Plus", userID, month(2025, 1), nil)
+
+	mockRepo.On("ListByUser", mock.Anything, userID).Return([]*entity.Subscription{existing}, nil)
+
+	_, err := service.CreateSubscription(context.Background(), dto.CreateSubscriptionCommand{
+		ServiceName: "yandex plus",
+		Price:       100,
+		UserID:      userID,
+		StartDate:   month(2025, 6),
+	})
+
+	var duplicate *usecase.DuplicateSubscriptionError
+	require.ErrorAs(t, err, &duplicate)
+	assert.ErrorIs(t, err, usecase.ErrConflict)
+	assert.Equal(t, []uuid.UUID{existing.ID()}, duplicate.IDs)
+	mockRepo.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+}
+
+func TestDuplicateGuardSubscriptionService_CreateSubscription_NoOverlap(t *testing.T) {
+	mockRepo, service := setupDuplicateGuardService(t)
+
+	userID := uuid.New()
+	existing := makeUserSubscription(t, "Netflix", userID, month(2025, 1), monthPtr(2025, 3))
+
+	mockRepo.On("ListByUser", mock.Anything, userID).Return([]*entity.Subscription{existing}, nil)
+	mockRepo.On("Add", mock.Anything, mock.Anything).Return(nil)
+
+	id, err := service.CreateSubscription(context.Background(), dto.CreateSubscriptionCommand{
+		ServiceName: "Netflix",
+		Price:       100,
+		UserID:      userID,
+		StartDate:   month(2025, 4),
+	})
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, id)
+	mockRepo.AssertExpectations(t)
+}
@@ -5,6 +5,12 @@ import (
 )
 
 var (
-	ErrNotFound   = fmt.Errorf("not found")
-	ErrRepository = fmt.Errorf("repository error")
+	ErrNotFound     = fmt.Errorf("not found")
+	ErrRepository   = fmt.Errorf("repository error")
+	ErrForbidden    = fmt.Errorf("forbidden")
+	ErrUnauthorized = fmt.Errorf("unauthorized")
+	ErrConflict     = fmt.Errorf("conflict")
+
+	ErrIdempotencyKeyReused    = fmt.Errorf("idempotency key was used with a different request")
+	ErrIdempotencyKeyInProcess = fmt.Errorf("request with the idempotency key is still being processed")
 )
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+)
+
+// ServerComponent is the component reported down while the service drains.
+const ServerComponent = "server"
+
+// ComponentUnavailable is the error reported for a failing component
+// whose actual error is not exposed.
+const ComponentUnavailable = "unavailable"
+
+// HealthCheck tells whether a dependency of the service works.
+type HealthCheck interface {
+	Name() string
+	// Check returns nil if the dependency works.
+	Check(ctx context.Context) error
+}
+
+// HealthService reports whether the service is alive and ready to serve requests.
+type HealthService interface {
+	// Live reports that the process runs. It doesn't check dependencies,
+	// so that an unavailable database doesn't get the service restarted.
+	Live(ctx context.Context) dto.HealthReport
+	// Ready runs the checks concurrently and reports every component.
+	Ready(ctx context.Context) dto.HealthReport
+	// Drain makes the service not ready for good, so that load balancers
+	// stop routing requests to it before it shuts down.
+	Drain()
+}
+
+type healthService struct {
+	checks       []HealthCheck
+	timeout      time.Duration
+	exposeErrors bool
+	draining     atomic.Bool
+}
+
+// NewHealthService returns a service whose checks each get timeout to finish.
+// Errors of the checks name hosts and addresses of dependencies, so they are
+// only logged and reported as ComponentUnavailable unless exposeErrors is set.
+func NewHealthService(timeout time.Duration, exposeErrors bool, checks ...HealthCheck) HealthService {
+	return &healthService{checks: checks, timeout: timeout, exposeErrors: exposeErrors}
+}
+
+func (s *healthService) Live(ctx context.Context) dto.HealthReport {
+	return dto.HealthReport{Status: dto.HealthStatusUp, Components: []dto.ComponentHealth{}}
+}
+
+func (s *healthService) Ready(ctx context.Context) dto.HealthReport {
+	// Dependencies are about to be closed, so they are not checked.
+	if s.draining.Load() {
+		return dto.HealthReport{
+			Status: dto.HealthStatusDown,
+			Components: []dto.ComponentHealth{{
+				Name:   ServerComponent,
+				Status: dto.HealthStatusDown,
+				Error:  "server is shutting down",
+			}},
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	components := make([]dto.ComponentHealth, len(s.checks))
+	var wg sync.WaitGroup
+	for i, check := range s.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			components[i] = s.runCheck(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := dto.HealthReport{Status: dto.HealthStatusUp, Components: components}
+	for _, component := range components {
+		if component.Status == dto.HealthStatusDown {
+			report.Status = dto.HealthStatusDown
+		}
+	}
+	return report
+}
+
+func (s *healthService) Drain() {
+	s.draining.Store(true)
+}
+
+func (s *healthService) runCheck(ctx context.Context, check HealthCheck) dto.ComponentHealth {
+	component := dto.ComponentHealth{Name: check.Name(), Status: dto.HealthStatusUp}
+	if err := check.Check(ctx); err != nil {
+		requestLogger(ctx).WithField("component", component.Name).WithError(err).Warn("health check failed")
+		component.Status = dto.HealthStatusDown
+		component.Error = ComponentUnavailable
+		if s.exposeErrors {
+			component.Error = err.Error()
+		}
+	}
+	return component
+}
@@ -0,0 +1,104 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	mock_usecase "github.com/MDx3R/ef-test/internal/usecase/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newMockHealthCheck(t *testing.T, name string, err error) *mock_usecase.MockHealthCheck {
+	check := mock_usecase.NewMockHealthCheck(t)
+	check.On("Name").Return(name).Maybe()
+	check.On("Check", mock.Anything).Return(err).Maybe()
+	return check
+}
+
+func TestHealthService_Live(t *testing.T) {
+	failing := newMockHealthCheck(t, "database", errors.New("connection refused"))
+	service := usecase.NewHealthService(time.Second, false, failing)
+
+	report := service.Live(context.Background())
+
+	assert.Equal(t, dto.HealthStatusUp, report.Status)
+	assert.Empty(t, report.Components)
+	failing.AssertNotCalled(t, "Check", mock.Anything)
+}
+
+func TestHealthService_Ready(t *testing.T) {
+	service := usecase.NewHealthService(time.Second, false,
+		newMockHealthCheck(t, "database", nil),
+		newMockHealthCheck(t, "migrations", nil),
+	)
+
+	report := service.Ready(context.Background())
+
+	assert.Equal(t, dto.HealthStatusUp, report.Status)
+	assert.Equal(t, []dto.ComponentHealth{
+		{Name: "database", Status: dto.HealthStatusUp},
+		{Name: "migrations", Status: dto.HealthStatusUp},
+	}, report.Components)
+}
+
+func TestHealthService_Ready_ComponentDown(t *testing.T) {
+	service := usecase.NewHealthService(time.Second, false,
+		newMockHealthCheck(t, "database", nil),
+		newMockHealthCheck(t, "migrations", errors.New("schema version is 6, want 7")),
+	)
+
+	report := service.Ready(context.Background())
+
+	assert.Equal(t, dto.HealthStatusDown, report.Status)
+	assert.Equal(t, []dto.ComponentHealth{
+		{Name: "database", Status: dto.HealthStatusUp},
+		{Name: "migrations", Status: dto.HealthStatusDown, Error: usecase.ComponentUnavailable},
+	}, report.Components)
+}
+
+func TestHealthService_Ready_ExposesErrors(t *testing.T) {
+	service := usecase.NewHealthService(time.Second, true,
+		newMockHealthCheck(t, "database", errors.New("dial tcp db.internal:5432: connection refused")),
+	)
+
+	report := service.Ready(context.Background())
+
+	assert.Equal(t, dto.HealthStatusDown, report.Status)
+	assert.Equal(t, []dto.ComponentHealth{
+		{Name: "database", Status: dto.HealthStatusDown, Error: "dial tcp db.internal:5432: connection refused"},
+	}, report.Components)
+}
+
+func TestHealthService_Ready_Timeout(t *testing.T) {
+	check := mock_usecase.NewMockHealthCheck(t)
+	check.On("Name").Return("database")
+	check.On("Check", mock.Anything).Return(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	service := usecase.NewHealthService(10*time.Millisecond, true, check)
+
+	report := service.Ready(context.Background())
+
+	assert.Equal(t, dto.HealthStatusDown, report.Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Components[0].Error)
+}
+
+func TestHealthService_Ready_Draining(t *testing.T) {
+	check := newMockHealthCheck(t, "database", nil)
+	service := usecase.NewHealthService(time.Second, false, check)
+
+	service.Drain()
+	report := service.Ready(context.Background())
+
+	assert.Equal(t, dto.HealthStatusDown, report.Status)
+	assert.Equal(t, []dto.ComponentHealth{
+		{Name: usecase.ServerComponent, Status: dto.HealthStatusDown, Error: "server is shutting down"},
+	}, report.Components)
+	check.AssertNotCalled(t, "Check", mock.Anything)
+}
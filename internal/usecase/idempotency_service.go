@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+)
+
+// IdempotencyService makes retries of a request with the same idempotency key
+// return the response of the first one. Keys are scoped to the Principal and
+// Tenant in ctx, so callers can't replay each other's responses.
+type IdempotencyService interface {
+	// Begin reserves key for a request and returns nil, or returns the response
+	// of a finished request with the key. It returns ErrIdempotencyKeyReused
+	// if that request differs and ErrIdempotencyKeyInProcess if it isn't finished.
+	Begin(ctx context.Context, key, requestHash string) (*dto.IdempotentResponse, error)
+	// Complete stores the response of a request begun with key.
+	Complete(ctx context.Context, key string, response dto.IdempotentResponse) error
+	// Abandon frees key of a request that failed, so that it can be retried.
+	Abandon(ctx context.Context, key string) error
+	// PurgeExpired removes the records of keys whose TTL has passed.
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+type idempotencyService struct {
+	repo IdempotencyRepository
+	// ttl is how long responses are replayed.
+	ttl time.Duration
+	// lease is how long a key is reserved before its request is finished,
+	// so that keys of requests that never finish are freed.
+	lease time.Duration
+}
+
+func NewIdempotencyService(repo IdempotencyRepository, ttl, lease time.Duration) IdempotencyService {
+	return &idempotencyService{repo: repo, ttl: ttl, lease: lease}
+}
+
+func (s *idempotencyService) Begin(ctx context.Context, key, requestHash string) (*dto.IdempotentResponse, error) {
+	now := time.Now().UTC()
+
+	existing, reserved, err := s.repo.Reserve(ctx, dto.IdempotencyRecord{
+		Owner:       owner(ctx),
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.lease),
+	})
+	switch {
+	case err != nil:
+		return nil, err
+	case reserved:
+		return nil, nil
+	case existing.RequestHash != requestHash:
+		return nil, ErrIdempotencyKeyReused
+	case existing.Response == nil:
+		return nil, ErrIdempotencyKeyInProcess
+	default:
+		return existing.Response, nil
+	}
+}
+
+func (s *idempotencyService) Complete(ctx context.Context, key string, response dto.IdempotentResponse) error {
+	return s.repo.Complete(ctx, owner(ctx), key, response, time.Now().UTC().Add(s.ttl))
+}
+
+func (s *idempotencyService) Abandon(ctx context.Context, key string) error {
+	return s.repo.Release(ctx, owner(ctx), key)
+}
+
+func (s *idempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	return s.repo.DeleteExpired(ctx, time.Now().UTC())
+}
+
+// owner returns the subject of the principal in ctx.
+// Anonymous callers of a tenant share their keys.
+func owner(ctx context.Context) string {
+	p, _ := PrincipalFrom(ctx)
+	return p.Subject
+}
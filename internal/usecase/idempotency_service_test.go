@@ -0,0 +1,105 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	mock_usecase "github.com/MDx3R/ef-test/internal/usecase/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupIdempotencyService(t *testing.T) (*mock_usecase.MockIdempotencyRepository, usecase.IdempotencyService) {
+	mockRepo := mock_usecase.NewMockIdempotencyRepository(t)
+	service := usecase.NewIdempotencyService(mockRepo, time.Hour, time.Minute)
+	return mockRepo, service
+}
+
+func TestIdempotencyService_Begin_Reserves(t *testing.T) {
+	mockRepo, service := setupIdempotencyService(t)
+
+	ctx := usecase.WithPrincipal(context.Background(), usecase.Principal{Subject: "user"})
+
+	var reserved dto.IdempotencyRecord
+	mockRepo.On("Reserve", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { reserved = args.Get(1).(dto.IdempotencyRecord) }).
+		Return(dto.IdempotencyRecord{}, true, nil)
+
+	replay, err := service.Begin(ctx, "key", "hash")
+
+	require.NoError(t, err)
+	assert.Nil(t, replay)
+	assert.Equal(t, "user", reserved.Owner)
+	assert.Equal(t, "key", reserved.Key)
+	assert.Equal(t, "hash", reserved.RequestHash)
+	assert.Nil(t, reserved.Response)
+	// Reservations are leased briefly, responses are kept for the TTL.
+	assert.Equal(t, time.Minute, reserved.ExpiresAt.Sub(reserved.CreatedAt))
+}
+
+func TestIdempotencyService_Begin_Replays(t *testing.T) {
+	mockRepo, service := setupIdempotencyService(t)
+
+	response := &dto.IdempotentResponse{StatusCode: 201, ContentType: "application/json", Body: []byte(`{}`)}
+
+	mockRepo.On("Reserve", mock.Anything, mock.Anything).
+		Return(dto.IdempotencyRecord{RequestHash: "hash", Response: response}, false, nil)
+
+	replay, err := service.Begin(context.Background(), "key", "hash")
+
+	require.NoError(t, err)
+	assert.Equal(t, response, replay)
+}
+
+func TestIdempotencyService_Begin_DifferentRequest(t *testing.T) {
+	mockRepo, service := setupIdempotencyService(t)
+
+	mockRepo.On("Reserve", mock.Anything, mock.Anything).
+		Return(dto.IdempotencyRecord{RequestHash: "other", Response: &dto.IdempotentResponse{StatusCode: 201}}, false, nil)
+
+	_, err := service.Begin(context.Background(), "key", "hash")
+
+	assert.ErrorIs(t, err, usecase.ErrIdempotencyKeyReused)
+}
+
+func TestIdempotencyService_Begin_InProcess(t *testing.T) {
+	mockRepo, service := setupIdempotencyService(t)
+
+	mockRepo.On("Reserve", mock.Anything, mock.Anything).
+		Return(dto.IdempotencyRecord{RequestHash: "hash"}, false, nil)
+
+	_, err := service.Begin(context.Background(), "key", "hash")
+
+	assert.ErrorIs(t, err, usecase.ErrIdempotencyKeyInProcess)
+}
+
+func TestIdempotencyService_Begin_Error(t *testing.T) {
+	mockRepo, service := setupIdempotencyService(t)
+
+	mockRepo.On("Reserve", mock.Anything, mock.Anything).
+		Return(dto.IdempotencyRecord{}, false, usecase.ErrRepository)
+
+	_, err := service.Begin(context.Background(), "key", "hash")
+
+	assert.ErrorIs(t, err, usecase.ErrRepository)
+}
+
+func TestIdempotencyService_CompleteAndAbandon_ScopedToPrincipal(t *testing.T) {
+	mockRepo, service := setupIdempotencyService(t)
+
+	ctx := usecase.WithPrincipal(context.Background(), usecase.Principal{Subject: "user"})
+	response := dto.IdempotentResponse{StatusCode: 201}
+
+	mockRepo.On("Complete", mock.Anything, "user", "key-1", response, mock.MatchedBy(func(expiresAt time.Time) bool {
+		return time.Until(expiresAt) > 59*time.Minute
+	})).Return(nil)
+	mockRepo.On("Release", mock.Anything, "user", "key-2").Return(nil)
+
+	assert.NoError(t, service.Complete(ctx, "key-1", response))
+	assert.NoError(t, service.Abandon(ctx, "key-2"))
+	mockRepo.AssertExpectations(t)
+}
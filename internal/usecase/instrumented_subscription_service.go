@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	"github.com/google/uuid"
+)
+
+// Operations counted by the instrumented subscription service.
+const (
+	OperationCreate = "create"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+	OperationMerge  = "merge"
+)
+
+// OperationRecorder counts subscription changes, such as a metrics registry.
+type OperationRecorder interface {
+	RecordOperation(operation string, err error)
+}
+
+// instrumentedSubscriptionService records every change passed on to next.
+// Reads are not counted, the HTTP metrics already cover them.
+type instrumentedSubscriptionService struct {
+	SubscriptionService
+	recorder OperationRecorder
+}
+
+func NewInstrumentedSubscriptionService(next SubscriptionService, recorder OperationRecorder) SubscriptionService {
+	return &instrumentedSubscriptionService{SubscriptionService: next, recorder: recorder}
+}
+
+func (s *instrumentedSubscriptionService) CreateSubscription(ctx context.Context, request dto.CreateSubscriptionCommand) (uuid.UUID, error) {
+	id, err := s.SubscriptionService.CreateSubscription(ctx, request)
+	s.recorder.RecordOperation(OperationCreate, err)
+	return id, err
+}
+
+func (s *instrumentedSubscriptionService) UpdateSubscription(ctx context.Context, id uuid.UUID, request dto.UpdateSubscriptionCommand) error {
+	err := s.SubscriptionService.UpdateSubscription(ctx, id, request)
+	s.recorder.RecordOperation(OperationUpdate, err)
+	return err
+}
+
+func (s *instrumentedSubscriptionService) PatchSubscription(ctx context.Context, id uuid.UUID, request dto.PatchSubscriptionCommand) (dto.SubscriptionDTO, error) {
+	sub, err := s.SubscriptionService.PatchSubscription(ctx, id, request)
+	s.recorder.RecordOperation(OperationUpdate, err)
+	return sub, err
+}
+
+func (s *instrumentedSubscriptionService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	err := s.SubscriptionService.DeleteSubscription(ctx, id)
+	s.recorder.RecordOperation(OperationDelete, err)
+	return err
+}
+
+func (s *instrumentedSubscriptionService) MergeSubscriptions(ctx context.Context, request dto.MergeSubscriptionsCommand) (dto.SubscriptionMergeDTO, error) {
+	merged, err := s.SubscriptionService.MergeSubscriptions(ctx, request)
+	s.recorder.RecordOperation(OperationMerge, err)
+	return merged, err
+}
@@ -0,0 +1,70 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	mock_usecase "github.com/MDx3R/ef-test/internal/usecase/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type recordedOperation struct {
+	operation string
+	failed    bool
+}
+
+type fakeRecorder struct {
+	operations []recordedOperation
+}
+
+func (r *fakeRecorder) RecordOperation(operation string, err error) {
+	r.operations = append(r.operations, recordedOperation{operation, err != nil})
+}
+
+func TestInstrumentedSubscriptionService_RecordsChanges(t *testing.T) {
+	next := mock_usecase.NewMockSubscriptionService(t)
+	recorder := &fakeRecorder{}
+	service := usecase.NewInstrumentedSubscriptionService(next, recorder)
+
+	id := uuid.New()
+	next.On("CreateSubscription", mock.Anything, mock.Anything).Return(id, nil)
+	next.On("UpdateSubscription", mock.Anything, id, mock.Anything).Return(usecase.ErrNotFound)
+	next.On("PatchSubscription", mock.Anything, id, mock.Anything).Return(dto.SubscriptionDTO{ID: id}, nil)
+	next.On("DeleteSubscription", mock.Anything, id).Return(nil)
+	next.On("MergeSubscriptions", mock.Anything, mock.Anything).Return(dto.SubscriptionMergeDTO{}, errors.New("failure"))
+
+	ctx := context.Background()
+	_, _ = service.CreateSubscription(ctx, dto.CreateSubscriptionCommand{})
+	_ = service.UpdateSubscription(ctx, id, dto.UpdateSubscriptionCommand{})
+	_, _ = service.PatchSubscription(ctx, id, dto.PatchSubscriptionCommand{})
+	_ = service.DeleteSubscription(ctx, id)
+	_, _ = service.MergeSubscriptions(ctx, dto.MergeSubscriptionsCommand{})
+
+	assert.Equal(t, []recordedOperation{
+		{usecase.OperationCreate, false},
+		{usecase.OperationUpdate, true},
+		{usecase.OperationUpdate, false},
+		{usecase.OperationDelete, false},
+		{usecase.OperationMerge, true},
+	}, recorder.operations)
+}
+
+func TestInstrumentedSubscriptionService_SkipsReads(t *testing.T) {
+	next := mock_usecase.NewMockSubscriptionService(t)
+	recorder := &fakeRecorder{}
+	service := usecase.NewInstrumentedSubscriptionService(next, recorder)
+
+	id := uuid.New()
+	next.On("GetSubscription", mock.Anything, id).Return(dto.SubscriptionDTO{ID: id}, nil)
+
+	sub, err := service.GetSubscription(context.Background(), id)
+
+	assert.NoError(t, err)
+	assert.Equal(t, id, sub.ID)
+	assert.Empty(t, recorder.operations)
+}
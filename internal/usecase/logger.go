@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+)
+
+type loggerKey struct{}
+
+// defaultLogger logs outside of requests, such as in background jobs.
+var defaultLogger atomic.Pointer[logrus.Logger]
+
+// SetLogger makes work outside of requests log with the configured logger
+// of the application. Until it is set, the standard logger is used.
+func SetLogger(logger *logrus.Logger) {
+	defaultLogger.Store(logger)
+}
+
+// WithLogger returns a copy of ctx carrying the log entry of the request,
+// so that every line logged for the request can be correlated.
+func WithLogger(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, loggerKey{}, entry)
+}
+
+// LoggerFrom returns the log entry of the request in ctx. The entry is bound
+// to ctx, so it also names the caller, the tenant and the trace known by then.
+func LoggerFrom(ctx context.Context) (*logrus.Entry, bool) {
+	entry, ok := ctx.Value(loggerKey{}).(*logrus.Entry)
+	if !ok {
+		return nil, false
+	}
+
+	entry = entry.WithContext(ctx)
+	if p, ok := PrincipalFrom(ctx); ok && p.Subject != "" {
+		entry = entry.WithField("user_id", p.Subject)
+	}
+	if t, ok := ctx.Value(tenantKey{}).(Tenant); ok {
+		entry = entry.WithField("tenant", t.ID)
+	}
+	return entry, true
+}
+
+// requestLogger returns the log entry of the request in ctx,
+// falling back to the logger of SetLogger outside of requests.
+func requestLogger(ctx context.Context) *logrus.Entry {
+	if entry, ok := LoggerFrom(ctx); ok {
+		return entry
+	}
+	logger := defaultLogger.Load()
+	if logger == nil {
+		logger = logrus.StandardLogger()
+	}
+	return logrus.NewEntry(logger).WithContext(ctx)
+}
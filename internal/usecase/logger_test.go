@@ -0,0 +1,64 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/usecase"
+	mock_usecase "github.com/MDx3R/ef-test/internal/usecase/mocks"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggerFrom_None(t *testing.T) {
+	entry, ok := usecase.LoggerFrom(context.Background())
+
+	assert.False(t, ok)
+	assert.Nil(t, entry)
+}
+
+func TestLoggerFrom_NamesCallerAndTenant(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := usecase.WithLogger(context.Background(), logger.WithField("request_id", "req-1"))
+	ctx = usecase.WithPrincipal(ctx, usecase.Principal{Subject: "user"})
+	ctx = usecase.WithTenant(ctx, usecase.Tenant{ID: "retail"})
+
+	entry, ok := usecase.LoggerFrom(ctx)
+
+	require.True(t, ok)
+	assert.Equal(t, logrus.Fields{"request_id": "req-1", "user_id": "user", "tenant": "retail"}, entry.Data)
+	assert.Equal(t, ctx, entry.Context)
+}
+
+func TestSetLogger_LogsOutsideOfRequests(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	usecase.SetLogger(logger)
+	t.Cleanup(func() { usecase.SetLogger(nil) })
+
+	cache := newFakeCache()
+	cache.err = errors.New("unavailable")
+	next := mock_usecase.NewMockSubscriptionService(t)
+	next.On("DeleteSubscription", mock.Anything, mock.Anything).Return(nil)
+	service := usecase.NewCachingSubscriptionService(next, cache, time.Minute, nil)
+
+	require.NoError(t, service.DeleteSubscription(context.Background(), uuid.New()))
+
+	require.NotNil(t, hook.LastEntry())
+	assert.Equal(t, "failed to renew cache generation", hook.LastEntry().Message)
+}
+
+func TestLoggerFrom_Anonymous(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	ctx := usecase.WithLogger(context.Background(), logger.WithField("request_id", "req-1"))
+
+	entry, ok := usecase.LoggerFrom(ctx)
+
+	require.True(t, ok)
+	assert.Equal(t, logrus.Fields{"request_id": "req-1"}, entry.Data)
+}
@@ -5,6 +5,7 @@
 package mock_usecase
 
 import (
+	"context"
 	"github.com/MDx3R/ef-test/internal/domain/entity"
 	"github.com/MDx3R/ef-test/internal/usecase/dto"
 	"github.com/google/uuid"
@@ -39,16 +40,16 @@ func (_m *MockSubscriptionRepository) EXPECT() *MockSubscriptionRepository_Expec
 }
 
 // Add provides a mock function for the type MockSubscriptionRepository
-func (_mock *MockSubscriptionRepository) Add(sub *entity.Subscription) error {
-	ret := _mock.Called(sub)
+func (_mock *MockSubscriptionRepository) Add(ctx context.Context, sub *entity.Subscription) error {
+	ret := _mock.Called(ctx, sub)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Add")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(*entity.Subscription) error); ok {
-		r0 = returnFunc(sub)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.Subscription) error); ok {
+		r0 = returnFunc(ctx, sub)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -61,19 +62,25 @@ type MockSubscriptionRepository_Add_Call struct {
 }
 
 // Add is a helper method to define mock.On call
+//   - ctx context.Context
 //   - sub *entity.Subscription
-func (_e *MockSubscriptionRepository_Expecter) Add(sub interface{}) *MockSubscriptionRepository_Add_Call {
-	return &MockSubscriptionRepository_Add_Call{Call: _e.mock.On("Add", sub)}
+func (_e *MockSubscriptionRepository_Expecter) Add(ctx interface{}, sub interface{}) *MockSubscriptionRepository_Add_Call {
+	return &MockSubscriptionRepository_Add_Call{Call: _e.mock.On("Add", ctx, sub)}
 }
 
-func (_c *MockSubscriptionRepository_Add_Call) Run(run func(sub *entity.Subscription)) *MockSubscriptionRepository_Add_Call {
+func (_c *MockSubscriptionRepository_Add_Call) Run(run func(ctx context.Context, sub *entity.Subscription)) *MockSubscriptionRepository_Add_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *entity.Subscription
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*entity.Subscription)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.Subscription
+		if args[1] != nil {
+			arg1 = args[1].(*entity.Subscription)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -84,14 +91,14 @@ func (_c *MockSubscriptionRepository_Add_Call) Return(err error) *MockSubscripti
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_Add_Call) RunAndReturn(run func(sub *entity.Subscription) error) *MockSubscriptionRepository_Add_Call {
+func (_c *MockSubscriptionRepository_Add_Call) RunAndReturn(run func(ctx context.Context, sub *entity.Subscription) error) *MockSubscriptionRepository_Add_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // CalculateTotalCost provides a mock function for the type MockSubscriptionRepository
-func (_mock *MockSubscriptionRepository) CalculateTotalCost(filter dto.TotalCostFilter) (int, error) {
-	ret := _mock.Called(filter)
+func (_mock *MockSubscriptionRepository) CalculateTotalCost(ctx context.Context, filter dto.TotalCostFilter) (int, error) {
+	ret := _mock.Called(ctx, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CalculateTotalCost")
@@ -99,16 +106,16 @@ func (_mock *MockSubscriptionRepository) CalculateTotalCost(filter dto.TotalCost
 
 	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(dto.TotalCostFilter) (int, error)); ok {
-		return returnFunc(filter)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.TotalCostFilter) (int, error)); ok {
+		return returnFunc(ctx, filter)
 	}
-	if returnFunc, ok := ret.Get(0).(func(dto.TotalCostFilter) int); ok {
-		r0 = returnFunc(filter)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.TotalCostFilter) int); ok {
+		r0 = returnFunc(ctx, filter)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(1).(func(dto.TotalCostFilter) error); ok {
-		r1 = returnFunc(filter)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dto.TotalCostFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -121,19 +128,25 @@ type MockSubscriptionRepository_CalculateTotalCost_Call struct {
 }
 
 // CalculateTotalCost is a helper method to define mock.On call
+//   - ctx context.Context
 //   - filter dto.TotalCostFilter
-func (_e *MockSubscriptionRepository_Expecter) CalculateTotalCost(filter interface{}) *MockSubscriptionRepository_CalculateTotalCost_Call {
-	return &MockSubscriptionRepository_CalculateTotalCost_Call{Call: _e.mock.On("CalculateTotalCost", filter)}
+func (_e *MockSubscriptionRepository_Expecter) CalculateTotalCost(ctx interface{}, filter interface{}) *MockSubscriptionRepository_CalculateTotalCost_Call {
+	return &MockSubscriptionRepository_CalculateTotalCost_Call{Call: _e.mock.On("CalculateTotalCost", ctx, filter)}
 }
 
-func (_c *MockSubscriptionRepository_CalculateTotalCost_Call) Run(run func(filter dto.TotalCostFilter)) *MockSubscriptionRepository_CalculateTotalCost_Call {
+func (_c *MockSubscriptionRepository_CalculateTotalCost_Call) Run(run func(ctx context.Context, filter dto.TotalCostFilter)) *MockSubscriptionRepository_CalculateTotalCost_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 dto.TotalCostFilter
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(dto.TotalCostFilter)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 dto.TotalCostFilter
+		if args[1] != nil {
+			arg1 = args[1].(dto.TotalCostFilter)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -144,22 +157,22 @@ func (_c *MockSubscriptionRepository_CalculateTotalCost_Call) Return(n int, err
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_CalculateTotalCost_Call) RunAndReturn(run func(filter dto.TotalCostFilter) (int, error)) *MockSubscriptionRepository_CalculateTotalCost_Call {
+func (_c *MockSubscriptionRepository_CalculateTotalCost_Call) RunAndReturn(run func(ctx context.Context, filter dto.TotalCostFilter) (int, error)) *MockSubscriptionRepository_CalculateTotalCost_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Delete provides a mock function for the type MockSubscriptionRepository
-func (_mock *MockSubscriptionRepository) Delete(id uuid.UUID) error {
-	ret := _mock.Called(id)
+func (_mock *MockSubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) error); ok {
-		r0 = returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -172,19 +185,25 @@ type MockSubscriptionRepository_Delete_Call struct {
 }
 
 // Delete is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id uuid.UUID
-func (_e *MockSubscriptionRepository_Expecter) Delete(id interface{}) *MockSubscriptionRepository_Delete_Call {
-	return &MockSubscriptionRepository_Delete_Call{Call: _e.mock.On("Delete", id)}
+func (_e *MockSubscriptionRepository_Expecter) Delete(ctx interface{}, id interface{}) *MockSubscriptionRepository_Delete_Call {
+	return &MockSubscriptionRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
 }
 
-func (_c *MockSubscriptionRepository_Delete_Call) Run(run func(id uuid.UUID)) *MockSubscriptionRepository_Delete_Call {
+func (_c *MockSubscriptionRepository_Delete_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockSubscriptionRepository_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 uuid.UUID
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(uuid.UUID)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -195,14 +214,14 @@ func (_c *MockSubscriptionRepository_Delete_Call) Return(err error) *MockSubscri
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_Delete_Call) RunAndReturn(run func(id uuid.UUID) error) *MockSubscriptionRepository_Delete_Call {
+func (_c *MockSubscriptionRepository_Delete_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockSubscriptionRepository_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Get provides a mock function for the type MockSubscriptionRepository
-func (_mock *MockSubscriptionRepository) Get(id uuid.UUID) (*entity.Subscription, error) {
-	ret := _mock.Called(id)
+func (_mock *MockSubscriptionRepository) Get(ctx context.Context, id uuid.UUID) (*entity.Subscription, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Get")
@@ -210,18 +229,18 @@ func (_mock *MockSubscriptionRepository) Get(id uuid.UUID) (*entity.Subscription
 
 	var r0 *entity.Subscription
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (*entity.Subscription, error)); ok {
-		return returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*entity.Subscription, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) *entity.Subscription); ok {
-		r0 = returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *entity.Subscription); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.Subscription)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = returnFunc(id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -234,19 +253,25 @@ type MockSubscriptionRepository_Get_Call struct {
 }
 
 // Get is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id uuid.UUID
-func (_e *MockSubscriptionRepository_Expecter) Get(id interface{}) *MockSubscriptionRepository_Get_Call {
-	return &MockSubscriptionRepository_Get_Call{Call: _e.mock.On("Get", id)}
+func (_e *MockSubscriptionRepository_Expecter) Get(ctx interface{}, id interface{}) *MockSubscriptionRepository_Get_Call {
+	return &MockSubscriptionRepository_Get_Call{Call: _e.mock.On("Get", ctx, id)}
 }
 
-func (_c *MockSubscriptionRepository_Get_Call) Run(run func(id uuid.UUID)) *MockSubscriptionRepository_Get_Call {
+func (_c *MockSubscriptionRepository_Get_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockSubscriptionRepository_Get_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 uuid.UUID
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(uuid.UUID)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -257,33 +282,31 @@ func (_c *MockSubscriptionRepository_Get_Call) Return(subscription *entity.Subsc
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_Get_Call) RunAndReturn(run func(id uuid.UUID) (*entity.Subscription, error)) *MockSubscriptionRepository_Get_Call {
+func (_c *MockSubscriptionRepository_Get_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*entity.Subscription, error)) *MockSubscriptionRepository_Get_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // List provides a mock function for the type MockSubscriptionRepository
-func (_mock *MockSubscriptionRepository) List(filter dto.SubscriptionFilter) ([]*entity.Subscription, error) {
-	ret := _mock.Called(filter)
+func (_mock *MockSubscriptionRepository) List(ctx context.Context, filter dto.SubscriptionFilter) (dto.SubscriptionPage, error) {
+	ret := _mock.Called(ctx, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for List")
 	}
 
-	var r0 []*entity.Subscription
+	var r0 dto.SubscriptionPage
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(dto.SubscriptionFilter) ([]*entity.Subscription, error)); ok {
-		return returnFunc(filter)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.SubscriptionFilter) (dto.SubscriptionPage, error)); ok {
+		return returnFunc(ctx, filter)
 	}
-	if returnFunc, ok := ret.Get(0).(func(dto.SubscriptionFilter) []*entity.Subscription); ok {
-		r0 = returnFunc(filter)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.SubscriptionFilter) dto.SubscriptionPage); ok {
+		r0 = returnFunc(ctx, filter)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*entity.Subscription)
-		}
+		r0 = ret.Get(0).(dto.SubscriptionPage)
 	}
-	if returnFunc, ok := ret.Get(1).(func(dto.SubscriptionFilter) error); ok {
-		r1 = returnFunc(filter)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dto.SubscriptionFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -296,45 +319,244 @@ type MockSubscriptionRepository_List_Call struct {
 }
 
 // List is a helper method to define mock.On call
+//   - ctx context.Context
 //   - filter dto.SubscriptionFilter
-func (_e *MockSubscriptionRepository_Expecter) List(filter interface{}) *MockSubscriptionRepository_List_Call {
-	return &MockSubscriptionRepository_List_Call{Call: _e.mock.On("List", filter)}
+func (_e *MockSubscriptionRepository_Expecter) List(ctx interface{}, filter interface{}) *MockSubscriptionRepository_List_Call {
+	return &MockSubscriptionRepository_List_Call{Call: _e.mock.On("List", ctx, filter)}
+}
+
+func (_c *MockSubscriptionRepository_List_Call) Run(run func(ctx context.Context, filter dto.SubscriptionFilter)) *MockSubscriptionRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 dto.SubscriptionFilter
+		if args[1] != nil {
+			arg1 = args[1].(dto.SubscriptionFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_List_Call) Return(subscriptionPage dto.SubscriptionPage, err error) *MockSubscriptionRepository_List_Call {
+	_c.Call.Return(subscriptionPage, err)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_List_Call) RunAndReturn(run func(ctx context.Context, filter dto.SubscriptionFilter) (dto.SubscriptionPage, error)) *MockSubscriptionRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByUser provides a mock function for the type MockSubscriptionRepository
+func (_mock *MockSubscriptionRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Subscription, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByUser")
+	}
+
+	var r0 []*entity.Subscription
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*entity.Subscription, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*entity.Subscription); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Subscription)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSubscriptionRepository_ListByUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByUser'
+type MockSubscriptionRepository_ListByUser_Call struct {
+	*mock.Call
+}
+
+// ListByUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockSubscriptionRepository_Expecter) ListByUser(ctx interface{}, userID interface{}) *MockSubscriptionRepository_ListByUser_Call {
+	return &MockSubscriptionRepository_ListByUser_Call{Call: _e.mock.On("ListByUser", ctx, userID)}
+}
+
+func (_c *MockSubscriptionRepository_ListByUser_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockSubscriptionRepository_ListByUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_ListByUser_Call) Return(subscriptions []*entity.Subscription, err error) *MockSubscriptionRepository_ListByUser_Call {
+	_c.Call.Return(subscriptions, err)
+	return _c
 }
 
-func (_c *MockSubscriptionRepository_List_Call) Run(run func(filter dto.SubscriptionFilter)) *MockSubscriptionRepository_List_Call {
+func (_c *MockSubscriptionRepository_ListByUser_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]*entity.Subscription, error)) *MockSubscriptionRepository_ListByUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Merge provides a mock function for the type MockSubscriptionRepository
+func (_mock *MockSubscriptionRepository) Merge(ctx context.Context, merge dto.SubscriptionMerge) error {
+	ret := _mock.Called(ctx, merge)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.SubscriptionMerge) error); ok {
+		r0 = returnFunc(ctx, merge)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSubscriptionRepository_Merge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Merge'
+type MockSubscriptionRepository_Merge_Call struct {
+	*mock.Call
+}
+
+// Merge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - merge dto.SubscriptionMerge
+func (_e *MockSubscriptionRepository_Expecter) Merge(ctx interface{}, merge interface{}) *MockSubscriptionRepository_Merge_Call {
+	return &MockSubscriptionRepository_Merge_Call{Call: _e.mock.On("Merge", ctx, merge)}
+}
+
+func (_c *MockSubscriptionRepository_Merge_Call) Run(run func(ctx context.Context, merge dto.SubscriptionMerge)) *MockSubscriptionRepository_Merge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 dto.SubscriptionMerge
+		if args[1] != nil {
+			arg1 = args[1].(dto.SubscriptionMerge)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_Merge_Call) Return(err error) *MockSubscriptionRepository_Merge_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSubscriptionRepository_Merge_Call) RunAndReturn(run func(ctx context.Context, merge dto.SubscriptionMerge) error) *MockSubscriptionRepository_Merge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function for the type MockSubscriptionRepository
+func (_mock *MockSubscriptionRepository) Search(ctx context.Context, filter dto.SubscriptionSearchFilter) ([]*entity.Subscription, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []*entity.Subscription
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.SubscriptionSearchFilter) ([]*entity.Subscription, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.SubscriptionSearchFilter) []*entity.Subscription); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Subscription)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dto.SubscriptionSearchFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSubscriptionRepository_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockSubscriptionRepository_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter dto.SubscriptionSearchFilter
+func (_e *MockSubscriptionRepository_Expecter) Search(ctx interface{}, filter interface{}) *MockSubscriptionRepository_Search_Call {
+	return &MockSubscriptionRepository_Search_Call{Call: _e.mock.On("Search", ctx, filter)}
+}
+
+func (_c *MockSubscriptionRepository_Search_Call) Run(run func(ctx context.Context, filter dto.SubscriptionSearchFilter)) *MockSubscriptionRepository_Search_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 dto.SubscriptionFilter
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(dto.SubscriptionFilter)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 dto.SubscriptionSearchFilter
+		if args[1] != nil {
+			arg1 = args[1].(dto.SubscriptionSearchFilter)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_List_Call) Return(subscriptions []*entity.Subscription, err error) *MockSubscriptionRepository_List_Call {
+func (_c *MockSubscriptionRepository_Search_Call) Return(subscriptions []*entity.Subscription, err error) *MockSubscriptionRepository_Search_Call {
 	_c.Call.Return(subscriptions, err)
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_List_Call) RunAndReturn(run func(filter dto.SubscriptionFilter) ([]*entity.Subscription, error)) *MockSubscriptionRepository_List_Call {
+func (_c *MockSubscriptionRepository_Search_Call) RunAndReturn(run func(ctx context.Context, filter dto.SubscriptionSearchFilter) ([]*entity.Subscription, error)) *MockSubscriptionRepository_Search_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // Update provides a mock function for the type MockSubscriptionRepository
-func (_mock *MockSubscriptionRepository) Update(sub *entity.Subscription) error {
-	ret := _mock.Called(sub)
+func (_mock *MockSubscriptionRepository) Update(ctx context.Context, sub *entity.Subscription) error {
+	ret := _mock.Called(ctx, sub)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Update")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(*entity.Subscription) error); ok {
-		r0 = returnFunc(sub)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *entity.Subscription) error); ok {
+		r0 = returnFunc(ctx, sub)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -347,19 +569,25 @@ type MockSubscriptionRepository_Update_Call struct {
 }
 
 // Update is a helper method to define mock.On call
+//   - ctx context.Context
 //   - sub *entity.Subscription
-func (_e *MockSubscriptionRepository_Expecter) Update(sub interface{}) *MockSubscriptionRepository_Update_Call {
-	return &MockSubscriptionRepository_Update_Call{Call: _e.mock.On("Update", sub)}
+func (_e *MockSubscriptionRepository_Expecter) Update(ctx interface{}, sub interface{}) *MockSubscriptionRepository_Update_Call {
+	return &MockSubscriptionRepository_Update_Call{Call: _e.mock.On("Update", ctx, sub)}
 }
 
-func (_c *MockSubscriptionRepository_Update_Call) Run(run func(sub *entity.Subscription)) *MockSubscriptionRepository_Update_Call {
+func (_c *MockSubscriptionRepository_Update_Call) Run(run func(ctx context.Context, sub *entity.Subscription)) *MockSubscriptionRepository_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 *entity.Subscription
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(*entity.Subscription)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *entity.Subscription
+		if args[1] != nil {
+			arg1 = args[1].(*entity.Subscription)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -370,7 +598,7 @@ func (_c *MockSubscriptionRepository_Update_Call) Return(err error) *MockSubscri
 	return _c
 }
 
-func (_c *MockSubscriptionRepository_Update_Call) RunAndReturn(run func(sub *entity.Subscription) error) *MockSubscriptionRepository_Update_Call {
+func (_c *MockSubscriptionRepository_Update_Call) RunAndReturn(run func(ctx context.Context, sub *entity.Subscription) error) *MockSubscriptionRepository_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -5,6 +5,7 @@
 package mock_usecase
 
 import (
+	"context"
 	"github.com/MDx3R/ef-test/internal/usecase/dto"
 	"github.com/google/uuid"
 	mock "github.com/stretchr/testify/mock"
@@ -38,8 +39,8 @@ func (_m *MockSubscriptionService) EXPECT() *MockSubscriptionService_Expecter {
 }
 
 // CalculateTotalCost provides a mock function for the type MockSubscriptionService
-func (_mock *MockSubscriptionService) CalculateTotalCost(filter dto.TotalCostFilter) (int, error) {
-	ret := _mock.Called(filter)
+func (_mock *MockSubscriptionService) CalculateTotalCost(ctx context.Context, filter dto.TotalCostFilter) (int, error) {
+	ret := _mock.Called(ctx, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CalculateTotalCost")
@@ -47,16 +48,16 @@ func (_mock *MockSubscriptionService) CalculateTotalCost(filter dto.TotalCostFil
 
 	var r0 int
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(dto.TotalCostFilter) (int, error)); ok {
-		return returnFunc(filter)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.TotalCostFilter) (int, error)); ok {
+		return returnFunc(ctx, filter)
 	}
-	if returnFunc, ok := ret.Get(0).(func(dto.TotalCostFilter) int); ok {
-		r0 = returnFunc(filter)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.TotalCostFilter) int); ok {
+		r0 = returnFunc(ctx, filter)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
-	if returnFunc, ok := ret.Get(1).(func(dto.TotalCostFilter) error); ok {
-		r1 = returnFunc(filter)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dto.TotalCostFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -69,19 +70,25 @@ type MockSubscriptionService_CalculateTotalCost_Call struct {
 }
 
 // CalculateTotalCost is a helper method to define mock.On call
+//   - ctx context.Context
 //   - filter dto.TotalCostFilter
-func (_e *MockSubscriptionService_Expecter) CalculateTotalCost(filter interface{}) *MockSubscriptionService_CalculateTotalCost_Call {
-	return &MockSubscriptionService_CalculateTotalCost_Call{Call: _e.mock.On("CalculateTotalCost", filter)}
+func (_e *MockSubscriptionService_Expecter) CalculateTotalCost(ctx interface{}, filter interface{}) *MockSubscriptionService_CalculateTotalCost_Call {
+	return &MockSubscriptionService_CalculateTotalCost_Call{Call: _e.mock.On("CalculateTotalCost", ctx, filter)}
 }
 
-func (_c *MockSubscriptionService_CalculateTotalCost_Call) Run(run func(filter dto.TotalCostFilter)) *MockSubscriptionService_CalculateTotalCost_Call {
+func (_c *MockSubscriptionService_CalculateTotalCost_Call) Run(run func(ctx context.Context, filter dto.TotalCostFilter)) *MockSubscriptionService_CalculateTotalCost_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 dto.TotalCostFilter
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(dto.TotalCostFilter)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 dto.TotalCostFilter
+		if args[1] != nil {
+			arg1 = args[1].(dto.TotalCostFilter)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -92,14 +99,14 @@ func (_c *MockSubscriptionService_CalculateTotalCost_Call) Return(n int, err err
 	return _c
 }
 
-func (_c *MockSubscriptionService_CalculateTotalCost_Call) RunAndReturn(run func(filter dto.TotalCostFilter) (int, error)) *MockSubscriptionService_CalculateTotalCost_Call {
+func (_c *MockSubscriptionService_CalculateTotalCost_Call) RunAndReturn(run func(ctx context.Context, filter dto.TotalCostFilter) (int, error)) *MockSubscriptionService_CalculateTotalCost_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // CreateSubscription provides a mock function for the type MockSubscriptionService
-func (_mock *MockSubscriptionService) CreateSubscription(request dto.CreateSubscriptionCommand) (uuid.UUID, error) {
-	ret := _mock.Called(request)
+func (_mock *MockSubscriptionService) CreateSubscription(ctx context.Context, request dto.CreateSubscriptionCommand) (uuid.UUID, error) {
+	ret := _mock.Called(ctx, request)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateSubscription")
@@ -107,18 +114,18 @@ func (_mock *MockSubscriptionService) CreateSubscription(request dto.CreateSubsc
 
 	var r0 uuid.UUID
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(dto.CreateSubscriptionCommand) (uuid.UUID, error)); ok {
-		return returnFunc(request)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.CreateSubscriptionCommand) (uuid.UUID, error)); ok {
+		return returnFunc(ctx, request)
 	}
-	if returnFunc, ok := ret.Get(0).(func(dto.CreateSubscriptionCommand) uuid.UUID); ok {
-		r0 = returnFunc(request)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.CreateSubscriptionCommand) uuid.UUID); ok {
+		r0 = returnFunc(ctx, request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(uuid.UUID)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(dto.CreateSubscriptionCommand) error); ok {
-		r1 = returnFunc(request)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dto.CreateSubscriptionCommand) error); ok {
+		r1 = returnFunc(ctx, request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -131,19 +138,25 @@ type MockSubscriptionService_CreateSubscription_Call struct {
 }
 
 // CreateSubscription is a helper method to define mock.On call
+//   - ctx context.Context
 //   - request dto.CreateSubscriptionCommand
-func (_e *MockSubscriptionService_Expecter) CreateSubscription(request interface{}) *MockSubscriptionService_CreateSubscription_Call {
-	return &MockSubscriptionService_CreateSubscription_Call{Call: _e.mock.On("CreateSubscription", request)}
+func (_e *MockSubscriptionService_Expecter) CreateSubscription(ctx interface{}, request interface{}) *MockSubscriptionService_CreateSubscription_Call {
+	return &MockSubscriptionService_CreateSubscription_Call{Call: _e.mock.On("CreateSubscription", ctx, request)}
 }
 
-func (_c *MockSubscriptionService_CreateSubscription_Call) Run(run func(request dto.CreateSubscriptionCommand)) *MockSubscriptionService_CreateSubscription_Call {
+func (_c *MockSubscriptionService_CreateSubscription_Call) Run(run func(ctx context.Context, request dto.CreateSubscriptionCommand)) *MockSubscriptionService_CreateSubscription_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 dto.CreateSubscriptionCommand
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(dto.CreateSubscriptionCommand)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 dto.CreateSubscriptionCommand
+		if args[1] != nil {
+			arg1 = args[1].(dto.CreateSubscriptionCommand)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -154,22 +167,22 @@ func (_c *MockSubscriptionService_CreateSubscription_Call) Return(uUID uuid.UUID
 	return _c
 }
 
-func (_c *MockSubscriptionService_CreateSubscription_Call) RunAndReturn(run func(request dto.CreateSubscriptionCommand) (uuid.UUID, error)) *MockSubscriptionService_CreateSubscription_Call {
+func (_c *MockSubscriptionService_CreateSubscription_Call) RunAndReturn(run func(ctx context.Context, request dto.CreateSubscriptionCommand) (uuid.UUID, error)) *MockSubscriptionService_CreateSubscription_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // DeleteSubscription provides a mock function for the type MockSubscriptionService
-func (_mock *MockSubscriptionService) DeleteSubscription(id uuid.UUID) error {
-	ret := _mock.Called(id)
+func (_mock *MockSubscriptionService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteSubscription")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) error); ok {
-		r0 = returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -182,19 +195,25 @@ type MockSubscriptionService_DeleteSubscription_Call struct {
 }
 
 // DeleteSubscription is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id uuid.UUID
-func (_e *MockSubscriptionService_Expecter) DeleteSubscription(id interface{}) *MockSubscriptionService_DeleteSubscription_Call {
-	return &MockSubscriptionService_DeleteSubscription_Call{Call: _e.mock.On("DeleteSubscription", id)}
+func (_e *MockSubscriptionService_Expecter) DeleteSubscription(ctx interface{}, id interface{}) *MockSubscriptionService_DeleteSubscription_Call {
+	return &MockSubscriptionService_DeleteSubscription_Call{Call: _e.mock.On("DeleteSubscription", ctx, id)}
 }
 
-func (_c *MockSubscriptionService_DeleteSubscription_Call) Run(run func(id uuid.UUID)) *MockSubscriptionService_DeleteSubscription_Call {
+func (_c *MockSubscriptionService_DeleteSubscription_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockSubscriptionService_DeleteSubscription_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 uuid.UUID
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(uuid.UUID)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -205,14 +224,82 @@ func (_c *MockSubscriptionService_DeleteSubscription_Call) Return(err error) *Mo
 	return _c
 }
 
-func (_c *MockSubscriptionService_DeleteSubscription_Call) RunAndReturn(run func(id uuid.UUID) error) *MockSubscriptionService_DeleteSubscription_Call {
+func (_c *MockSubscriptionService_DeleteSubscription_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockSubscriptionService_DeleteSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindDuplicates provides a mock function for the type MockSubscriptionService
+func (_mock *MockSubscriptionService) FindDuplicates(ctx context.Context, userID uuid.UUID) ([]dto.DuplicateGroupDTO, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDuplicates")
+	}
+
+	var r0 []dto.DuplicateGroupDTO
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]dto.DuplicateGroupDTO, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []dto.DuplicateGroupDTO); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.DuplicateGroupDTO)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSubscriptionService_FindDuplicates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindDuplicates'
+type MockSubscriptionService_FindDuplicates_Call struct {
+	*mock.Call
+}
+
+// FindDuplicates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uuid.UUID
+func (_e *MockSubscriptionService_Expecter) FindDuplicates(ctx interface{}, userID interface{}) *MockSubscriptionService_FindDuplicates_Call {
+	return &MockSubscriptionService_FindDuplicates_Call{Call: _e.mock.On("FindDuplicates", ctx, userID)}
+}
+
+func (_c *MockSubscriptionService_FindDuplicates_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockSubscriptionService_FindDuplicates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_FindDuplicates_Call) Return(duplicateGroupDTOs []dto.DuplicateGroupDTO, err error) *MockSubscriptionService_FindDuplicates_Call {
+	_c.Call.Return(duplicateGroupDTOs, err)
+	return _c
+}
+
+func (_c *MockSubscriptionService_FindDuplicates_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]dto.DuplicateGroupDTO, error)) *MockSubscriptionService_FindDuplicates_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // GetSubscription provides a mock function for the type MockSubscriptionService
-func (_mock *MockSubscriptionService) GetSubscription(id uuid.UUID) (dto.SubscriptionDTO, error) {
-	ret := _mock.Called(id)
+func (_mock *MockSubscriptionService) GetSubscription(ctx context.Context, id uuid.UUID) (dto.SubscriptionDTO, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetSubscription")
@@ -220,16 +307,16 @@ func (_mock *MockSubscriptionService) GetSubscription(id uuid.UUID) (dto.Subscri
 
 	var r0 dto.SubscriptionDTO
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) (dto.SubscriptionDTO, error)); ok {
-		return returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (dto.SubscriptionDTO, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(uuid.UUID) dto.SubscriptionDTO); ok {
-		r0 = returnFunc(id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) dto.SubscriptionDTO); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		r0 = ret.Get(0).(dto.SubscriptionDTO)
 	}
-	if returnFunc, ok := ret.Get(1).(func(uuid.UUID) error); ok {
-		r1 = returnFunc(id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -242,56 +329,60 @@ type MockSubscriptionService_GetSubscription_Call struct {
 }
 
 // GetSubscription is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id uuid.UUID
-func (_e *MockSubscriptionService_Expecter) GetSubscription(id interface{}) *MockSubscriptionService_GetSubscription_Call {
-	return &MockSubscriptionService_GetSubscription_Call{Call: _e.mock.On("GetSubscription", id)}
+func (_e *MockSubscriptionService_Expecter) GetSubscription(ctx interface{}, id interface{}) *MockSubscriptionService_GetSubscription_Call {
+	return &MockSubscriptionService_GetSubscription_Call{Call: _e.mock.On("GetSubscription", ctx, id)}
 }
 
-func (_c *MockSubscriptionService_GetSubscription_Call) Run(run func(id uuid.UUID)) *MockSubscriptionService_GetSubscription_Call {
+func (_c *MockSubscriptionService_GetSubscription_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockSubscriptionService_GetSubscription_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 uuid.UUID
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(uuid.UUID)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSubscriptionService_GetSubscription_Call) Return(subscriptionResponse dto.SubscriptionDTO, err error) *MockSubscriptionService_GetSubscription_Call {
-	_c.Call.Return(subscriptionResponse, err)
+func (_c *MockSubscriptionService_GetSubscription_Call) Return(subscriptionDTO dto.SubscriptionDTO, err error) *MockSubscriptionService_GetSubscription_Call {
+	_c.Call.Return(subscriptionDTO, err)
 	return _c
 }
 
-func (_c *MockSubscriptionService_GetSubscription_Call) RunAndReturn(run func(id uuid.UUID) (dto.SubscriptionDTO, error)) *MockSubscriptionService_GetSubscription_Call {
+func (_c *MockSubscriptionService_GetSubscription_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (dto.SubscriptionDTO, error)) *MockSubscriptionService_GetSubscription_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // ListSubscriptions provides a mock function for the type MockSubscriptionService
-func (_mock *MockSubscriptionService) ListSubscriptions(filter dto.SubscriptionFilter) ([]dto.SubscriptionDTO, error) {
-	ret := _mock.Called(filter)
+func (_mock *MockSubscriptionService) ListSubscriptions(ctx context.Context, filter dto.SubscriptionFilter) (dto.SubscriptionPageDTO, error) {
+	ret := _mock.Called(ctx, filter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListSubscriptions")
 	}
 
-	var r0 []dto.SubscriptionDTO
+	var r0 dto.SubscriptionPageDTO
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(dto.SubscriptionFilter) ([]dto.SubscriptionDTO, error)); ok {
-		return returnFunc(filter)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.SubscriptionFilter) (dto.SubscriptionPageDTO, error)); ok {
+		return returnFunc(ctx, filter)
 	}
-	if returnFunc, ok := ret.Get(0).(func(dto.SubscriptionFilter) []dto.SubscriptionDTO); ok {
-		r0 = returnFunc(filter)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.SubscriptionFilter) dto.SubscriptionPageDTO); ok {
+		r0 = returnFunc(ctx, filter)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]dto.SubscriptionDTO)
-		}
+		r0 = ret.Get(0).(dto.SubscriptionPageDTO)
 	}
-	if returnFunc, ok := ret.Get(1).(func(dto.SubscriptionFilter) error); ok {
-		r1 = returnFunc(filter)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dto.SubscriptionFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -304,45 +395,257 @@ type MockSubscriptionService_ListSubscriptions_Call struct {
 }
 
 // ListSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
 //   - filter dto.SubscriptionFilter
-func (_e *MockSubscriptionService_Expecter) ListSubscriptions(filter interface{}) *MockSubscriptionService_ListSubscriptions_Call {
-	return &MockSubscriptionService_ListSubscriptions_Call{Call: _e.mock.On("ListSubscriptions", filter)}
+func (_e *MockSubscriptionService_Expecter) ListSubscriptions(ctx interface{}, filter interface{}) *MockSubscriptionService_ListSubscriptions_Call {
+	return &MockSubscriptionService_ListSubscriptions_Call{Call: _e.mock.On("ListSubscriptions", ctx, filter)}
+}
+
+func (_c *MockSubscriptionService_ListSubscriptions_Call) Run(run func(ctx context.Context, filter dto.SubscriptionFilter)) *MockSubscriptionService_ListSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 dto.SubscriptionFilter
+		if args[1] != nil {
+			arg1 = args[1].(dto.SubscriptionFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_ListSubscriptions_Call) Return(subscriptionPageDTO dto.SubscriptionPageDTO, err error) *MockSubscriptionService_ListSubscriptions_Call {
+	_c.Call.Return(subscriptionPageDTO, err)
+	return _c
+}
+
+func (_c *MockSubscriptionService_ListSubscriptions_Call) RunAndReturn(run func(ctx context.Context, filter dto.SubscriptionFilter) (dto.SubscriptionPageDTO, error)) *MockSubscriptionService_ListSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergeSubscriptions provides a mock function for the type MockSubscriptionService
+func (_mock *MockSubscriptionService) MergeSubscriptions(ctx context.Context, request dto.MergeSubscriptionsCommand) (dto.SubscriptionMergeDTO, error) {
+	ret := _mock.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeSubscriptions")
+	}
+
+	var r0 dto.SubscriptionMergeDTO
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.MergeSubscriptionsCommand) (dto.SubscriptionMergeDTO, error)); ok {
+		return returnFunc(ctx, request)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.MergeSubscriptionsCommand) dto.SubscriptionMergeDTO); ok {
+		r0 = returnFunc(ctx, request)
+	} else {
+		r0 = ret.Get(0).(dto.SubscriptionMergeDTO)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dto.MergeSubscriptionsCommand) error); ok {
+		r1 = returnFunc(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSubscriptionService_MergeSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeSubscriptions'
+type MockSubscriptionService_MergeSubscriptions_Call struct {
+	*mock.Call
+}
+
+// MergeSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request dto.MergeSubscriptionsCommand
+func (_e *MockSubscriptionService_Expecter) MergeSubscriptions(ctx interface{}, request interface{}) *MockSubscriptionService_MergeSubscriptions_Call {
+	return &MockSubscriptionService_MergeSubscriptions_Call{Call: _e.mock.On("MergeSubscriptions", ctx, request)}
 }
 
-func (_c *MockSubscriptionService_ListSubscriptions_Call) Run(run func(filter dto.SubscriptionFilter)) *MockSubscriptionService_ListSubscriptions_Call {
+func (_c *MockSubscriptionService_MergeSubscriptions_Call) Run(run func(ctx context.Context, request dto.MergeSubscriptionsCommand)) *MockSubscriptionService_MergeSubscriptions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 dto.SubscriptionFilter
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(dto.SubscriptionFilter)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 dto.MergeSubscriptionsCommand
+		if args[1] != nil {
+			arg1 = args[1].(dto.MergeSubscriptionsCommand)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockSubscriptionService_ListSubscriptions_Call) Return(subscriptionResponses []dto.SubscriptionDTO, err error) *MockSubscriptionService_ListSubscriptions_Call {
-	_c.Call.Return(subscriptionResponses, err)
+func (_c *MockSubscriptionService_MergeSubscriptions_Call) Return(subscriptionMergeDTO dto.SubscriptionMergeDTO, err error) *MockSubscriptionService_MergeSubscriptions_Call {
+	_c.Call.Return(subscriptionMergeDTO, err)
 	return _c
 }
 
-func (_c *MockSubscriptionService_ListSubscriptions_Call) RunAndReturn(run func(filter dto.SubscriptionFilter) ([]dto.SubscriptionDTO, error)) *MockSubscriptionService_ListSubscriptions_Call {
+func (_c *MockSubscriptionService_MergeSubscriptions_Call) RunAndReturn(run func(ctx context.Context, request dto.MergeSubscriptionsCommand) (dto.SubscriptionMergeDTO, error)) *MockSubscriptionService_MergeSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchSubscription provides a mock function for the type MockSubscriptionService
+func (_mock *MockSubscriptionService) PatchSubscription(ctx context.Context, id uuid.UUID, request dto.PatchSubscriptionCommand) (dto.SubscriptionDTO, error) {
+	ret := _mock.Called(ctx, id, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchSubscription")
+	}
+
+	var r0 dto.SubscriptionDTO
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, dto.PatchSubscriptionCommand) (dto.SubscriptionDTO, error)); ok {
+		return returnFunc(ctx, id, request)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, dto.PatchSubscriptionCommand) dto.SubscriptionDTO); ok {
+		r0 = returnFunc(ctx, id, request)
+	} else {
+		r0 = ret.Get(0).(dto.SubscriptionDTO)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, dto.PatchSubscriptionCommand) error); ok {
+		r1 = returnFunc(ctx, id, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSubscriptionService_PatchSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchSubscription'
+type MockSubscriptionService_PatchSubscription_Call struct {
+	*mock.Call
+}
+
+// PatchSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - request dto.PatchSubscriptionCommand
+func (_e *MockSubscriptionService_Expecter) PatchSubscription(ctx interface{}, id interface{}, request interface{}) *MockSubscriptionService_PatchSubscription_Call {
+	return &MockSubscriptionService_PatchSubscription_Call{Call: _e.mock.On("PatchSubscription", ctx, id, request)}
+}
+
+func (_c *MockSubscriptionService_PatchSubscription_Call) Run(run func(ctx context.Context, id uuid.UUID, request dto.PatchSubscriptionCommand)) *MockSubscriptionService_PatchSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 dto.PatchSubscriptionCommand
+		if args[2] != nil {
+			arg2 = args[2].(dto.PatchSubscriptionCommand)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_PatchSubscription_Call) Return(subscriptionDTO dto.SubscriptionDTO, err error) *MockSubscriptionService_PatchSubscription_Call {
+	_c.Call.Return(subscriptionDTO, err)
+	return _c
+}
+
+func (_c *MockSubscriptionService_PatchSubscription_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, request dto.PatchSubscriptionCommand) (dto.SubscriptionDTO, error)) *MockSubscriptionService_PatchSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchSubscriptions provides a mock function for the type MockSubscriptionService
+func (_mock *MockSubscriptionService) SearchSubscriptions(ctx context.Context, filter dto.SubscriptionSearchFilter) ([]dto.SubscriptionDTO, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchSubscriptions")
+	}
+
+	var r0 []dto.SubscriptionDTO
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.SubscriptionSearchFilter) ([]dto.SubscriptionDTO, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, dto.SubscriptionSearchFilter) []dto.SubscriptionDTO); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.SubscriptionDTO)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, dto.SubscriptionSearchFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSubscriptionService_SearchSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchSubscriptions'
+type MockSubscriptionService_SearchSubscriptions_Call struct {
+	*mock.Call
+}
+
+// SearchSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter dto.SubscriptionSearchFilter
+func (_e *MockSubscriptionService_Expecter) SearchSubscriptions(ctx interface{}, filter interface{}) *MockSubscriptionService_SearchSubscriptions_Call {
+	return &MockSubscriptionService_SearchSubscriptions_Call{Call: _e.mock.On("SearchSubscriptions", ctx, filter)}
+}
+
+func (_c *MockSubscriptionService_SearchSubscriptions_Call) Run(run func(ctx context.Context, filter dto.SubscriptionSearchFilter)) *MockSubscriptionService_SearchSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 dto.SubscriptionSearchFilter
+		if args[1] != nil {
+			arg1 = args[1].(dto.SubscriptionSearchFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSubscriptionService_SearchSubscriptions_Call) Return(subscriptionDTOs []dto.SubscriptionDTO, err error) *MockSubscriptionService_SearchSubscriptions_Call {
+	_c.Call.Return(subscriptionDTOs, err)
+	return _c
+}
+
+func (_c *MockSubscriptionService_SearchSubscriptions_Call) RunAndReturn(run func(ctx context.Context, filter dto.SubscriptionSearchFilter) ([]dto.SubscriptionDTO, error)) *MockSubscriptionService_SearchSubscriptions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // UpdateSubscription provides a mock function for the type MockSubscriptionService
-func (_mock *MockSubscriptionService) UpdateSubscription(id uuid.UUID, request dto.UpdateSubscriptionCommand) error {
-	ret := _mock.Called(id, request)
+func (_mock *MockSubscriptionService) UpdateSubscription(ctx context.Context, id uuid.UUID, request dto.UpdateSubscriptionCommand) error {
+	ret := _mock.Called(ctx, id, request)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateSubscription")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(uuid.UUID, dto.UpdateSubscriptionCommand) error); ok {
-		r0 = returnFunc(id, request)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, dto.UpdateSubscriptionCommand) error); ok {
+		r0 = returnFunc(ctx, id, request)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -355,25 +658,31 @@ type MockSubscriptionService_UpdateSubscription_Call struct {
 }
 
 // UpdateSubscription is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id uuid.UUID
 //   - request dto.UpdateSubscriptionCommand
-func (_e *MockSubscriptionService_Expecter) UpdateSubscription(id interface{}, request interface{}) *MockSubscriptionService_UpdateSubscription_Call {
-	return &MockSubscriptionService_UpdateSubscription_Call{Call: _e.mock.On("UpdateSubscription", id, request)}
+func (_e *MockSubscriptionService_Expecter) UpdateSubscription(ctx interface{}, id interface{}, request interface{}) *MockSubscriptionService_UpdateSubscription_Call {
+	return &MockSubscriptionService_UpdateSubscription_Call{Call: _e.mock.On("UpdateSubscription", ctx, id, request)}
 }
 
-func (_c *MockSubscriptionService_UpdateSubscription_Call) Run(run func(id uuid.UUID, request dto.UpdateSubscriptionCommand)) *MockSubscriptionService_UpdateSubscription_Call {
+func (_c *MockSubscriptionService_UpdateSubscription_Call) Run(run func(ctx context.Context, id uuid.UUID, request dto.UpdateSubscriptionCommand)) *MockSubscriptionService_UpdateSubscription_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 uuid.UUID
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(uuid.UUID)
+			arg0 = args[0].(context.Context)
 		}
-		var arg1 dto.UpdateSubscriptionCommand
+		var arg1 uuid.UUID
 		if args[1] != nil {
-			arg1 = args[1].(dto.UpdateSubscriptionCommand)
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 dto.UpdateSubscriptionCommand
+		if args[2] != nil {
+			arg2 = args[2].(dto.UpdateSubscriptionCommand)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -384,7 +693,7 @@ func (_c *MockSubscriptionService_UpdateSubscription_Call) Return(err error) *Mo
 	return _c
 }
 
-func (_c *MockSubscriptionService_UpdateSubscription_Call) RunAndReturn(run func(id uuid.UUID, request dto.UpdateSubscriptionCommand) error) *MockSubscriptionService_UpdateSubscription_Call {
+func (_c *MockSubscriptionService_UpdateSubscription_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, request dto.UpdateSubscriptionCommand) error) *MockSubscriptionService_UpdateSubscription_Call {
 	_c.Call.Return(run)
 	return _c
 }
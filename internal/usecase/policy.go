@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Action is an operation the policy grants to roles.
+type Action string
+
+const (
+	ActionSubscriptionsRead   Action = "subscriptions:read"
+	ActionSubscriptionsCreate Action = "subscriptions:create"
+	ActionSubscriptionsUpdate Action = "subscriptions:update"
+	ActionSubscriptionsDelete Action = "subscriptions:delete"
+	ActionReportsTotal        Action = "reports:total"
+)
+
+var actions = []Action{
+	ActionSubscriptionsRead,
+	ActionSubscriptionsCreate,
+	ActionSubscriptionsUpdate,
+	ActionSubscriptionsDelete,
+	ActionReportsTotal,
+}
+
+// Resource tells whose subscriptions a permission covers.
+type Resource string
+
+const (
+	// ResourceOwn covers the subscriptions of the caller only.
+	ResourceOwn Resource = "own"
+	// ResourceAny covers the subscriptions of every user.
+	ResourceAny Resource = "any"
+)
+
+type Permission struct {
+	Action   Action
+	Resource Resource
+}
+
+// Policy grants permissions to roles. A caller holds the union of the
+// permissions of its roles; callers without roles get the default role.
+type Policy struct {
+	defaultRole string
+	grants      map[string]map[Action]Resource
+}
+
+func NewPolicy(defaultRole string, roles map[string][]Permission) (*Policy, error) {
+	grants := make(map[string]map[Action]Resource, len(roles))
+	for role, permissions := range roles {
+		grants[role] = make(map[Action]Resource, len(permissions))
+		for _, permission := range permissions {
+			if !slices.Contains(actions, permission.Action) {
+				return nil, fmt.Errorf("role %s: unknown action %q", role, permission.Action)
+			}
+			if permission.Resource != ResourceOwn && permission.Resource != ResourceAny {
+				return nil, fmt.Errorf("role %s: unknown resource %q", role, permission.Resource)
+			}
+			grants[role][permission.Action] = widest(grants[role][permission.Action], permission.Resource)
+		}
+	}
+
+	if _, ok := grants[defaultRole]; defaultRole != "" && !ok {
+		return nil, fmt.Errorf("default role %s is not defined", defaultRole)
+	}
+
+	return &Policy{defaultRole: defaultRole, grants: grants}, nil
+}
+
+// Grant returns the resource the roles of p may perform action on.
+// It is empty when none of them may.
+func (pl *Policy) Grant(p Principal, action Action) Resource {
+	var granted Resource
+	for _, role := range pl.RolesOf(p) {
+		granted = widest(granted, pl.grants[role][action])
+	}
+	return granted
+}
+
+// RolesOf returns the roles the policy evaluates for p.
+func (pl *Policy) RolesOf(p Principal) []string {
+	if len(p.Roles) == 0 && pl.defaultRole != "" {
+		return []string{pl.defaultRole}
+	}
+	return p.Roles
+}
+
+func widest(a, b Resource) Resource {
+	if a == ResourceAny || b == ResourceAny {
+		return ResourceAny
+	}
+	if a == ResourceOwn || b == ResourceOwn {
+		return ResourceOwn
+	}
+	return ""
+}
+
+// AccessDeniedError describes an action the policy doesn't grant to the caller.
+type AccessDeniedError struct {
+	Action   Action
+	Resource string
+	Roles    []string
+	Reason   string
+}
+
+func (e *AccessDeniedError) Error() string {
+	return fmt.Sprintf("%s on %s denied for roles [%s]: %s",
+		e.Action, e.Resource, strings.Join(e.Roles, ", "), e.Reason)
+}
+
+func (e *AccessDeniedError) Unwrap() error {
+	return ErrForbidden
+}
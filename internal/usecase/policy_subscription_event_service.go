@@ -0,0 +1,32 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+)
+
+// policySubscriptionEventService lets callers watch the subscriptions they may read.
+type policySubscriptionEventService struct {
+	next SubscriptionEventService
+	// guard only checks grants, it never reads subscriptions.
+	guard *policySubscriptionService
+}
+
+func NewPolicySubscriptionEventService(next SubscriptionEventService, policy *Policy) SubscriptionEventService {
+	return &policySubscriptionEventService{next: next, guard: &policySubscriptionService{policy: policy}}
+}
+
+func (s *policySubscriptionEventService) Watch(ctx context.Context, filter dto.SubscriptionEventFilter) (<-chan dto.SubscriptionEventDTO, error) {
+	userID, err := s.guard.scopeUserID(ctx, ActionSubscriptionsRead, filter.UserID)
+	if err != nil {
+		return nil, err
+	}
+	filter.UserID = userID
+
+	return s.next.Watch(ctx, filter)
+}
+
+func (s *policySubscriptionEventService) Close() {
+	s.next.Close()
+}
@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	"github.com/google/uuid"
+)
+
+const (
+	reasonNotGranted = "no role grants the action"
+	reasonNotOwner   = "roles grant the action on own subscriptions only"
+)
+
+// policySubscriptionService authorizes users against the policy before
+// passing calls on. Clients are limited by the scopes of their key instead,
+// and requests without a principal are not restricted.
+type policySubscriptionService struct {
+	next   SubscriptionService
+	policy *Policy
+}
+
+func NewPolicySubscriptionService(next SubscriptionService, policy *Policy) SubscriptionService {
+	return &policySubscriptionService{next: next, policy: policy}
+}
+
+func (s *policySubscriptionService) GetSubscription(ctx context.Context, id uuid.UUID) (dto.SubscriptionDTO, error) {
+	own, err := s.grant(ctx, ActionSubscriptionsRead, subscriptionResource(id))
+	if err != nil {
+		return dto.SubscriptionDTO{}, err
+	}
+
+	sub, err := s.next.GetSubscription(ctx, id)
+	if err != nil {
+		return dto.SubscriptionDTO{}, err
+	}
+	if own {
+		if err := requireOwned(ctx, sub.UserID); err != nil {
+			return dto.SubscriptionDTO{}, err
+		}
+	}
+	return sub, nil
+}
+
+func (s *policySubscriptionService) ListSubscriptions(ctx context.Context, filter dto.SubscriptionFilter) (dto.SubscriptionPageDTO, error) {
+	userID, err := s.scopeUserID(ctx, ActionSubscriptionsRead, filter.UserID)
+	if err != nil {
+		return dto.SubscriptionPageDTO{}, err
+	}
+	filter.UserID = userID
+
+	return s.next.ListSubscriptions(ctx, filter)
+}
+
+func (s *policySubscriptionService) CreateSubscription(ctx context.Context, request dto.CreateSubscriptionCommand) (uuid.UUID, error) {
+	if err := s.authorize(ctx, ActionSubscriptionsCreate, request.UserID); err != nil {
+		return uuid.Nil, err
+	}
+	return s.next.CreateSubscription(ctx, request)
+}
+
+func (s *policySubscriptionService) UpdateSubscription(ctx context.Context, id uuid.UUID, request dto.UpdateSubscriptionCommand) error {
+	if err := s.authorizeSubscription(ctx, ActionSubscriptionsUpdate, id); err != nil {
+		return err
+	}
+	return s.next.UpdateSubscription(ctx, id, request)
+}
+
+func (s *policySubscriptionService) PatchSubscription(ctx context.Context, id uuid.UUID, request dto.PatchSubscriptionCommand) (dto.SubscriptionDTO, error) {
+	if err := s.authorizeSubscription(ctx, ActionSubscriptionsUpdate, id); err != nil {
+		return dto.SubscriptionDTO{}, err
+	}
+	return s.next.PatchSubscription(ctx, id, request)
+}
+
+func (s *policySubscriptionService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	err := s.authorizeSubscription(ctx, ActionSubscriptionsDelete, id)
+	// Deleting a missing subscription succeeds, and so does deleting one of
+	// another user, so that the caller can't tell the two apart.
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.next.DeleteSubscription(ctx, id)
+}
+
+func (s *policySubscriptionService) CalculateTotalCost(ctx context.Context, filter dto.TotalCostFilter) (int, error) {
+	if err := s.authorize(ctx, ActionReportsTotal, filter.UserID); err != nil {
+		return 0, err
+	}
+	return s.next.CalculateTotalCost(ctx, filter)
+}
+
+func (s *policySubscriptionService) SearchSubscriptions(ctx context.Context, filter dto.SubscriptionSearchFilter) ([]dto.SubscriptionDTO, error) {
+	userID, err := s.scopeUserID(ctx, ActionSubscriptionsRead, filter.UserID)
+	if err != nil {
+		return nil, err
+	}
+	filter.UserID = userID
+
+	return s.next.SearchSubscriptions(ctx, filter)
+}
+
+func (s *policySubscriptionService) FindDuplicates(ctx context.Context, userID uuid.UUID) ([]dto.DuplicateGroupDTO, error) {
+	if err := s.authorize(ctx, ActionSubscriptionsRead, userID); err != nil {
+		return nil, err
+	}
+	return s.next.FindDuplicates(ctx, userID)
+}
+
+// MergeSubscriptions requires the caller to update the target and delete the sources.
+func (s *policySubscriptionService) MergeSubscriptions(ctx context.Context, request dto.MergeSubscriptionsCommand) (dto.SubscriptionMergeDTO, error) {
+	if err := s.authorizeSubscription(ctx, ActionSubscriptionsUpdate, request.TargetID); err != nil {
+		return dto.SubscriptionMergeDTO{}, err
+	}
+	for _, id := range request.SourceIDs {
+		if err := s.authorizeSubscription(ctx, ActionSubscriptionsDelete, id); err != nil {
+			return dto.SubscriptionMergeDTO{}, err
+		}
+	}
+	return s.next.MergeSubscriptions(ctx, request)
+}
+
+// authorizeSubscription checks that the caller may perform action on the subscription.
+func (s *policySubscriptionService) authorizeSubscription(ctx context.Context, action Action, id uuid.UUID) error {
+	own, err := s.grant(ctx, action, subscriptionResource(id))
+	if err != nil || !own {
+		return err
+	}
+
+	sub, err := s.next.GetSubscription(ctx, id)
+	if err != nil {
+		return err
+	}
+	return requireOwned(ctx, sub.UserID)
+}
+
+// grant checks that the caller may perform action at all and reports
+// whether it may do so on its own subscriptions only.
+func (s *policySubscriptionService) grant(ctx context.Context, action Action, resource string) (bool, error) {
+	p, ok := PrincipalFrom(ctx)
+	if !ok || p.Client {
+		return false, nil
+	}
+
+	switch s.policy.Grant(p, action) {
+	case ResourceAny:
+		return false, nil
+	case ResourceOwn:
+		return true, nil
+	default:
+		return false, s.deny(p, action, resource, reasonNotGranted)
+	}
+}
+
+func (s *policySubscriptionService) requireOwner(ctx context.Context, action Action, resource string, userID uuid.UUID) error {
+	if p, _ := PrincipalFrom(ctx); !p.Owns(userID) {
+		return s.deny(p, action, resource, reasonNotOwner)
+	}
+	return nil
+}
+
+// requireOwned reports the subscriptions of other users as missing, so that
+// callers acting on their own subscriptions only can't learn of them.
+func requireOwned(ctx context.Context, userID uuid.UUID) error {
+	if p, _ := PrincipalFrom(ctx); !p.Owns(userID) {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// authorize checks that the caller may perform action on the subscriptions of userID.
+func (s *policySubscriptionService) authorize(ctx context.Context, action Action, userID uuid.UUID) error {
+	resource := userResource(userID)
+	own, err := s.grant(ctx, action, resource)
+	if err != nil || !own {
+		return err
+	}
+	return s.requireOwner(ctx, action, resource, userID)
+}
+
+// scopeUserID narrows a user filter down to the caller's own subscriptions
+// unless the caller may perform action on any.
+func (s *policySubscriptionService) scopeUserID(ctx context.Context, action Action, userID *uuid.UUID) (*uuid.UUID, error) {
+	resource := "subscriptions"
+	if userID != nil {
+		resource = userResource(*userID)
+	}
+
+	own, err := s.grant(ctx, action, resource)
+	if err != nil || !own {
+		return userID, err
+	}
+
+	p, _ := PrincipalFrom(ctx)
+	id, ok := p.UserID()
+	if !ok || (userID != nil && *userID != id) {
+		return nil, s.deny(p, action, resource, reasonNotOwner)
+	}
+	return &id, nil
+}
+
+func (s *policySubscriptionService) deny(p Principal, action Action, resource, reason string) error {
+	return &AccessDeniedError{
+		Action:   action,
+		Resource: resource,
+		Roles:    s.policy.RolesOf(p),
+		Reason:   reason,
+	}
+}
+
+func subscriptionResource(id uuid.UUID) string {
+	return "subscriptions/" + id.String()
+}
+
+func userResource(userID uuid.UUID) string {
+	return "users/" + userID.String() + "/subscriptions"
+}
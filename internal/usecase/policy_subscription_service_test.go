@@ -0,0 +1,393 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	mock_usecase "github.com/MDx3R/ef-test/internal/usecase/mocks"
+	"github.com/MDx3R/ef-test/test/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testPolicy(t *testing.T) *usecase.Policy {
+	own := func(actions ...usecase.Action) []usecase.Permission {
+		permissions := make([]usecase.Permission, len(actions))
+		for i, action := range actions {
+			permissions[i] = usecase.Permission{Action: action, Resource: usecase.ResourceOwn}
+		}
+		return permissions
+	}
+
+	policy, err := usecase.NewPolicy(usecase.RoleEditor, map[string][]usecase.Permission{
+		usecase.RoleViewer: own(usecase.ActionSubscriptionsRead, usecase.ActionReportsTotal),
+		usecase.RoleEditor: own(
+			usecase.ActionSubscriptionsRead,
+			usecase.ActionSubscriptionsCreate,
+			usecase.ActionSubscriptionsUpdate,
+			usecase.ActionSubscriptionsDelete,
+			usecase.ActionReportsTotal,
+		),
+		usecase.RoleFinance: append(
+			own(usecase.ActionSubscriptionsRead),
+			usecase.Permission{Action: usecase.ActionReportsTotal, Resource: usecase.ResourceAny},
+		),
+		usecase.RoleAdmin: {
+			{Action: usecase.ActionSubscriptionsRead, Resource: usecase.ResourceAny},
+			{Action: usecase.ActionSubscriptionsCreate, Resource: usecase.ResourceAny},
+			{Action: usecase.ActionSubscriptionsUpdate, Resource: usecase.ResourceAny},
+			{Action: usecase.ActionSubscriptionsDelete, Resource: usecase.ResourceAny},
+			{Action: usecase.ActionReportsTotal, Resource: usecase.ResourceAny},
+		},
+	})
+	require.NoError(t, err)
+	return policy
+}
+
+func setupPolicyService(t *testing.T) (*mock_usecase.MockSubscriptionRepository, usecase.SubscriptionService) {
+	mockRepo := mock_usecase.NewMockSubscriptionRepository(t)
+	service := usecase.NewPolicySubscriptionService(usecase.NewSubscriptionService(mockRepo), testPolicy(t))
+	return mockRepo, service
+}
+
+func userContext(userID uuid.UUID) context.Context {
+	return usecase.WithPrincipal(context.Background(), usecase.Principal{Subject: userID.String()})
+}
+
+func roleContext(userID uuid.UUID, roles ...string) context.Context {
+	return usecase.WithPrincipal(context.Background(), usecase.Principal{Subject: userID.String(), Roles: roles})
+}
+
+func adminContext() context.Context {
+	return usecase.WithPrincipal(context.Background(), usecase.Principal{Subject: "admin", Roles: []string{usecase.RoleAdmin}})
+}
+
+func TestPolicySubscriptionService_GetSubscription_Owner(t *testing.T) {
+	mockRepo, service := setupPolicyService(t)
+
+	sub := testutil.MakeTestSubscription(t)
+
+	mockRepo.On("Get", mock.Anything, sub.ID()).Return(sub, nil)
+
+	resp, err := service.GetSubscription(userContext(sub.UserID()), sub.ID())
+
+	assert.NoError(t, err)
+	assert.Equal(t, sub.ID(), resp.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPolicySubscriptionService_GetSubscription_Foreign_NotFound(t *testing.T) {
+	mockRepo, service := setupPolicyService(t)
+
+	sub := testutil.MakeTestSubscription(t)
+
+	mockRepo.On("Get", mock.Anything, sub.ID()).Return(sub, nil)
+
+	_, err := service.GetSubscription(userContext(uuid.New()), sub.ID())
+
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+	assert.NotErrorIs(t, err, usecase.ErrForbidden)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPolicySubscriptionService_GetSubscription_Admin(t *testing.T) {
+	mockRepo, service := setupPolicyService(t)
+
+	sub := testutil.MakeTestSubscription(t)
+
+	mockRepo.On("Get", mock.Anything, sub.ID()).Return(sub, nil)
+
+	_, err := service.GetSubscription(adminContext(), sub.ID())
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPolicySubscriptionService_ListSubscriptions_ScopedToPrincipal(t *testing.T) {
+	mockRepo, service := setupPolicyService(t)
+
+	userID := uuid.New()
+
+	mockRepo.On("List", mock.Anything, dto.SubscriptionFilter{UserID: &userID}).Return(dto.SubscriptionPage{}, nil)
+
+	_, err := service.ListSubscriptions(userContext(userID), dto.SubscriptionFilter{})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPolicySubscriptionService_ListSubscriptions_ForeignUser_Forbidden(t *testing.T) {
+	_, service := setupPolicyService(t)
+
+	other := uuid.New()
+
+	_, err := service.ListSubscriptions(userContext(uuid.New()), dto.SubscriptionFilter{UserID: &other})
+
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+}
+
+func TestPolicySubscriptionService_ListSubscriptions_NonUUIDSubject_Forbidden(t *testing.T) {
+	_, service := setupPolicyService(t)
+
+	ctx := usecase.WithPrincipal(context.Background(), usecase.Principal{Subject: "batch-job"})
+
+	_, err := service.ListSubscriptions(ctx, dto.SubscriptionFilter{})
+
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+}
+
+func TestPolicySubscriptionService_CreateSubscription_Foreign_Forbidden(t *testing.T) {
+	_, service := setupPolicyService(t)
+
+	request := dto.CreateSubscriptionCommand{
+		ServiceName: "test_service",
+		Price:       100,
+		UserID:      uuid.New(),
+		StartDate:   time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	_, err := service.CreateSubscription(userContext(uuid.New()), request)
+
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+}
+
+func TestPolicySubscriptionService_UpdateSubscription_Foreign_NotFound(t *testing.T) {
+	mockRepo, service := setupPolicyService(t)
+
+	sub := testutil.MakeTestSubscription(t)
+
+	mockRepo.On("Get", mock.Anything, sub.ID()).Return(sub, nil)
+
+	err := service.UpdateSubscription(userContext(uuid.New()), sub.ID(), dto.UpdateSubscriptionCommand{
+		ServiceName: "other",
+		Price:       200,
+		StartDate:   sub.StartDate(),
+	})
+
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestPolicySubscriptionService_PatchSubscription_Foreign_NotFound(t *testing.T) {
+	mockRepo, service := setupPolicyService(t)
+
+	sub := testutil.MakeTestSubscription(t)
+	price := 0
+
+	mockRepo.On("Get", mock.Anything, sub.ID()).Return(sub, nil)
+
+	_, err := service.PatchSubscription(userContext(uuid.New()), sub.ID(), dto.PatchSubscriptionCommand{Price: &price})
+
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestPolicySubscriptionService_DeleteSubscription_Foreign_Skips(t *testing.T) {
+	mockRepo, service := setupPolicyService(t)
+
+	sub := testutil.MakeTestSubscription(t)
+
+	mockRepo.On("Get", mock.Anything, sub.ID()).Return(sub, nil)
+
+	err := service.DeleteSubscription(userContext(uuid.New()), sub.ID())
+
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestPolicySubscriptionService_DeleteSubscription_Owner_NotFound_Skips(t *testing.T) {
+	mockRepo, service := setupPolicyService(t)
+
+	id := uuid.New()
+
+	mockRepo.On("Get", mock.Anything, id).Return(nil, usecase.ErrNotFound)
+
+	err := service.DeleteSubscription(userContext(uuid.New()), id)
+
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestPolicySubscriptionService_CalculateTotalCost_Foreign_Forbidden(t *testing.T) {
+	_, service := setupPolicyService(t)
+
+	_, err := service.CalculateTotalCost(userContext(uuid.New()), dto.TotalCostFilter{UserID: uuid.New()})
+
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+}
+
+func TestPolicySubscriptionService_SearchSubscriptions_ScopedToPrincipal(t *testing.T) {
+	mockRepo, service := setupPolicyService(t)
+
+	userID := uuid.New()
+
+	mockRepo.On("Search", mock.Anything, dto.SubscriptionSearchFilter{Query: "netflix", UserID: &userID, Limit: 20}).Return(nil, nil)
+
+	_, err := service.SearchSubscriptions(userContext(userID), dto.SubscriptionSearchFilter{Query: "netflix", Limit: 20})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPolicySubscriptionService_ListSubscriptions_Client_NotScopedToOwner(t *testing.T) {
+	mockRepo, service := setupPolicyService(t)
+
+	ctx := usecase.WithPrincipal(context.Background(), usecase.Principal{
+		Subject: uuid.NewString(),
+		Client:  true,
+		Scopes:  []string{entity.ScopeSubscriptionsRead},
+	})
+
+	mockRepo.On("List", mock.Anything, dto.SubscriptionFilter{}).Return(dto.SubscriptionPage{}, nil)
+
+	_, err := service.ListSubscriptions(ctx, dto.SubscriptionFilter{})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPolicySubscriptionService_Anonymous_NotRestricted(t *testing.T) {
+	mockRepo, service := setupPolicyService(t)
+
+	other := uuid.New()
+
+	mockRepo.On("List", mock.Anything, dto.SubscriptionFilter{UserID: &other}).Return(dto.SubscriptionPage{}, nil)
+
+	_, err := service.ListSubscriptions(context.Background(), dto.SubscriptionFilter{UserID: &other})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPolicySubscriptionService_CalculateTotalCost_FinanceForeign(t *testing.T) {
+	mockRepo, service := setupPolicyService(t)
+
+	filter := dto.TotalCostFilter{UserID: uuid.New()}
+
+	mockRepo.On("CalculateTotalCost", mock.Anything, filter).Return(300, nil)
+
+	total, err := service.CalculateTotalCost(roleContext(uuid.New(), usecase.RoleFinance), filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 300, total)
+}
+
+func TestPolicySubscriptionService_UpdateSubscription_Finance_Denied(t *testing.T) {
+	mockRepo, service := setupPolicyService(t)
+
+	sub := testutil.MakeTestSubscription(t)
+
+	err := service.UpdateSubscription(roleContext(sub.UserID(), usecase.RoleFinance), sub.ID(), dto.UpdateSubscriptionCommand{
+		ServiceName: "other",
+		Price:       200,
+		StartDate:   sub.StartDate(),
+	})
+
+	var denied *usecase.AccessDeniedError
+	require.ErrorAs(t, err, &denied)
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+	assert.Equal(t, usecase.ActionSubscriptionsUpdate, denied.Action)
+	assert.Equal(t, "subscriptions/"+sub.ID().String(), denied.Resource)
+	assert.Equal(t, []string{usecase.RoleFinance}, denied.Roles)
+	mockRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+}
+
+func TestPolicySubscriptionService_CreateSubscription_Viewer_Denied(t *testing.T) {
+	_, service := setupPolicyService(t)
+
+	userID := uuid.New()
+
+	_, err := service.CreateSubscription(roleContext(userID, usecase.RoleViewer), dto.CreateSubscriptionCommand{
+		ServiceName: "test_service",
+		Price:       100,
+		UserID:      userID,
+		StartDate:   time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+}
+
+func TestPolicySubscriptionService_RolesCombine(t *testing.T) {
+	mockRepo, service := setupPolicyService(t)
+
+	sub := testutil.MakeTestSubscription(t)
+	ctx := roleContext(sub.UserID(), usecase.RoleFinance, usecase.RoleEditor)
+
+	mockRepo.On("Get", mock.Anything, sub.ID()).Return(sub, nil)
+	mockRepo.On("Delete", mock.Anything, sub.ID()).Return(nil)
+
+	err := service.DeleteSubscription(ctx, sub.ID())
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPolicySubscriptionService_UnknownRole_Denied(t *testing.T) {
+	_, service := setupPolicyService(t)
+
+	userID := uuid.New()
+
+	_, err := service.ListSubscriptions(roleContext(userID, "auditor"), dto.SubscriptionFilter{})
+
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+}
+
+func TestPolicySubscriptionService_FindDuplicates_ForeignUser_Forbidden(t *testing.T) {
+	_, service := setupPolicyService(t)
+
+	_, err := service.FindDuplicates(userContext(uuid.New()), uuid.New())
+
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+}
+
+func TestPolicySubscriptionService_FindDuplicates_Owner(t *testing.T) {
+	mockRepo, service := setupPolicyService(t)
+
+	userID := uuid.New()
+
+	mockRepo.On("ListByUser", mock.Anything, userID).Return([]*entity.Subscription{}, nil)
+
+	_, err := service.FindDuplicates(userContext(userID), userID)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPolicySubscriptionService_MergeSubscriptions_ForeignSource_NotFound(t *testing.T) {
+	mockRepo, service := setupPolicyService(t)
+
+	target := testutil.MakeTestSubscription(t)
+	foreign := testutil.MakeTestSubscription(t)
+
+	mockRepo.On("Get", mock.Anything, target.ID()).Return(target, nil)
+	mockRepo.On("Get", mock.Anything, foreign.ID()).Return(foreign, nil)
+
+	_, err := service.MergeSubscriptions(userContext(target.UserID()), dto.MergeSubscriptionsCommand{
+		TargetID:  target.ID(),
+		SourceIDs: []uuid.UUID{foreign.ID()},
+	})
+
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+	mockRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything)
+}
+
+func TestPolicySubscriptionService_MergeSubscriptions_Viewer_Forbidden(t *testing.T) {
+	_, service := setupPolicyService(t)
+
+	userID := uuid.New()
+
+	_, err := service.MergeSubscriptions(roleContext(userID, usecase.RoleViewer), dto.MergeSubscriptionsCommand{
+		TargetID:  uuid.New(),
+		SourceIDs: []uuid.UUID{uuid.New()},
+	})
+
+	var denied *usecase.AccessDeniedError
+	require.ErrorAs(t, err, &denied)
+	assert.Equal(t, usecase.ActionSubscriptionsUpdate, denied.Action)
+}
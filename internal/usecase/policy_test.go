@@ -0,0 +1,49 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy_Grant(t *testing.T) {
+	policy := testPolicy(t)
+	principal := func(roles ...string) usecase.Principal {
+		return usecase.Principal{Subject: uuid.NewString(), Roles: roles}
+	}
+
+	assert.Equal(t, usecase.ResourceAny, policy.Grant(principal(usecase.RoleFinance), usecase.ActionReportsTotal))
+	assert.Equal(t, usecase.ResourceOwn, policy.Grant(principal(usecase.RoleFinance), usecase.ActionSubscriptionsRead))
+	assert.Empty(t, policy.Grant(principal(usecase.RoleFinance), usecase.ActionSubscriptionsDelete))
+	assert.Equal(t, usecase.ResourceOwn, policy.Grant(principal(), usecase.ActionSubscriptionsCreate))
+	assert.Equal(t, usecase.ResourceAny, policy.Grant(principal(usecase.RoleViewer, usecase.RoleFinance), usecase.ActionReportsTotal))
+	assert.Empty(t, policy.Grant(principal("auditor"), usecase.ActionSubscriptionsRead))
+}
+
+func TestNewPolicy_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		defaultRole string
+		roles       map[string][]usecase.Permission
+	}{
+		{"unknown action", "", map[string][]usecase.Permission{
+			"viewer": {{Action: "subscriptions:export", Resource: usecase.ResourceOwn}},
+		}},
+		{"unknown resource", "", map[string][]usecase.Permission{
+			"viewer": {{Action: usecase.ActionSubscriptionsRead, Resource: "team"}},
+		}},
+		{"undefined default role", "editor", map[string][]usecase.Permission{
+			"viewer": {{Action: usecase.ActionSubscriptionsRead, Resource: usecase.ResourceOwn}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := usecase.NewPolicy(tt.defaultRole, tt.roles)
+
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Roles known to the default access policy, see configs/policy.yaml.
+const (
+	RoleViewer  = "viewer"
+	RoleEditor  = "editor"
+	RoleFinance = "finance"
+	RoleAdmin   = "admin"
+)
+
+// Principal is the authenticated caller. Its Subject is the user ID
+// of the subscriptions it owns.
+type Principal struct {
+	Subject string
+	Roles   []string
+	// Tenant is the tenant the credentials were issued for, if any.
+	Tenant string
+	// Client marks service-to-service callers, such as API keys.
+	// Clients are limited by Scopes instead of by the access policy.
+	Client bool
+	Scopes []string
+}
+
+func (p Principal) IsAdmin() bool {
+	return slices.Contains(p.Roles, RoleAdmin)
+}
+
+// HasScope reports whether the principal may perform actions of scope.
+// Scopes only limit clients; users are limited by the access policy.
+func (p Principal) HasScope(scope string) bool {
+	return !p.Client || slices.Contains(p.Scopes, scope)
+}
+
+// UserID returns the subject as a user ID, if it is one.
+func (p Principal) UserID() (uuid.UUID, bool) {
+	id, err := uuid.Parse(p.Subject)
+	return id, err == nil
+}
+
+func (p Principal) Owns(userID uuid.UUID) bool {
+	id, ok := p.UserID()
+	return ok && id == userID
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	"github.com/google/uuid"
+)
+
+// publishingSubscriptionService appends the changes made through it to the
+// event log. Writes succeed even if their events can't be appended.
+type publishingSubscriptionService struct {
+	SubscriptionService
+	subRepo SubscriptionRepository
+	events  SubscriptionEventLog
+}
+
+func NewPublishingSubscriptionService(next SubscriptionService, subRepo SubscriptionRepository, events SubscriptionEventLog) SubscriptionService {
+	return &publishingSubscriptionService{SubscriptionService: next, subRepo: subRepo, events: events}
+}
+
+func (s *publishingSubscriptionService) CreateSubscription(ctx context.Context, request dto.CreateSubscriptionCommand) (uuid.UUID, error) {
+	id, err := s.SubscriptionService.CreateSubscription(ctx, request)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	s.publishStored(ctx, dto.SubscriptionCreated, id)
+	return id, nil
+}
+
+func (s *publishingSubscriptionService) UpdateSubscription(ctx context.Context, id uuid.UUID, request dto.UpdateSubscriptionCommand) error {
+	if err := s.SubscriptionService.UpdateSubscription(ctx, id, request); err != nil {
+		return err
+	}
+	s.publishStored(ctx, dto.SubscriptionUpdated, id)
+	return nil
+}
+
+func (s *publishingSubscriptionService) PatchSubscription(ctx context.Context, id uuid.UUID, request dto.PatchSubscriptionCommand) (dto.SubscriptionDTO, error) {
+	sub, err := s.SubscriptionService.PatchSubscription(ctx, id, request)
+	if err != nil {
+		return dto.SubscriptionDTO{}, err
+	}
+	s.publish(ctx, dto.SubscriptionUpdated, sub)
+	return sub, nil
+}
+
+// DeleteSubscription reports only subscriptions that existed, with their last values.
+func (s *publishingSubscriptionService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	sub, err := s.subRepo.Get(ctx, id)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	if err := s.SubscriptionService.DeleteSubscription(ctx, id); err != nil {
+		return err
+	}
+	if sub != nil {
+		s.publish(ctx, dto.SubscriptionDeleted, dto.FromSubscription(sub))
+	}
+	return nil
+}
+
+// MergeSubscriptions reports the target as updated and the sources as deleted.
+func (s *publishingSubscriptionService) MergeSubscriptions(ctx context.Context, request dto.MergeSubscriptionsCommand) (dto.SubscriptionMergeDTO, error) {
+	sources := make([]*entity.Subscription, 0, len(request.SourceIDs))
+	for _, id := range request.SourceIDs {
+		sub, err := s.subRepo.Get(ctx, id)
+		if err != nil {
+			return dto.SubscriptionMergeDTO{}, err
+		}
+		sources = append(sources, sub)
+	}
+
+	result, err := s.SubscriptionService.MergeSubscriptions(ctx, request)
+	if err != nil {
+		return dto.SubscriptionMergeDTO{}, err
+	}
+
+	s.publish(ctx, dto.SubscriptionUpdated, result.Subscription)
+	for _, sub := range sources {
+		s.publish(ctx, dto.SubscriptionDeleted, dto.FromSubscription(sub))
+	}
+	return result, nil
+}
+
+// publishStored reports the subscription as it was stored.
+func (s *publishingSubscriptionService) publishStored(ctx context.Context, eventType dto.SubscriptionEventType, id uuid.UUID) {
+	sub, err := s.subRepo.Get(ctx, id)
+	if err != nil {
+		requestLogger(ctx).WithError(err).WithField("subscription_id", id).Warn("failed to read subscription for event")
+		return
+	}
+	s.publish(ctx, eventType, dto.FromSubscription(sub))
+}
+
+func (s *publishingSubscriptionService) publish(ctx context.Context, eventType dto.SubscriptionEventType, sub dto.SubscriptionDTO) {
+	event := dto.SubscriptionEventDTO{
+		Type:         eventType,
+		TenantID:     TenantFrom(ctx).ID,
+		Subscription: sub,
+		OccurredAt:   time.Now().UTC(),
+	}
+	if _, err := s.events.Append(ctx, event); err != nil {
+		requestLogger(ctx).WithError(err).WithField("subscription_id", sub.ID).Warn("failed to publish subscription event")
+	}
+}
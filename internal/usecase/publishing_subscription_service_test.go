@@ -0,0 +1,115 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	mock_usecase "github.com/MDx3R/ef-test/internal/usecase/mocks"
+	"github.com/MDx3R/ef-test/test/testutil"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupPublishingService(t *testing.T) (*mock_usecase.MockSubscriptionRepository, *mock_usecase.MockSubscriptionEventLog, usecase.SubscriptionService) {
+	mockRepo := mock_usecase.NewMockSubscriptionRepository(t)
+	mockLog := mock_usecase.NewMockSubscriptionEventLog(t)
+	service := usecase.NewPublishingSubscriptionService(usecase.NewSubscriptionService(mockRepo), mockRepo, mockLog)
+	return mockRepo, mockLog, service
+}
+
+func eventOf(eventType dto.SubscriptionEventType, id uuid.UUID) any {
+	return mock.MatchedBy(func(event dto.SubscriptionEventDTO) bool {
+		return event.Type == eventType && event.Subscription.ID == id && event.TenantID == usecase.DefaultTenantID
+	})
+}
+
+func TestPublishingSubscriptionService_CreateSubscription(t *testing.T) {
+	mockRepo, mockLog, service := setupPublishingService(t)
+
+	sub := testutil.MakeTestSubscription(t)
+	var added uuid.UUID
+	mockRepo.On("Add", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		added = args.Get(1).(*entity.Subscription).ID()
+	})
+	mockRepo.On("Get", mock.Anything, mock.Anything).Return(sub, nil)
+	mockLog.On("Append", mock.Anything, eventOf(dto.SubscriptionCreated, sub.ID())).Return(dto.SubscriptionEventDTO{}, nil)
+
+	id, err := service.CreateSubscription(context.Background(), dto.CreateSubscriptionCommand{
+		ServiceName: "test_service",
+		Price:       100,
+		UserID:      sub.UserID(),
+		StartDate:   sub.StartDate(),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, added, id)
+	mockRepo.AssertCalled(t, "Get", mock.Anything, id)
+	mockLog.AssertExpectations(t)
+}
+
+func TestPublishingSubscriptionService_CreateSubscription_Error_NotPublished(t *testing.T) {
+	mockRepo, mockLog, service := setupPublishingService(t)
+
+	mockRepo.On("Add", mock.Anything, mock.Anything).Return(usecase.ErrRepository)
+
+	_, err := service.CreateSubscription(context.Background(), dto.CreateSubscriptionCommand{
+		ServiceName: "test_service",
+		Price:       100,
+		UserID:      uuid.New(),
+		StartDate:   month(2025, 8),
+	})
+
+	assert.ErrorIs(t, err, usecase.ErrRepository)
+	mockLog.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+}
+
+func TestPublishingSubscriptionService_PatchSubscription_AppendError_Ignored(t *testing.T) {
+	mockRepo, mockLog, service := setupPublishingService(t)
+
+	sub := testutil.MakeTestSubscription(t)
+	price := 0
+	mockRepo.On("Get", mock.Anything, sub.ID()).Return(sub, nil)
+	mockRepo.On("Update", mock.Anything, sub).Return(nil)
+	mockLog.On("Append", mock.Anything, eventOf(dto.SubscriptionUpdated, sub.ID())).
+		Return(dto.SubscriptionEventDTO{}, errors.New("log unavailable"))
+
+	result, err := service.PatchSubscription(context.Background(), sub.ID(), dto.PatchSubscriptionCommand{Price: &price})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Price)
+	mockLog.AssertExpectations(t)
+}
+
+func TestPublishingSubscriptionService_DeleteSubscription(t *testing.T) {
+	mockRepo, mockLog, service := setupPublishingService(t)
+
+	sub := testutil.MakeTestSubscription(t)
+	mockRepo.On("Get", mock.Anything, sub.ID()).Return(sub, nil)
+	mockRepo.On("Delete", mock.Anything, sub.ID()).Return(nil)
+	mockLog.On("Append", mock.Anything, mock.MatchedBy(func(event dto.SubscriptionEventDTO) bool {
+		return event.Type == dto.SubscriptionDeleted && event.Subscription == dto.FromSubscription(sub)
+	})).Return(dto.SubscriptionEventDTO{}, nil)
+
+	err := service.DeleteSubscription(context.Background(), sub.ID())
+
+	assert.NoError(t, err)
+	mockLog.AssertExpectations(t)
+}
+
+func TestPublishingSubscriptionService_DeleteSubscription_Missing_NotPublished(t *testing.T) {
+	mockRepo, mockLog, service := setupPublishingService(t)
+
+	id := uuid.New()
+	mockRepo.On("Get", mock.Anything, id).Return(nil, usecase.ErrNotFound)
+	mockRepo.On("Delete", mock.Anything, id).Return(usecase.ErrNotFound)
+
+	err := service.DeleteSubscription(context.Background(), id)
+
+	assert.NoError(t, err)
+	mockLog.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+}
@@ -1,16 +1,73 @@
 package usecase
 
 import (
+	"context"
+	"time"
+
 	"github.com/MDx3R/ef-test/internal/domain/entity"
 	"github.com/MDx3R/ef-test/internal/usecase/dto"
 	"github.com/google/uuid"
 )
 
+// SubscriptionRepository stores subscriptions of the Tenant in ctx.
+// Subscriptions of other tenants are never read or changed.
 type SubscriptionRepository interface {
-	Get(id uuid.UUID) (*entity.Subscription, error)
-	List(filter dto.SubscriptionFilter) ([]*entity.Subscription, error)
-	Add(sub *entity.Subscription) error
-	Update(sub *entity.Subscription) error
-	Delete(id uuid.UUID) error
-	CalculateTotalCost(filter dto.TotalCostFilter) (int, error)
+	Get(ctx context.Context, id uuid.UUID) (*entity.Subscription, error)
+	List(ctx context.Context, filter dto.SubscriptionFilter) (dto.SubscriptionPage, error)
+	Add(ctx context.Context, sub *entity.Subscription) error
+	Update(ctx context.Context, sub *entity.Subscription) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	CalculateTotalCost(ctx context.Context, filter dto.TotalCostFilter) (int, error)
+	// Search returns subscriptions matching filter.Query, most relevant first.
+	Search(ctx context.Context, filter dto.SubscriptionSearchFilter) ([]*entity.Subscription, error)
+	// ListByUser returns every subscription of the user ordered by (start_date, id).
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Subscription, error)
+	// Merge updates the target, removes the sources and records an audit entry
+	// for each of them, all or nothing. It returns ErrNotFound if any is missing.
+	Merge(ctx context.Context, merge dto.SubscriptionMerge) error
+}
+
+// APIKeyRepository stores API keys. Get and List only see keys of the Tenant in ctx;
+// GetByHash looks across tenants, since a key identifies its tenant.
+type APIKeyRepository interface {
+	Get(ctx context.Context, id uuid.UUID) (*entity.APIKey, error)
+	GetByHash(ctx context.Context, hash string) (*entity.APIKey, error)
+	// List returns every key, including revoked and expired ones, newest first.
+	List(ctx context.Context) ([]*entity.APIKey, error)
+	Add(ctx context.Context, key *entity.APIKey) error
+	// Update returns ErrNotFound if the key doesn't exist in its tenant.
+	Update(ctx context.Context, key *entity.APIKey) error
+	// TouchLastUsed only sets the last usage time of a key that isn't revoked,
+	// so that it can't undo a concurrent revocation. It returns ErrNotFound
+	// if there is no such key in the tenant.
+	TouchLastUsed(ctx context.Context, tenantID string, id uuid.UUID, at time.Time) error
+}
+
+// IdempotencyRepository stores idempotency records of the Tenant in ctx.
+// A record is identified by its owner and key.
+type IdempotencyRepository interface {
+	// Reserve adds record unless a record with the same owner and key exists that
+	// expires after record.CreatedAt. It returns that record and false instead.
+	Reserve(ctx context.Context, record dto.IdempotencyRecord) (dto.IdempotencyRecord, bool, error)
+	// Complete stores the response of a reserved record and keeps it until expiresAt.
+	// It returns ErrNotFound if no record awaits a response.
+	Complete(ctx context.Context, owner, key string, response dto.IdempotentResponse, expiresAt time.Time) error
+	// Release removes a record that awaits a response, so the key may be used again.
+	Release(ctx context.Context, owner, key string) error
+	// DeleteExpired removes records of all tenants that expire by now.
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// SubscriptionEventLog keeps the latest subscription events of all tenants.
+// Older events are discarded once the log is full.
+type SubscriptionEventLog interface {
+	// Append stores the event under the next ID and returns it with the ID set.
+	Append(ctx context.Context, event dto.SubscriptionEventDTO) (dto.SubscriptionEventDTO, error)
+	// After returns the kept events with IDs above id, oldest first.
+	// It reports false if some of them were already discarded.
+	After(ctx context.Context, id uint64) ([]dto.SubscriptionEventDTO, bool, error)
+	// LastID returns the ID of the latest event, or 0 if there was none.
+	LastID(ctx context.Context) (uint64, error)
+	// Changed returns a channel that is closed by the next Append.
+	Changed() <-chan struct{}
 }
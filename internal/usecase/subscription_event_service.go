@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+)
+
+// SubscriptionEventService streams changes of subscriptions of the Tenant in ctx.
+type SubscriptionEventService interface {
+	// Watch sends the events matching the filter until ctx is done or the
+	// service is closed, then closes the channel.
+	Watch(ctx context.Context, filter dto.SubscriptionEventFilter) (<-chan dto.SubscriptionEventDTO, error)
+	// Close ends every watch, so that open streams don't hold up the shutdown.
+	Close()
+}
+
+type subscriptionEventService struct {
+	log  SubscriptionEventLog
+	done chan struct{}
+	once sync.Once
+}
+
+func NewSubscriptionEventService(log SubscriptionEventLog) SubscriptionEventService {
+	return &subscriptionEventService{log: log, done: make(chan struct{})}
+}
+
+func (s *subscriptionEventService) Watch(ctx context.Context, filter dto.SubscriptionEventFilter) (<-chan dto.SubscriptionEventDTO, error) {
+	lastID, err := s.log.LastID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantID := TenantFrom(ctx).ID
+	events := make(chan dto.SubscriptionEventDTO)
+
+	after := lastID
+	var reset bool
+	if filter.LastEventID != nil {
+		after = *filter.LastEventID
+		// IDs start over when the log is lost with a restart.
+		if after > lastID {
+			after, reset = lastID, true
+		}
+	}
+
+	go func() {
+		defer close(events)
+		if reset && !s.send(ctx, events, resetEvent(tenantID, after)) {
+			return
+		}
+		s.watch(ctx, tenantID, filter, after, events)
+	}()
+	return events, nil
+}
+
+func (s *subscriptionEventService) watch(ctx context.Context, tenantID string, filter dto.SubscriptionEventFilter, after uint64, events chan<- dto.SubscriptionEventDTO) {
+	for {
+		// Taken before reading, so that an event appended in between isn't missed.
+		changed := s.log.Changed()
+
+		batch, complete, err := s.log.After(ctx, after)
+		if err != nil {
+			requestLogger(ctx).WithError(err).Error("failed to read subscription events")
+			return
+		}
+		if !complete && len(batch) > 0 {
+			if !s.send(ctx, events, resetEvent(tenantID, batch[0].ID-1)) {
+				return
+			}
+		}
+
+		for _, event := range batch {
+			after = event.ID
+			if event.TenantID != tenantID || !filter.Matches(event) {
+				continue
+			}
+			if !s.send(ctx, events, event) {
+				return
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.done:
+			return
+		case <-changed:
+		}
+	}
+}
+
+// send reports false if the watch ended before the event was taken.
+func (s *subscriptionEventService) send(ctx context.Context, events chan<- dto.SubscriptionEventDTO, event dto.SubscriptionEventDTO) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-s.done:
+		return false
+	case events <- event:
+		return true
+	}
+}
+
+func (s *subscriptionEventService) Close() {
+	s.once.Do(func() { close(s.done) })
+}
+
+// resetEvent carries the ID watching resumes after, so that a reconnect doesn't reset again.
+func resetEvent(tenantID string, after uint64) dto.SubscriptionEventDTO {
+	return dto.SubscriptionEventDTO{
+		ID:         after,
+		Type:       dto.SubscriptionEventsReset,
+		TenantID:   tenantID,
+		OccurredAt: time.Now().UTC(),
+	}
+}
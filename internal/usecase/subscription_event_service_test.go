@@ -0,0 +1,166 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	mock_usecase "github.com/MDx3R/ef-test/internal/usecase/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSubscriptionEventService(t *testing.T, lastID uint64) (*mock_usecase.MockSubscriptionEventLog, usecase.SubscriptionEventService) {
+	mockLog := mock_usecase.NewMockSubscriptionEventLog(t)
+	mockLog.On("LastID", mock.Anything).Return(lastID, nil)
+	// Nothing is appended while the tests watch.
+	mockLog.On("Changed").Return((<-chan struct{})(make(chan struct{}))).Maybe()
+
+	service := usecase.NewSubscriptionEventService(mockLog)
+	t.Cleanup(service.Close)
+	return mockLog, service
+}
+
+func makeEvent(id uint64, tenantID string, userID uuid.UUID, serviceName string) dto.SubscriptionEventDTO {
+	return dto.SubscriptionEventDTO{
+		ID:           id,
+		Type:         dto.SubscriptionUpdated,
+		TenantID:     tenantID,
+		Subscription: dto.SubscriptionDTO{ID: uuid.New(), UserID: userID, ServiceName: serviceName},
+	}
+}
+
+func receiveEvent(t *testing.T, events <-chan dto.SubscriptionEventDTO) dto.SubscriptionEventDTO {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		require.True(t, ok, "events closed")
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+		return dto.SubscriptionEventDTO{}
+	}
+}
+
+func assertEventsClosed(t *testing.T, events <-chan dto.SubscriptionEventDTO) {
+	t.Helper()
+
+	select {
+	case event, ok := <-events:
+		assert.False(t, ok, "unexpected event %d", event.ID)
+	case <-time.After(time.Second):
+		t.Fatal("events not closed")
+	}
+}
+
+func TestSubscriptionEventService_Watch_Filtered(t *testing.T) {
+	mockLog, service := setupSubscriptionEventService(t, 0)
+
+	userID := uuid.New()
+	mockLog.On("After", mock.Anything, uint64(0)).Return([]dto.SubscriptionEventDTO{
+		makeEvent(1, usecase.DefaultTenantID, uuid.New(), "Netflix"),
+		makeEvent(2, "retail", userID, "Netflix"),
+		makeEvent(3, usecase.DefaultTenantID, userID, "Spotify"),
+		makeEvent(4, usecase.DefaultTenantID, userID, "netflix"),
+	}, true, nil).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	name := "NETFLIX"
+	events, err := service.Watch(ctx, dto.SubscriptionEventFilter{UserID: &userID, ServiceName: &name})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(4), receiveEvent(t, events).ID)
+
+	cancel()
+	assertEventsClosed(t, events)
+}
+
+func TestSubscriptionEventService_Watch_FromNow(t *testing.T) {
+	mockLog, service := setupSubscriptionEventService(t, 7)
+
+	mockLog.On("After", mock.Anything, uint64(7)).Return(nil, true, nil).Once()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := service.Watch(ctx, dto.SubscriptionEventFilter{})
+	require.NoError(t, err)
+
+	cancel()
+	assertEventsClosed(t, events)
+}
+
+func TestSubscriptionEventService_Watch_ResumeDiscarded_Reset(t *testing.T) {
+	mockLog, service := setupSubscriptionEventService(t, 5)
+
+	mockLog.On("After", mock.Anything, uint64(1)).Return([]dto.SubscriptionEventDTO{
+		makeEvent(4, usecase.DefaultTenantID, uuid.New(), "Netflix"),
+		makeEvent(5, usecase.DefaultTenantID, uuid.New(), "Netflix"),
+	}, false, nil).Once()
+
+	lastEventID := uint64(1)
+	events, err := service.Watch(context.Background(), dto.SubscriptionEventFilter{LastEventID: &lastEventID})
+	require.NoError(t, err)
+
+	reset := receiveEvent(t, events)
+	assert.Equal(t, dto.SubscriptionEventsReset, reset.Type)
+	assert.Equal(t, uint64(3), reset.ID)
+	assert.Equal(t, uint64(4), receiveEvent(t, events).ID)
+	assert.Equal(t, uint64(5), receiveEvent(t, events).ID)
+}
+
+func TestSubscriptionEventService_Watch_UnknownLastEventID_Reset(t *testing.T) {
+	mockLog, service := setupSubscriptionEventService(t, 2)
+
+	mockLog.On("After", mock.Anything, uint64(2)).Return(nil, true, nil).Once()
+
+	lastEventID := uint64(40)
+	events, err := service.Watch(context.Background(), dto.SubscriptionEventFilter{LastEventID: &lastEventID})
+	require.NoError(t, err)
+
+	reset := receiveEvent(t, events)
+	assert.Equal(t, dto.SubscriptionEventsReset, reset.Type)
+	assert.Equal(t, uint64(2), reset.ID)
+}
+
+func TestSubscriptionEventService_Close_EndsWatch(t *testing.T) {
+	mockLog, service := setupSubscriptionEventService(t, 0)
+
+	mockLog.On("After", mock.Anything, uint64(0)).Return(nil, true, nil).Maybe()
+
+	events, err := service.Watch(context.Background(), dto.SubscriptionEventFilter{})
+	require.NoError(t, err)
+
+	service.Close()
+
+	assertEventsClosed(t, events)
+}
+
+func TestPolicySubscriptionEventService_Watch_ScopedToPrincipal(t *testing.T) {
+	mockEvents := mock_usecase.NewMockSubscriptionEventService(t)
+	service := usecase.NewPolicySubscriptionEventService(mockEvents, testPolicy(t))
+
+	userID := uuid.New()
+	events := make(<-chan dto.SubscriptionEventDTO)
+	mockEvents.On("Watch", mock.Anything, dto.SubscriptionEventFilter{UserID: &userID}).Return(events, nil)
+
+	_, err := service.Watch(userContext(userID), dto.SubscriptionEventFilter{})
+
+	assert.NoError(t, err)
+	mockEvents.AssertExpectations(t)
+}
+
+func TestPolicySubscriptionEventService_Watch_ForeignUser_Forbidden(t *testing.T) {
+	mockEvents := mock_usecase.NewMockSubscriptionEventService(t)
+	service := usecase.NewPolicySubscriptionEventService(mockEvents, testPolicy(t))
+
+	other := uuid.New()
+
+	_, err := service.Watch(userContext(uuid.New()), dto.SubscriptionEventFilter{UserID: &other})
+
+	assert.ErrorIs(t, err, usecase.ErrForbidden)
+	mockEvents.AssertNotCalled(t, "Watch", mock.Anything, mock.Anything)
+}
@@ -1,20 +1,35 @@
 package usecase
 
 import (
+	"context"
 	"errors"
+	"slices"
+	"time"
 
+	"github.com/MDx3R/ef-test/internal/domain"
 	"github.com/MDx3R/ef-test/internal/domain/entity"
 	"github.com/MDx3R/ef-test/internal/usecase/dto"
 	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// SubscriptionService manages subscriptions. It doesn't authorize callers,
+// see NewPolicySubscriptionService.
 type SubscriptionService interface {
-	GetSubscription(id uuid.UUID) (dto.SubscriptionDTO, error)
-	ListSubscriptions(filter dto.SubscriptionFilter) ([]dto.SubscriptionDTO, error)
-	CreateSubscription(request dto.CreateSubscriptionCommand) (uuid.UUID, error)
-	UpdateSubscription(id uuid.UUID, request dto.UpdateSubscriptionCommand) error
-	DeleteSubscription(id uuid.UUID) error
-	CalculateTotalCost(filter dto.TotalCostFilter) (int, error)
+	GetSubscription(ctx context.Context, id uuid.UUID) (dto.SubscriptionDTO, error)
+	ListSubscriptions(ctx context.Context, filter dto.SubscriptionFilter) (dto.SubscriptionPageDTO, error)
+	CreateSubscription(ctx context.Context, request dto.CreateSubscriptionCommand) (uuid.UUID, error)
+	UpdateSubscription(ctx context.Context, id uuid.UUID, request dto.UpdateSubscriptionCommand) error
+	// PatchSubscription changes the fields set in the request and returns the result.
+	PatchSubscription(ctx context.Context, id uuid.UUID, request dto.PatchSubscriptionCommand) (dto.SubscriptionDTO, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	CalculateTotalCost(ctx context.Context, filter dto.TotalCostFilter) (int, error)
+	SearchSubscriptions(ctx context.Context, filter dto.SubscriptionSearchFilter) ([]dto.SubscriptionDTO, error)
+	// FindDuplicates groups subscriptions of the user that look like double entries.
+	FindDuplicates(ctx context.Context, userID uuid.UUID) ([]dto.DuplicateGroupDTO, error)
+	// MergeSubscriptions folds the sources into the target and removes them.
+	MergeSubscriptions(ctx context.Context, request dto.MergeSubscriptionsCommand) (dto.SubscriptionMergeDTO, error)
 }
 
 type subscriptionService struct {
@@ -25,29 +40,48 @@ func NewSubscriptionService(subRepo SubscriptionRepository) SubscriptionService
 	return &subscriptionService{subRepo: subRepo}
 }
 
-func (s *subscriptionService) GetSubscription(id uuid.UUID) (dto.SubscriptionDTO, error) {
-	sub, err := s.subRepo.Get(id)
+func (s *subscriptionService) GetSubscription(ctx context.Context, id uuid.UUID) (_ dto.SubscriptionDTO, err error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.GetSubscription", attribute.String("subscription.id", id.String()))
+	defer endSpan(span, &err)
+
+	sub, err := s.subRepo.Get(ctx, id)
 	if err != nil {
 		return dto.SubscriptionDTO{}, err
 	}
 	return dto.FromSubscription(sub), nil
 }
 
-func (s *subscriptionService) ListSubscriptions(filter dto.SubscriptionFilter) ([]dto.SubscriptionDTO, error) {
-	subs, err := s.subRepo.List(filter)
+func (s *subscriptionService) ListSubscriptions(ctx context.Context, filter dto.SubscriptionFilter) (_ dto.SubscriptionPageDTO, err error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.ListSubscriptions", attribute.Int("page_size", filter.PageSize))
+	defer endSpan(span, &err)
+
+	page, err := s.subRepo.List(ctx, filter)
 	if err != nil {
-		return []dto.SubscriptionDTO{}, err
+		return dto.SubscriptionPageDTO{}, err
 	}
 
-	result := make([]dto.SubscriptionDTO, len(subs))
-	for i, sub := range subs {
-		result[i] = dto.FromSubscription(sub)
+	result := dto.SubscriptionPageDTO{
+		Items:   make([]dto.SubscriptionDTO, len(page.Subscriptions)),
+		HasMore: page.HasMore,
+		Total:   page.Total,
+	}
+	for i, sub := range page.Subscriptions {
+		result.Items[i] = dto.FromSubscription(sub)
+	}
+
+	// A cursor only makes sense for the (start_date, id) order it is built from.
+	if page.HasMore && len(result.Items) > 0 && len(filter.Sort) == 0 {
+		next := dto.CursorOf(result.Items[len(result.Items)-1])
+		result.NextCursor = &next
 	}
 
 	return result, nil
 }
 
-func (s *subscriptionService) CreateSubscription(request dto.CreateSubscriptionCommand) (uuid.UUID, error) {
+func (s *subscriptionService) CreateSubscription(ctx context.Context, request dto.CreateSubscriptionCommand) (_ uuid.UUID, err error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.CreateSubscription", attribute.String("user.id", request.UserID.String()))
+	defer endSpan(span, &err)
+
 	sub, err := entity.NewSubscription(
 		request.ServiceName,
 		request.UserID,
@@ -58,40 +92,231 @@ func (s *subscriptionService) CreateSubscription(request dto.CreateSubscriptionC
 	if err != nil {
 		return uuid.Nil, err
 	}
+	sub.SetNotes(request.Notes)
 
-	if err := s.subRepo.Add(sub); err != nil {
+	if err := s.subRepo.Add(ctx, sub); err != nil {
 		return uuid.Nil, err
 	}
+	requestLogger(ctx).WithField("subscription_id", sub.ID()).Debug("subscription stored")
 	return sub.ID(), nil
 }
 
-func (s *subscriptionService) UpdateSubscription(id uuid.UUID, request dto.UpdateSubscriptionCommand) error {
-	sub, err := s.subRepo.Get(id)
+func (s *subscriptionService) UpdateSubscription(ctx context.Context, id uuid.UUID, request dto.UpdateSubscriptionCommand) (err error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.UpdateSubscription", attribute.String("subscription.id", id.String()))
+	defer endSpan(span, &err)
+
+	sub, err := s.subRepo.Get(ctx, id)
 	if err != nil {
 		return err
 	}
 
 	sub.SetServiceName(request.ServiceName)
 	sub.SetPrice(request.Price)
+	if request.Notes != nil {
+		sub.SetNotes(*request.Notes)
+	}
 	if err := sub.SetStartEndDate(request.StartDate, request.EndDate); err != nil {
 		return err
 	}
 
-	if err := s.subRepo.Update(sub); err != nil {
+	if err := s.subRepo.Update(ctx, sub); err != nil {
 		return err
 	}
+	requestLogger(ctx).WithField("subscription_id", id).Debug("subscription stored")
 
 	return nil
 }
 
-func (s *subscriptionService) DeleteSubscription(id uuid.UUID) error {
-	err := s.subRepo.Delete(id)
-	if err != nil && !errors.Is(err, ErrNotFound) {
-		return err
+func (s *subscriptionService) PatchSubscription(ctx context.Context, id uuid.UUID, request dto.PatchSubscriptionCommand) (_ dto.SubscriptionDTO, err error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.PatchSubscription", attribute.String("subscription.id", id.String()))
+	defer endSpan(span, &err)
+
+	sub, err := s.subRepo.Get(ctx, id)
+	if err != nil {
+		return dto.SubscriptionDTO{}, err
 	}
-	return nil
+
+	if request.ServiceName != nil {
+		sub.SetServiceName(*request.ServiceName)
+	}
+	if request.Price != nil {
+		sub.SetPrice(*request.Price)
+	}
+	if request.Notes != nil {
+		sub.SetNotes(*request.Notes)
+	}
+
+	// Both dates are checked together, so that a patch may move the period
+	// past its old bounds in one go.
+	startDate, endDate := sub.StartDate(), sub.EndDate()
+	if request.StartDate != nil {
+		startDate = *request.StartDate
+	}
+	if request.ClearEndDate {
+		endDate = nil
+	} else if request.EndDate != nil {
+		endDate = request.EndDate
+	}
+	if err := sub.SetStartEndDate(startDate, endDate); err != nil {
+		return dto.SubscriptionDTO{}, err
+	}
+
+	if err := s.subRepo.Update(ctx, sub); err != nil {
+		return dto.SubscriptionDTO{}, err
+	}
+	requestLogger(ctx).WithField("subscription_id", id).Debug("subscription stored")
+
+	return dto.FromSubscription(sub), nil
 }
 
-func (s *subscriptionService) CalculateTotalCost(filter dto.TotalCostFilter) (int, error) {
-	return s.subRepo.CalculateTotalCost(filter)
+func (s *subscriptionService) DeleteSubscription(ctx context.Context, id uuid.UUID) (err error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.DeleteSubscription", attribute.String("subscription.id", id.String()))
+	defer endSpan(span, &err)
+
+	err = s.subRepo.Delete(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		// Deleting is idempotent, a missing subscription counts as deleted.
+		requestLogger(ctx).WithField("subscription_id", id).Debug("subscription already deleted")
+		return nil
+	}
+	return err
+}
+
+func (s *subscriptionService) CalculateTotalCost(ctx context.Context, filter dto.TotalCostFilter) (_ int, err error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.CalculateTotalCost", attribute.String("user.id", filter.UserID.String()))
+	defer endSpan(span, &err)
+
+	return s.subRepo.CalculateTotalCost(ctx, filter)
+}
+
+func (s *subscriptionService) SearchSubscriptions(ctx context.Context, filter dto.SubscriptionSearchFilter) (_ []dto.SubscriptionDTO, err error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.SearchSubscriptions", attribute.Int("limit", filter.Limit))
+	defer endSpan(span, &err)
+
+	subs, err := s.subRepo.Search(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.SubscriptionDTO, len(subs))
+	for i, sub := range subs {
+		result[i] = dto.FromSubscription(sub)
+	}
+	return result, nil
+}
+
+func (s *subscriptionService) FindDuplicates(ctx context.Context, userID uuid.UUID) (_ []dto.DuplicateGroupDTO, err error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.FindDuplicates", attribute.String("user.id", userID.String()))
+	defer endSpan(span, &err)
+
+	subs, err := s.subRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]dto.DuplicateGroupDTO, 0)
+	for _, group := range groupDuplicates(subs) {
+		result := dto.DuplicateGroupDTO{
+			ServiceName:   group[0].ServiceName(),
+			Subscriptions: make([]dto.SubscriptionDTO, len(group)),
+		}
+		for i, sub := range group {
+			result.Subscriptions[i] = dto.FromSubscription(sub)
+		}
+		groups = append(groups, result)
+	}
+	return groups, nil
+}
+
+// groupDuplicates splits subscriptions ordered by start date into groups of
+// one service whose periods overlap, directly or through other members.
+// Subscriptions without duplicates are left out.
+func groupDuplicates(subs []*entity.Subscription) [][]*entity.Subscription {
+	type openGroup struct {
+		index int
+		// end is the latest end date of the members, nil if one never ends.
+		end *time.Time
+	}
+
+	var groups [][]*entity.Subscription
+	open := make(map[string]*openGroup)
+	for _, sub := range subs {
+		name := entity.NormalizeServiceName(sub.ServiceName())
+
+		g, ok := open[name]
+		if !ok || (g.end != nil && sub.StartDate().After(*g.end)) {
+			groups = append(groups, nil)
+			g = &openGroup{index: len(groups) - 1, end: sub.EndDate()}
+			open[name] = g
+		} else if g.end != nil && (sub.EndDate() == nil || sub.EndDate().After(*g.end)) {
+			g.end = sub.EndDate()
+		}
+		groups[g.index] = append(groups[g.index], sub)
+	}
+
+	return slices.DeleteFunc(groups, func(group []*entity.Subscription) bool {
+		return len(group) < 2
+	})
+}
+
+func (s *subscriptionService) MergeSubscriptions(ctx context.Context, request dto.MergeSubscriptionsCommand) (_ dto.SubscriptionMergeDTO, err error) {
+	ctx, span := startSpan(ctx, "SubscriptionService.MergeSubscriptions",
+		attribute.String("subscription.id", request.TargetID.String()),
+		attribute.Int("merge.sources", len(request.SourceIDs)),
+	)
+	defer endSpan(span, &err)
+
+	if len(request.SourceIDs) == 0 || hasDuplicateIDs(request.SourceIDs) {
+		return dto.SubscriptionMergeDTO{}, domain.ErrInvalidMerge
+	}
+
+	target, err := s.subRepo.Get(ctx, request.TargetID)
+	if err != nil {
+		return dto.SubscriptionMergeDTO{}, err
+	}
+
+	sources := make([]*entity.Subscription, len(request.SourceIDs))
+	for i, id := range request.SourceIDs {
+		source, err := s.subRepo.Get(ctx, id)
+		if err != nil {
+			return dto.SubscriptionMergeDTO{}, err
+		}
+		if err := target.Absorb(source); err != nil {
+			return dto.SubscriptionMergeDTO{}, err
+		}
+		sources[i] = source
+	}
+
+	p, _ := PrincipalFrom(ctx)
+	merge := dto.SubscriptionMerge{
+		Target:   target,
+		Sources:  sources,
+		MergedBy: p.Subject,
+		MergedAt: time.Now().UTC(),
+	}
+	if err := s.subRepo.Merge(ctx, merge); err != nil {
+		return dto.SubscriptionMergeDTO{}, err
+	}
+	requestLogger(ctx).WithFields(logrus.Fields{
+		"subscription_id": target.ID(),
+		"merged_ids":      request.SourceIDs,
+	}).Info("subscriptions merged")
+
+	return dto.SubscriptionMergeDTO{
+		Subscription: dto.FromSubscription(target),
+		MergedIDs:    request.SourceIDs,
+		MergedBy:     merge.MergedBy,
+		MergedAt:     merge.MergedAt,
+	}, nil
+}
+
+func hasDuplicateIDs(ids []uuid.UUID) bool {
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			return true
+		}
+		seen[id] = struct{}{}
+	}
+	return false
 }
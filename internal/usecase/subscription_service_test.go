@@ -1,16 +1,21 @@
 package usecase_test
 
 import (
+	"context"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/MDx3R/ef-test/internal/domain"
 	"github.com/MDx3R/ef-test/internal/domain/entity"
 	"github.com/MDx3R/ef-test/internal/usecase"
 	"github.com/MDx3R/ef-test/internal/usecase/dto"
 	mock_usecase "github.com/MDx3R/ef-test/internal/usecase/mocks"
+	"github.com/MDx3R/ef-test/test/testutil"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func setupSubscriptionService(t *testing.T) (*mock_usecase.MockSubscriptionRepository, usecase.SubscriptionService) {
@@ -19,29 +24,15 @@ func setupSubscriptionService(t *testing.T) (*mock_usecase.MockSubscriptionRepos
 	return mockRepo, service
 }
 
-func makeTestSubscription(t *testing.T) *entity.Subscription {
-	id := uuid.New()
-	sub, _ := entity.NewSubscriptionWithID(
-		id,
-		"test_service",
-		uuid.New(),
-		100,
-		time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
-		nil,
-	)
-
-	return sub
-}
-
 func TestSubscriptionService_GetSubscription(t *testing.T) {
 	mockRepo, service := setupSubscriptionService(t)
 
-	sub := makeTestSubscription(t)
+	sub := testutil.MakeTestSubscription(t)
 	id := sub.ID()
 
-	mockRepo.On("Get", id).Return(sub, nil)
+	mockRepo.On("Get", mock.Anything, id).Return(sub, nil)
 
-	resp, err := service.GetSubscription(id)
+	resp, err := service.GetSubscription(context.Background(), id)
 
 	assert.NoError(t, err)
 	assert.Equal(t, id, resp.ID)
@@ -56,12 +47,12 @@ func TestSubscriptionService_GetSubscription(t *testing.T) {
 func TestSubscriptionService_GetSubscription_NotFound(t *testing.T) {
 	mockRepo, service := setupSubscriptionService(t)
 
-	sub := makeTestSubscription(t)
+	sub := testutil.MakeTestSubscription(t)
 	id := sub.ID()
 
-	mockRepo.On("Get", id).Return(nil, usecase.ErrNotFound)
+	mockRepo.On("Get", mock.Anything, id).Return(nil, usecase.ErrNotFound)
 
-	_, err := service.GetSubscription(id)
+	_, err := service.GetSubscription(context.Background(), id)
 
 	assert.Error(t, err)
 	mockRepo.AssertExpectations(t)
@@ -71,20 +62,61 @@ func TestSubscriptionService_ListSubscriptions(t *testing.T) {
 	mockRepo, service := setupSubscriptionService(t)
 
 	subs := []*entity.Subscription{
-		makeTestSubscription(t),
-		makeTestSubscription(t),
+		testutil.MakeTestSubscription(t),
+		testutil.MakeTestSubscription(t),
 	}
 
 	filter := dto.SubscriptionFilter{}
 
-	mockRepo.On("List", filter).Return(subs, nil)
+	mockRepo.On("List", mock.Anything, filter).Return(dto.SubscriptionPage{Subscriptions: subs}, nil)
+
+	resp, err := service.ListSubscriptions(context.Background(), filter)
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Items, 2)
+	assert.Equal(t, subs[0].ID(), resp.Items[0].ID)
+	assert.Equal(t, subs[1].ID(), resp.Items[1].ID)
+	assert.False(t, resp.HasMore)
+	assert.Nil(t, resp.NextCursor)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSubscriptionService_ListSubscriptions_HasMore(t *testing.T) {
+	mockRepo, service := setupSubscriptionService(t)
+
+	subs := []*entity.Subscription{
+		testutil.MakeTestSubscription(t),
+		testutil.MakeTestSubscription(t),
+	}
+
+	filter := dto.SubscriptionFilter{PageSize: 2}
+
+	mockRepo.On("List", mock.Anything, filter).Return(dto.SubscriptionPage{Subscriptions: subs, HasMore: true}, nil)
+
+	resp, err := service.ListSubscriptions(context.Background(), filter)
+
+	assert.NoError(t, err)
+	assert.True(t, resp.HasMore)
+	assert.Equal(t, &dto.Cursor{StartDate: subs[1].StartDate(), ID: subs[1].ID()}, resp.NextCursor)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSubscriptionService_ListSubscriptions_SortedHasNoCursor(t *testing.T) {
+	mockRepo, service := setupSubscriptionService(t)
+
+	subs := []*entity.Subscription{testutil.MakeTestSubscription(t)}
+	total := 3
 
-	resp, err := service.ListSubscriptions(filter)
+	filter := dto.SubscriptionFilter{Sort: []dto.SortOrder{{Field: dto.SortByPrice}}, PageSize: 1, IncludeTotal: true}
+
+	mockRepo.On("List", mock.Anything, filter).Return(dto.SubscriptionPage{Subscriptions: subs, HasMore: true, Total: &total}, nil)
+
+	resp, err := service.ListSubscriptions(context.Background(), filter)
 
 	assert.NoError(t, err)
-	assert.Len(t, resp, 2)
-	assert.Equal(t, subs[0].ID(), resp[0].ID)
-	assert.Equal(t, subs[1].ID(), resp[1].ID)
+	assert.True(t, resp.HasMore)
+	assert.Nil(t, resp.NextCursor)
+	assert.Equal(t, &total, resp.Total)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -93,12 +125,12 @@ func TestSubscriptionService_ListSubscriptions_Error(t *testing.T) {
 
 	filter := dto.SubscriptionFilter{}
 
-	mockRepo.On("List", filter).Return(nil, usecase.ErrRepository)
+	mockRepo.On("List", mock.Anything, filter).Return(dto.SubscriptionPage{}, usecase.ErrRepository)
 
-	resp, err := service.ListSubscriptions(filter)
+	resp, err := service.ListSubscriptions(context.Background(), filter)
 
 	assert.Error(t, err)
-	assert.Empty(t, resp)
+	assert.Empty(t, resp.Items)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -114,9 +146,9 @@ func TestSubscriptionService_CreateSubscriptions(t *testing.T) {
 		EndDate:     &endDate,
 	}
 
-	mockRepo.On("Add", mock.AnythingOfType("*entity.Subscription")).Return(nil)
+	mockRepo.On("Add", mock.Anything, mock.AnythingOfType("*entity.Subscription")).Return(nil)
 
-	id, err := service.CreateSubscription(req)
+	id, err := service.CreateSubscription(context.Background(), req)
 
 	assert.NoError(t, err)
 	assert.NotEqual(t, uuid.Nil, id)
@@ -128,9 +160,9 @@ func TestSubscriptionService_CreateSubscriptions_Error(t *testing.T) {
 
 	req := dto.CreateSubscriptionCommand{}
 
-	mockRepo.On("Add", mock.AnythingOfType("*entity.Subscription")).Return(usecase.ErrRepository)
+	mockRepo.On("Add", mock.Anything, mock.AnythingOfType("*entity.Subscription")).Return(usecase.ErrRepository)
 
-	id, err := service.CreateSubscription(req)
+	id, err := service.CreateSubscription(context.Background(), req)
 
 	assert.Error(t, err)
 	assert.Equal(t, uuid.Nil, id)
@@ -140,7 +172,7 @@ func TestSubscriptionService_CreateSubscriptions_Error(t *testing.T) {
 func TestSubscriptionService_UpdateSubscriptions(t *testing.T) {
 	mockRepo, service := setupSubscriptionService(t)
 
-	sub := makeTestSubscription(t)
+	sub := testutil.MakeTestSubscription(t)
 	id := sub.ID()
 
 	startDate := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
@@ -152,8 +184,8 @@ func TestSubscriptionService_UpdateSubscriptions(t *testing.T) {
 		EndDate:     &endDate,
 	}
 
-	mockRepo.On("Get", id).Return(sub, nil)
-	mockRepo.On("Update", mock.MatchedBy(func(u *entity.Subscription) bool {
+	mockRepo.On("Get", mock.Anything, id).Return(sub, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.Subscription) bool {
 		return (u.ID() == sub.ID() &&
 			u.ServiceName() == req.ServiceName &&
 			u.UserID() == sub.UserID() &&
@@ -161,7 +193,31 @@ func TestSubscriptionService_UpdateSubscriptions(t *testing.T) {
 			time.Time.Equal(*u.EndDate(), *req.EndDate))
 	})).Return(nil)
 
-	err := service.UpdateSubscription(id, req)
+	err := service.UpdateSubscription(context.Background(), id, req)
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSubscriptionService_UpdateSubscriptions_NotesOmitted(t *testing.T) {
+	mockRepo, service := setupSubscriptionService(t)
+
+	sub := testutil.MakeTestSubscription(t)
+	sub.SetNotes("family")
+	id := sub.ID()
+
+	req := dto.UpdateSubscriptionCommand{
+		ServiceName: "updated_name",
+		Price:       150,
+		StartDate:   sub.StartDate(),
+	}
+
+	mockRepo.On("Get", mock.Anything, id).Return(sub, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.Subscription) bool {
+		return u.ServiceName() == req.ServiceName && u.Notes() == "family"
+	})).Return(nil)
+
+	err := service.UpdateSubscription(context.Background(), id, req)
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
@@ -170,14 +226,14 @@ func TestSubscriptionService_UpdateSubscriptions(t *testing.T) {
 func TestSubscriptionService_UpdateSubscriptions_GetError(t *testing.T) {
 	mockRepo, service := setupSubscriptionService(t)
 
-	sub := makeTestSubscription(t)
+	sub := testutil.MakeTestSubscription(t)
 	id := sub.ID()
 
 	req := dto.UpdateSubscriptionCommand{}
 
-	mockRepo.On("Get", id).Return(nil, usecase.ErrNotFound)
+	mockRepo.On("Get", mock.Anything, id).Return(nil, usecase.ErrNotFound)
 
-	err := service.UpdateSubscription(id, req)
+	err := service.UpdateSubscription(context.Background(), id, req)
 
 	assert.Error(t, err)
 	mockRepo.AssertExpectations(t)
@@ -186,28 +242,112 @@ func TestSubscriptionService_UpdateSubscriptions_GetError(t *testing.T) {
 func TestSubscriptionService_UpdateSubscriptions_UpdateError(t *testing.T) {
 	mockRepo, service := setupSubscriptionService(t)
 
-	sub := makeTestSubscription(t)
+	sub := testutil.MakeTestSubscription(t)
 	id := sub.ID()
 
 	req := dto.UpdateSubscriptionCommand{}
 
-	mockRepo.On("Get", id).Return(sub, nil)
-	mockRepo.On("Update", mock.Anything).Return(usecase.ErrRepository)
+	mockRepo.On("Get", mock.Anything, id).Return(sub, nil)
+	mockRepo.On("Update", mock.Anything, mock.Anything).Return(usecase.ErrRepository)
 
-	err := service.UpdateSubscription(id, req)
+	err := service.UpdateSubscription(context.Background(), id, req)
 
 	assert.Error(t, err)
 	mockRepo.AssertExpectations(t)
 }
 
+func TestSubscriptionService_PatchSubscription(t *testing.T) {
+	mockRepo, service := setupSubscriptionService(t)
+
+	sub := testutil.MakeTestSubscription(t)
+	id := sub.ID()
+
+	price := 0
+	req := dto.PatchSubscriptionCommand{Price: &price}
+
+	mockRepo.On("Get", mock.Anything, id).Return(sub, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *entity.Subscription) bool {
+		return u.Price() == 0 &&
+			u.ServiceName() == "test_service" &&
+			u.StartDate().Equal(time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)) &&
+			u.EndDate() == nil
+	})).Return(nil)
+
+	result, err := service.PatchSubscription(context.Background(), id, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Price)
+	assert.Equal(t, "test_service", result.ServiceName)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSubscriptionService_PatchSubscription_EndDate(t *testing.T) {
+	endDate := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	earlier := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	later := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		current  *time.Time
+		request  dto.PatchSubscriptionCommand
+		expected *time.Time
+		wantErr  error
+	}{
+		{"kept when absent", &endDate, dto.PatchSubscriptionCommand{}, &endDate, nil},
+		{"set", nil, dto.PatchSubscriptionCommand{EndDate: &endDate}, &endDate, nil},
+		{"cleared", &endDate, dto.PatchSubscriptionCommand{ClearEndDate: true}, nil, nil},
+		{"clear wins over set", &endDate, dto.PatchSubscriptionCommand{EndDate: &endDate, ClearEndDate: true}, nil, nil},
+		{"before start", nil, dto.PatchSubscriptionCommand{EndDate: &earlier}, nil, domain.ErrInvalidPeriod},
+		{"start after kept end", &endDate, dto.PatchSubscriptionCommand{StartDate: &later}, nil, domain.ErrInvalidPeriod},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo, service := setupSubscriptionService(t)
+
+			sub, err := entity.NewSubscriptionWithID(uuid.New(), "test_service", uuid.New(), 100,
+				time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), tt.current)
+			require.NoError(t, err)
+
+			mockRepo.On("Get", mock.Anything, sub.ID()).Return(sub, nil)
+			if tt.wantErr == nil {
+				mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			}
+
+			result, err := service.PatchSubscription(context.Background(), sub.ID(), tt.request)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result.EndDate)
+		})
+	}
+}
+
+func TestSubscriptionService_PatchSubscription_GetError(t *testing.T) {
+	mockRepo, service := setupSubscriptionService(t)
+
+	id := uuid.New()
+
+	mockRepo.On("Get", mock.Anything, id).Return(nil, usecase.ErrNotFound)
+
+	_, err := service.PatchSubscription(context.Background(), id, dto.PatchSubscriptionCommand{})
+
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestSubscriptionService_DeleteSubscription(t *testing.T) {
 	mockRepo, service := setupSubscriptionService(t)
 
 	id := uuid.New()
 
-	mockRepo.On("Delete", id).Return(nil)
+	mockRepo.On("Delete", mock.Anything, id).Return(nil)
 
-	err := service.DeleteSubscription(id)
+	err := service.DeleteSubscription(context.Background(), id)
 
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
@@ -218,9 +358,9 @@ func TestSubscriptionService_DeleteSubscription_Error(t *testing.T) {
 
 	id := uuid.New()
 
-	mockRepo.On("Delete", id).Return(usecase.ErrRepository)
+	mockRepo.On("Delete", mock.Anything, id).Return(usecase.ErrRepository)
 
-	err := service.DeleteSubscription(id)
+	err := service.DeleteSubscription(context.Background(), id)
 
 	assert.Error(t, err)
 	mockRepo.AssertExpectations(t)
@@ -231,11 +371,44 @@ func TestSubscriptionService_DeleteSubscription_NotFound_Skips(t *testing.T) {
 
 	id := uuid.New()
 
-	mockRepo.On("Delete", id).Return(usecase.ErrNotFound)
+	mockRepo.On("Delete", mock.Anything, id).Return(usecase.ErrNotFound)
+
+	err := service.DeleteSubscription(context.Background(), id)
 
-	err := service.DeleteSubscription(id)
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSubscriptionService_SearchSubscriptions(t *testing.T) {
+	mockRepo, service := setupSubscriptionService(t)
+
+	sub := testutil.MakeTestSubscription(t)
+	sub.SetNotes("family plan")
+
+	filter := dto.SubscriptionSearchFilter{Query: "family", Limit: 20}
+
+	mockRepo.On("Search", mock.Anything, filter).Return([]*entity.Subscription{sub}, nil)
+
+	resp, err := service.SearchSubscriptions(context.Background(), filter)
 
 	assert.NoError(t, err)
+	assert.Len(t, resp, 1)
+	assert.Equal(t, sub.ID(), resp[0].ID)
+	assert.Equal(t, "family plan", resp[0].Notes)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSubscriptionService_SearchSubscriptions_Error(t *testing.T) {
+	mockRepo, service := setupSubscriptionService(t)
+
+	filter := dto.SubscriptionSearchFilter{Query: "family", Limit: 20}
+
+	mockRepo.On("Search", mock.Anything, filter).Return(nil, usecase.ErrRepository)
+
+	resp, err := service.SearchSubscriptions(context.Background(), filter)
+
+	assert.Error(t, err)
+	assert.Nil(t, resp)
 	mockRepo.AssertExpectations(t)
 }
 
@@ -251,9 +424,9 @@ func TestSubscriptionService_CalculateTotalCost(t *testing.T) {
 		PeriodEnd:   endDate,
 	}
 
-	mockRepo.On("CalculateTotalCost", filter).Return(150, nil)
+	mockRepo.On("CalculateTotalCost", mock.Anything, filter).Return(150, nil)
 
-	result, err := service.CalculateTotalCost(filter)
+	result, err := service.CalculateTotalCost(context.Background(), filter)
 
 	assert.NoError(t, err)
 	assert.Equal(t, 150, result)
@@ -265,11 +438,209 @@ func TestSubscriptionService_CalculateTotalCost_Error(t *testing.T) {
 
 	filter := dto.TotalCostFilter{}
 
-	mockRepo.On("CalculateTotalCost", filter).Return(0, usecase.ErrRepository)
+	mockRepo.On("CalculateTotalCost", mock.Anything, filter).Return(0, usecase.ErrRepository)
 
-	result, err := service.CalculateTotalCost(filter)
+	result, err := service.CalculateTotalCost(context.Background(), filter)
 
 	assert.Error(t, err)
 	assert.Equal(t, 0, result)
 	mockRepo.AssertExpectations(t)
 }
+
+func makeUserSubscription(t *testing.T, serviceName string, userID uuid.UUID, start time.Time, end *time.Time) *entity.Subscription {
+	sub, err := entity.NewSubscription(serviceName, userID, 100, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sub
+}
+
+func month(year int, m time.Month) time.Time {
+	return time.Date(year, m, 1, 0, 0, 0, 0, time.UTC)
+}
+
+func monthPtr(year int, m time.Month) *time.Time {
+	t := month(year, m)
+	return &t
+}
+
+func TestSubscriptionService_FindDuplicates(t *testing.T) {
+	mockRepo, service := setupSubscriptionService(t)
+
+	userID := uuid.New()
+	first := makeUserSubscription(t, "Yandex Plus", userID, month(2025, 1), monthPtr(2025, 3))
+	other := makeUserSubscription(t, "Netflix", userID, month(2025, 1), nil)
+	// Overlaps the first one only through the third.
+	second := makeUserSubscription(t, "yandex-plus", userID, month(2025, 2), monthPtr(2025, 2))
+	third := makeUserSubscription(t, "YANDEX PLUS", userID, month(2025, 3), monthPtr(2025, 6))
+	separate := makeUserSubscription(t, "Yandex Plus", userID, month(2025, 8), nil)
+
+	mockRepo.On("ListByUser", mock.Anything, userID).
+		Return([]*entity.Subscription{first, other, second, third, separate}, nil)
+
+	groups, err := service.FindDuplicates(context.Background(), userID)
+
+	assert.NoError(t, err)
+	if assert.Len(t, groups, 1) {
+		assert.Equal(t, "Yandex Plus", groups[0].ServiceName)
+		ids := make([]uuid.UUID, len(groups[0].Subscriptions))
+		for i, sub := range groups[0].Subscriptions {
+			ids[i] = sub.ID
+		}
+		assert.Equal(t, []uuid.UUID{first.ID(), second.ID(), third.ID()}, ids)
+	}
+}
+
+func TestSubscriptionService_FindDuplicates_OpenEndedCoversLater(t *testing.T) {
+	mockRepo, service := setupSubscriptionService(t)
+
+	userID := uuid.New()
+	open := makeUserSubscription(t, "Netflix", userID, month(2024, 1), nil)
+	later := makeUserSubscription(t, "Netflix", userID, month(2025, 6), monthPtr(2025, 7))
+
+	mockRepo.On("ListByUser", mock.Anything, userID).Return([]*entity.Subscription{open, later}, nil)
+
+	groups, err := service.FindDuplicates(context.Background(), userID)
+
+	assert.NoError(t, err)
+	assert.Len(t, groups, 1)
+}
+
+func TestSubscriptionService_FindDuplicates_None(t *testing.T) {
+	mockRepo, service := setupSubscriptionService(t)
+
+	userID := uuid.New()
+	mockRepo.On("ListByUser", mock.Anything, userID).Return([]*entity.Subscription{
+		makeUserSubscription(t, "Netflix", userID, month(2025, 1), monthPtr(2025, 2)),
+		makeUserSubscription(t, "Netflix", userID, month(2025, 3), nil),
+	}, nil)
+
+	groups, err := service.FindDuplicates(context.Background(), userID)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, groups)
+	assert.Empty(t, groups)
+}
+
+func TestSubscriptionService_MergeSubscriptions(t *testing.T) {
+	mockRepo, service := setupSubscriptionService(t)
+
+	userID := uuid.New()
+	target := makeUserSubscription(t, "Netflix", userID, month(2025, 3), monthPtr(2025, 6))
+	target.SetNotes("family")
+	source := makeUserSubscription(t, "netflix", userID, month(2025, 1), monthPtr(2025, 4))
+	source.SetNotes("promo")
+
+	mockRepo.On("Get", mock.Anything, target.ID()).Return(target, nil)
+	mockRepo.On("Get", mock.Anything, source.ID()).Return(source, nil)
+	mockRepo.On("Merge", mock.Anything, mock.MatchedBy(func(m dto.SubscriptionMerge) bool {
+		return m.Target.ID() == target.ID() && len(m.Sources) == 1 && m.Sources[0].ID() == source.ID() &&
+			m.MergedBy == "alice"
+	})).Return(nil)
+
+	ctx := usecase.WithPrincipal(context.Background(), usecase.Principal{Subject: "alice"})
+	result, err := service.MergeSubscriptions(ctx, dto.MergeSubscriptionsCommand{
+		TargetID:  target.ID(),
+		SourceIDs: []uuid.UUID{source.ID()},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, target.ID(), result.Subscription.ID)
+	assert.Equal(t, "Netflix", result.Subscription.ServiceName)
+	assert.Equal(t, month(2025, 1), result.Subscription.StartDate)
+	assert.Equal(t, monthPtr(2025, 6), result.Subscription.EndDate)
+	assert.Equal(t, "family\npromo", result.Subscription.Notes)
+	assert.Equal(t, []uuid.UUID{source.ID()}, result.MergedIDs)
+	assert.Equal(t, "alice", result.MergedBy)
+	assert.False(t, result.MergedAt.IsZero())
+}
+
+func TestSubscriptionService_MergeSubscriptions_DifferentService(t *testing.T) {
+	mockRepo, service := setupSubscriptionService(t)
+
+	userID := uuid.New()
+	target := makeUserSubscription(t, "Netflix", userID, month(2025, 1), nil)
+	source := makeUserSubscription(t, "Spotify", userID, month(2025, 1), nil)
+
+	mockRepo.On("Get", mock.Anything, target.ID()).Return(target, nil)
+	mockRepo.On("Get", mock.Anything, source.ID()).Return(source, nil)
+
+	_, err := service.MergeSubscriptions(context.Background(), dto.MergeSubscriptionsCommand{
+		TargetID:  target.ID(),
+		SourceIDs: []uuid.UUID{source.ID()},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidMerge)
+	mockRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything)
+}
+
+func TestSubscriptionService_MergeSubscriptions_PeriodsApart(t *testing.T) {
+	mockRepo, service := setupSubscriptionService(t)
+
+	userID := uuid.New()
+	target := makeUserSubscription(t, "Netflix", userID, month(2025, 1), monthPtr(2025, 2))
+	source := makeUserSubscription(t, "Netflix", userID, month(2025, 11), monthPtr(2025, 12))
+
+	mockRepo.On("Get", mock.Anything, target.ID()).Return(target, nil)
+	mockRepo.On("Get", mock.Anything, source.ID()).Return(source, nil)
+
+	_, err := service.MergeSubscriptions(context.Background(), dto.MergeSubscriptionsCommand{
+		TargetID:  target.ID(),
+		SourceIDs: []uuid.UUID{source.ID()},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidMerge)
+	assert.Equal(t, monthPtr(2025, 2), target.EndDate())
+	mockRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything)
+}
+
+func TestSubscriptionService_MergeSubscriptions_NotesTooLong(t *testing.T) {
+	mockRepo, service := setupSubscriptionService(t)
+
+	userID := uuid.New()
+	target := makeUserSubscription(t, "Netflix", userID, month(2025, 1), nil)
+	target.SetNotes(strings.Repeat("a", entity.MaxNotesLength))
+	source := makeUserSubscription(t, "Netflix", userID, month(2025, 1), nil)
+	source.SetNotes("b")
+
+	mockRepo.On("Get", mock.Anything, target.ID()).Return(target, nil)
+	mockRepo.On("Get", mock.Anything, source.ID()).Return(source, nil)
+
+	_, err := service.MergeSubscriptions(context.Background(), dto.MergeSubscriptionsCommand{
+		TargetID:  target.ID(),
+		SourceIDs: []uuid.UUID{source.ID()},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrNotesTooLong)
+	assert.Len(t, target.Notes(), entity.MaxNotesLength)
+	mockRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything)
+}
+
+func TestSubscriptionService_MergeSubscriptions_RepeatedSource(t *testing.T) {
+	_, service := setupSubscriptionService(t)
+
+	sourceID := uuid.New()
+	_, err := service.MergeSubscriptions(context.Background(), dto.MergeSubscriptionsCommand{
+		TargetID:  uuid.New(),
+		SourceIDs: []uuid.UUID{sourceID, sourceID},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidMerge)
+}
+
+func TestSubscriptionService_MergeSubscriptions_SourceNotFound(t *testing.T) {
+	mockRepo, service := setupSubscriptionService(t)
+
+	target := testutil.MakeTestSubscription(t)
+	sourceID := uuid.New()
+
+	mockRepo.On("Get", mock.Anything, target.ID()).Return(target, nil)
+	mockRepo.On("Get", mock.Anything, sourceID).Return(nil, usecase.ErrNotFound)
+
+	_, err := service.MergeSubscriptions(context.Background(), dto.MergeSubscriptionsCommand{
+		TargetID:  target.ID(),
+		SourceIDs: []uuid.UUID{sourceID},
+	})
+
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+}
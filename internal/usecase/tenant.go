@@ -0,0 +1,29 @@
+package usecase
+
+import "context"
+
+// DefaultTenantID is the tenant of requests that don't name one.
+// Data created before multi-tenancy belongs to it.
+const DefaultTenantID = "default"
+
+// Tenant is the organisation a request acts on. Repositories only
+// see and change data of the tenant in the request context.
+type Tenant struct {
+	ID string
+	// Currency is the ISO 4217 code prices of the tenant are kept in.
+	Currency string
+}
+
+type tenantKey struct{}
+
+func WithTenant(ctx context.Context, t Tenant) context.Context {
+	return context.WithValue(ctx, tenantKey{}, t)
+}
+
+// TenantFrom returns the tenant of ctx or the default tenant if there is none.
+func TenantFrom(ctx context.Context) Tenant {
+	if t, ok := ctx.Value(tenantKey{}).(Tenant); ok {
+		return t
+	}
+	return Tenant{ID: DefaultTenantID}
+}
@@ -0,0 +1,59 @@
+package usecase_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var (
+	spanRecorder    = tracetest.NewSpanRecorder()
+	installRecorder sync.Once
+)
+
+// recordSpans installs a global provider once: tracers created before keep
+// delegating to the first provider set.
+func recordSpans() *tracetest.SpanRecorder {
+	installRecorder.Do(func() {
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)))
+	})
+	return spanRecorder
+}
+
+func TestSubscriptionService_Spans(t *testing.T) {
+	recorder := recordSpans()
+	mockRepo, service := setupSubscriptionService(t)
+
+	sub := testutil.MakeTestSubscription(t)
+	mockRepo.On("Get", mock.Anything, sub.ID()).Return(sub, nil).Once()
+	mockRepo.On("Get", mock.Anything, sub.ID()).Return(nil, usecase.ErrNotFound).Once()
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "request")
+	_, errFound := service.GetSubscription(ctx, sub.ID())
+	_, errMissing := service.GetSubscription(ctx, sub.ID())
+	parent.End()
+
+	require.NoError(t, errFound)
+	require.ErrorIs(t, errMissing, usecase.ErrNotFound)
+
+	var spans []sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Parent().SpanID() == parent.SpanContext().SpanID() {
+			spans = append(spans, span)
+		}
+	}
+	require.Len(t, spans, 2)
+	assert.Equal(t, "SubscriptionService.GetSubscription", spans[0].Name())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+}
@@ -0,0 +1,8 @@
+CREATE TABLE subscriptions (
+    id CHAR(36) PRIMARY KEY,
+    service_name VARCHAR(255) NOT NULL,
+    price INT NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE
+);
@@ -0,0 +1,11 @@
+CREATE TABLE api_keys (
+    id CHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL UNIQUE,
+    prefix VARCHAR(32) NOT NULL,
+    scopes VARCHAR(255) NOT NULL,
+    created_at DATETIME(6) NOT NULL,
+    expires_at DATETIME(6),
+    last_used_at DATETIME(6),
+    revoked_at DATETIME(6)
+);
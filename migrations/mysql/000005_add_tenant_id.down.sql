@@ -0,0 +1,6 @@
+DROP INDEX idx_api_keys_tenant_id ON api_keys;
+ALTER TABLE api_keys DROP COLUMN tenant_id;
+
+DROP INDEX idx_subscriptions_tenant_start ON subscriptions;
+DROP INDEX idx_subscriptions_tenant_user ON subscriptions;
+ALTER TABLE subscriptions DROP COLUMN tenant_id;
@@ -0,0 +1,6 @@
+ALTER TABLE subscriptions ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+CREATE INDEX idx_subscriptions_tenant_user ON subscriptions (tenant_id, user_id);
+CREATE INDEX idx_subscriptions_tenant_start ON subscriptions (tenant_id, start_date, id);
+
+ALTER TABLE api_keys ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT 'default';
+CREATE INDEX idx_api_keys_tenant_id ON api_keys (tenant_id);
@@ -0,0 +1,15 @@
+CREATE TABLE subscription_merges (
+    id CHAR(36) PRIMARY KEY,
+    tenant_id VARCHAR(64) NOT NULL,
+    target_id CHAR(36) NOT NULL,
+    source_id CHAR(36) NOT NULL,
+    service_name VARCHAR(255) NOT NULL,
+    price INT NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE,
+    notes TEXT NOT NULL,
+    merged_by VARCHAR(255) NOT NULL,
+    merged_at DATETIME(6) NOT NULL
+);
+CREATE INDEX idx_subscription_merges_tenant_target ON subscription_merges (tenant_id, target_id);
@@ -0,0 +1,4 @@
+-- The repository lower-cases service names in Go, so that prefix filters ignore
+-- case beyond ASCII the same way on every dialect.
+ALTER TABLE subscriptions ADD COLUMN service_name_lower VARCHAR(255) NOT NULL DEFAULT '';
+UPDATE subscriptions SET service_name_lower = LOWER(service_name);
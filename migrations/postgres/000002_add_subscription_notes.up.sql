@@ -0,0 +1 @@
+ALTER TABLE subscriptions ADD COLUMN notes TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_subscriptions_notes_trgm;
+DROP INDEX IF EXISTS idx_subscriptions_service_name_trgm;
+DROP INDEX IF EXISTS idx_subscriptions_search_vector;
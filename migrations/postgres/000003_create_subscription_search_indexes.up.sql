@@ -0,0 +1,9 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- The expression must match the one used by the repository search query.
+CREATE INDEX idx_subscriptions_search_vector ON subscriptions USING GIN (
+    (setweight(to_tsvector('simple', service_name), 'A') || setweight(to_tsvector('simple', notes), 'B'))
+);
+
+CREATE INDEX idx_subscriptions_service_name_trgm ON subscriptions USING GIN (service_name gin_trgm_ops);
+CREATE INDEX idx_subscriptions_notes_trgm ON subscriptions USING GIN (notes gin_trgm_ops);
@@ -0,0 +1,6 @@
+-- The repository lower-cases service names in Go, so that prefix filters ignore
+-- case beyond ASCII the same way on every dialect. Existing rows are filled by
+-- LOWER, which folds only ASCII under the C locale; they are lower-cased by the
+-- service on their next update.
+ALTER TABLE subscriptions ADD COLUMN service_name_lower TEXT NOT NULL DEFAULT '';
+UPDATE subscriptions SET service_name_lower = LOWER(service_name);
@@ -0,0 +1,8 @@
+CREATE TABLE subscriptions (
+    id TEXT PRIMARY KEY,
+    service_name TEXT NOT NULL,
+    price INTEGER NOT NULL,
+    user_id TEXT NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE
+);
@@ -0,0 +1,6 @@
+DROP INDEX idx_api_keys_tenant_id;
+ALTER TABLE api_keys DROP COLUMN tenant_id;
+
+DROP INDEX idx_subscriptions_tenant_start;
+DROP INDEX idx_subscriptions_tenant_user;
+ALTER TABLE subscriptions DROP COLUMN tenant_id;
@@ -0,0 +1,15 @@
+CREATE TABLE subscription_merges (
+    id TEXT PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    target_id TEXT NOT NULL,
+    source_id TEXT NOT NULL,
+    service_name TEXT NOT NULL,
+    price INTEGER NOT NULL,
+    user_id TEXT NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE,
+    notes TEXT NOT NULL,
+    merged_by TEXT NOT NULL,
+    merged_at DATETIME NOT NULL
+);
+CREATE INDEX idx_subscription_merges_tenant_target ON subscription_merges (tenant_id, target_id);
@@ -0,0 +1,5 @@
+-- The repository lower-cases service names in Go, since LOWER of SQLite folds
+-- only ASCII. Existing rows are filled by it all the same; names beyond ASCII
+-- are lower-cased by the service on their next update.
+ALTER TABLE subscriptions ADD COLUMN service_name_lower TEXT NOT NULL DEFAULT '';
+UPDATE subscriptions SET service_name_lower = LOWER(service_name);
@@ -0,0 +1,213 @@
+package compat_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/config"
+	"github.com/MDx3R/ef-test/internal/infra/database/memory"
+	logruslogger "github.com/MDx3R/ef-test/internal/infra/logger"
+	ginserver "github.com/MDx3R/ef-test/internal/infra/server/gin"
+	handlersv1 "github.com/MDx3R/ef-test/internal/transport/http/gin/v1"
+	handlersv2 "github.com/MDx3R/ef-test/internal/transport/http/gin/v2"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The suite pins the bodies of the first API version, which existing clients
+// parse. A failure here means a breaking change: make it in v2 instead.
+
+var logger = logruslogger.NewLogger()
+
+// v1Prefixes are the mount points of the first version.
+var v1Prefixes = []string{"", "/v1"}
+
+var deprecation = config.DeprecationConfig{
+	DeprecatedAt: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+	SunsetAt:     time.Date(2027, 5, 1, 0, 0, 0, 0, time.UTC),
+}
+
+func newServer(t *testing.T) http.Handler {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	service := usecase.NewSubscriptionService(memory.NewMemorySubscriptionRepository())
+	server, err := ginserver.New(&config.ServerConfig{V1Deprecation: deprecation})
+	require.NoError(t, err)
+	server.RegisterSubscriptionHandler(
+		handlersv1.NewSubscriptionHandler(service, logger),
+		handlersv2.NewSubscriptionHandler(service, logger),
+	)
+	return server.Handler()
+}
+
+func do(handler http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+// create stores a subscription through prefix and returns its ID.
+func create(t *testing.T, handler http.Handler, prefix, body string) string {
+	t.Helper()
+	w := do(handler, http.MethodPost, prefix+"/subscriptions", body)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	id := strings.TrimSuffix(strings.TrimPrefix(w.Body.String(), `{"id":"`), `"}`)
+	require.Len(t, id, 36)
+	return id
+}
+
+const (
+	userID    = "123e4567-e89b-12d3-a456-426614174000"
+	openEnded = `{"service_name":"Netflix","price":999,"user_id":"` + userID + `","start_date":"08-2025","notes":"family"}`
+	bounded   = `{"service_name":"Spotify","price":299,"user_id":"` + userID + `","start_date":"09-2025","end_date":"12-2025"}`
+)
+
+func TestV1_Get(t *testing.T) {
+	for _, prefix := range v1Prefixes {
+		t.Run("prefix "+prefix, func(t *testing.T) {
+			// Arrange
+			handler := newServer(t)
+			id := create(t, handler, prefix, openEnded)
+
+			// Act
+			w := do(handler, http.MethodGet, prefix+"/subscriptions/"+id, "")
+
+			// Assert
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, fmt.Sprintf(`{
+				"id": "%s",
+				"service_name": "Netflix",
+				"price": 999,
+				"user_id": "%s",
+				"start_date": "08-2025",
+				"end_date": "null",
+				"notes": "family"
+			}`, id, userID), w.Body.String())
+		})
+	}
+}
+
+func TestV1_List(t *testing.T) {
+	// Arrange
+	handler := newServer(t)
+	first := create(t, handler, "", openEnded)
+	second := create(t, handler, "", bounded)
+
+	// Act
+	w := do(handler, http.MethodGet, "/v1/subscriptions?user_id="+userID, "")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"items": [
+			{"id": "%s", "service_name": "Netflix", "price": 999, "user_id": "%s", "start_date": "08-2025", "end_date": "null", "notes": "family"},
+			{"id": "%s", "service_name": "Spotify", "price": 299, "user_id": "%s", "start_date": "09-2025", "end_date": "12-2025", "notes": ""}
+		],
+		"page": 1,
+		"page_size": 20,
+		"total": 2,
+		"next_cursor": null,
+		"has_more": false
+	}`, first, userID, second, userID), w.Body.String())
+}
+
+func TestV1_List_DateFormatISO(t *testing.T) {
+	// Arrange
+	handler := newServer(t)
+	create(t, handler, "", openEnded)
+
+	// Act
+	w := do(handler, http.MethodGet, "/v1/subscriptions?date_format=iso", "")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"start_date":"2025-08","end_date":null`)
+}
+
+func TestV1_List_LargePage(t *testing.T) {
+	// Arrange
+	handler := newServer(t)
+	create(t, handler, "", openEnded)
+
+	// Act
+	w := do(handler, http.MethodGet, "/v1/subscriptions?page_size=500", "")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"page_size":500`)
+}
+
+func TestV1_Search(t *testing.T) {
+	// Arrange
+	handler := newServer(t)
+	id := create(t, handler, "", bounded)
+
+	// Act
+	w := do(handler, http.MethodGet, "/v1/subscriptions/search?q=spotify", "")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, fmt.Sprintf(`{"items": [
+		{"id": "%s", "service_name": "Spotify", "price": 299, "user_id": "%s", "start_date": "09-2025", "end_date": "12-2025", "notes": ""}
+	]}`, id, userID), w.Body.String())
+}
+
+func TestV1_Total(t *testing.T) {
+	// Arrange
+	handler := newServer(t)
+	create(t, handler, "", bounded)
+
+	// Act
+	w := do(handler, http.MethodGet, "/v1/subscriptions/total?user_id="+userID+"&service_name=Spotify&period_start=09-2025&period_end=10-2025", "")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"value": 299}`, w.Body.String())
+}
+
+func TestV1_DeprecationHeaders(t *testing.T) {
+	for _, prefix := range v1Prefixes {
+		t.Run("prefix "+prefix, func(t *testing.T) {
+			// Arrange
+			handler := newServer(t)
+
+			// Act
+			ok := do(handler, http.MethodGet, prefix+"/subscriptions", "")
+			rejected := do(handler, http.MethodGet, prefix+"/subscriptions/not-a-uuid", "")
+
+			// Assert
+			for _, w := range []*httptest.ResponseRecorder{ok, rejected} {
+				assert.Equal(t, "@1793491200", w.Header().Get("Deprecation"))
+				assert.Equal(t, "Sat, 01 May 2027 00:00:00 GMT", w.Header().Get("Sunset"))
+			}
+			assert.Equal(t, http.StatusBadRequest, rejected.Code)
+		})
+	}
+}
+
+func TestV2_NotDeprecated(t *testing.T) {
+	// Arrange
+	handler := newServer(t)
+	id := create(t, handler, "/v2", openEnded)
+
+	// Act
+	w := do(handler, http.MethodGet, "/v2/subscriptions/"+id, "")
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Deprecation"))
+	assert.Empty(t, w.Header().Get("Sunset"))
+	assert.Contains(t, w.Body.String(), `"start_date":"2025-08","end_date":null`)
+}
@@ -0,0 +1,282 @@
+package contract
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	"github.com/MDx3R/ef-test/internal/usecase"
+)
+
+// APIKeyRepositoryFactory returns an empty repository.
+// It is called once per test case.
+type APIKeyRepositoryFactory func(t *testing.T) usecase.APIKeyRepository
+
+func RunAPIKeyRepositoryTests(t *testing.T, newRepo APIKeyRepositoryFactory) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo usecase.APIKeyRepository)
+	}{
+		{"AddAndGet", testAPIKeyAddAndGet},
+		{"Add_DuplicateHash", testAPIKeyAddDuplicateHash},
+		{"Get_NotFound", testAPIKeyGetNotFound},
+		{"GetByHash", testAPIKeyGetByHash},
+		{"GetByHash_NotFound", testAPIKeyGetByHashNotFound},
+		{"Update", testAPIKeyUpdate},
+		{"Update_NotFound", testAPIKeyUpdateNotFound},
+		{"TouchLastUsed", testAPIKeyTouchLastUsed},
+		{"TouchLastUsed_Revoked", testAPIKeyTouchLastUsedRevoked},
+		{"TouchLastUsed_OtherTenant", testAPIKeyTouchLastUsedOtherTenant},
+		{"List", testAPIKeyList},
+		{"Tenant_GetAndListIsolated", testAPIKeyTenantGetAndListIsolated},
+		{"Tenant_GetByHashFindsAnyTenant", testAPIKeyTenantGetByHashFindsAnyTenant},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepo(t))
+		})
+	}
+}
+
+func makeAPIKey(t *testing.T, hash string, createdAt time.Time, expiresAt *time.Time) *entity.APIKey {
+	return makeTenantAPIKey(t, usecase.DefaultTenantID, hash, createdAt, expiresAt)
+}
+
+func makeTenantAPIKey(t *testing.T, tenantID, hash string, createdAt time.Time, expiresAt *time.Time) *entity.APIKey {
+	key, err := entity.NewAPIKeyWithID(
+		uuid.New(),
+		tenantID,
+		"billing-export",
+		hash,
+		"sk_abcdefgh",
+		[]string{entity.ScopeSubscriptionsRead, entity.ScopeReportsRead},
+		createdAt,
+		expiresAt,
+	)
+	require.NoError(t, err)
+	return key
+}
+
+func assertSameAPIKey(t *testing.T, expected, actual *entity.APIKey) {
+	t.Helper()
+
+	assert.Equal(t, expected.ID(), actual.ID())
+	assert.Equal(t, expected.TenantID(), actual.TenantID())
+	assert.Equal(t, expected.Name(), actual.Name())
+	assert.Equal(t, expected.Hash(), actual.Hash())
+	assert.Equal(t, expected.Prefix(), actual.Prefix())
+	assert.Equal(t, expected.Scopes(), actual.Scopes())
+	assert.True(t, expected.CreatedAt().Equal(actual.CreatedAt()))
+	assertSameTime(t, expected.ExpiresAt(), actual.ExpiresAt())
+	assertSameTime(t, expected.LastUsedAt(), actual.LastUsedAt())
+	assertSameTime(t, expected.RevokedAt(), actual.RevokedAt())
+}
+
+func assertSameTime(t *testing.T, expected, actual *time.Time) {
+	t.Helper()
+
+	if expected == nil {
+		assert.Nil(t, actual)
+		return
+	}
+	if assert.NotNil(t, actual) {
+		assert.True(t, expected.Equal(*actual), "expected %v, got %v", *expected, *actual)
+	}
+}
+
+func at(hour int) time.Time {
+	return time.Date(2025, 8, 1, hour, 0, 0, 0, time.UTC)
+}
+
+func testAPIKeyAddAndGet(t *testing.T, repo usecase.APIKeyRepository) {
+	// Arrange
+	expires := at(12)
+	key := makeAPIKey(t, "hash", at(10), &expires)
+
+	// Act
+	err := repo.Add(t.Context(), key)
+	require.NoError(t, err)
+	got, err := repo.Get(t.Context(), key.ID())
+
+	// Assert
+	require.NoError(t, err)
+	assertSameAPIKey(t, key, got)
+}
+
+func testAPIKeyAddDuplicateHash(t *testing.T, repo usecase.APIKeyRepository) {
+	// Arrange
+	require.NoError(t, repo.Add(t.Context(), makeAPIKey(t, "hash", at(10), nil)))
+
+	// Act
+	err := repo.Add(t.Context(), makeAPIKey(t, "hash", at(11), nil))
+
+	// Assert
+	assert.ErrorIs(t, err, usecase.ErrRepository)
+}
+
+func testAPIKeyGetNotFound(t *testing.T, repo usecase.APIKeyRepository) {
+	// Act
+	_, err := repo.Get(t.Context(), uuid.New())
+
+	// Assert
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+}
+
+func testAPIKeyGetByHash(t *testing.T, repo usecase.APIKeyRepository) {
+	// Arrange
+	key := makeAPIKey(t, "hash-1", at(10), nil)
+	require.NoError(t, repo.Add(t.Context(), key))
+	require.NoError(t, repo.Add(t.Context(), makeAPIKey(t, "hash-2", at(10), nil)))
+
+	// Act
+	got, err := repo.GetByHash(t.Context(), "hash-1")
+
+	// Assert
+	require.NoError(t, err)
+	assertSameAPIKey(t, key, got)
+}
+
+func testAPIKeyGetByHashNotFound(t *testing.T, repo usecase.APIKeyRepository) {
+	// Arrange
+	require.NoError(t, repo.Add(t.Context(), makeAPIKey(t, "hash", at(10), nil)))
+
+	// Act
+	_, err := repo.GetByHash(t.Context(), "other")
+
+	// Assert
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+}
+
+func testAPIKeyUpdate(t *testing.T, repo usecase.APIKeyRepository) {
+	// Arrange
+	key := makeAPIKey(t, "hash", at(10), nil)
+	require.NoError(t, repo.Add(t.Context(), key))
+
+	key.Touch(at(11))
+	key.Revoke(at(12))
+
+	// Act
+	err := repo.Update(t.Context(), key)
+	require.NoError(t, err)
+	got, err := repo.Get(t.Context(), key.ID())
+
+	// Assert
+	require.NoError(t, err)
+	assertSameAPIKey(t, key, got)
+	assert.False(t, got.IsActive(at(13)))
+}
+
+func testAPIKeyUpdateNotFound(t *testing.T, repo usecase.APIKeyRepository) {
+	// Arrange
+	key := makeAPIKey(t, "hash", at(10), nil)
+
+	// Act
+	err := repo.Update(t.Context(), key)
+
+	// Assert
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+}
+
+func testAPIKeyTouchLastUsed(t *testing.T, repo usecase.APIKeyRepository) {
+	// Arrange
+	key := makeAPIKey(t, "hash", at(10), nil)
+	require.NoError(t, repo.Add(t.Context(), key))
+
+	// Act
+	err := repo.TouchLastUsed(t.Context(), key.TenantID(), key.ID(), at(11))
+	require.NoError(t, err)
+	got, err := repo.Get(t.Context(), key.ID())
+
+	// Assert
+	require.NoError(t, err)
+	last := at(11)
+	assertSameTime(t, &last, got.LastUsedAt())
+}
+
+func testAPIKeyTouchLastUsedRevoked(t *testing.T, repo usecase.APIKeyRepository) {
+	// Arrange
+	key := makeAPIKey(t, "hash", at(10), nil)
+	require.NoError(t, repo.Add(t.Context(), key))
+	key.Revoke(at(11))
+	require.NoError(t, repo.Update(t.Context(), key))
+
+	// Act
+	err := repo.TouchLastUsed(t.Context(), key.TenantID(), key.ID(), at(12))
+	got, getErr := repo.Get(t.Context(), key.ID())
+
+	// Assert
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+	require.NoError(t, getErr)
+	assert.Nil(t, got.LastUsedAt())
+	assertSameTime(t, key.RevokedAt(), got.RevokedAt())
+}
+
+func testAPIKeyTouchLastUsedOtherTenant(t *testing.T, repo usecase.APIKeyRepository) {
+	// Arrange
+	key := makeTenantAPIKey(t, "acme", "hash", at(10), nil)
+	require.NoError(t, repo.Add(tenantContext(t, "acme"), key))
+
+	// Act
+	err := repo.TouchLastUsed(t.Context(), "globex", key.ID(), at(11))
+
+	// Assert
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+}
+
+func testAPIKeyList(t *testing.T, repo usecase.APIKeyRepository) {
+	// Arrange
+	older := makeAPIKey(t, "hash-1", at(10), nil)
+	newer := makeAPIKey(t, "hash-2", at(11), nil)
+	revoked := makeAPIKey(t, "hash-3", at(9), nil)
+	revoked.Revoke(at(12))
+	for _, key := range []*entity.APIKey{older, newer, revoked} {
+		require.NoError(t, repo.Add(t.Context(), key))
+	}
+
+	// Act
+	keys, err := repo.List(t.Context())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+	assert.Equal(t, newer.ID(), keys[0].ID())
+	assert.Equal(t, older.ID(), keys[1].ID())
+	assert.Equal(t, revoked.ID(), keys[2].ID())
+}
+
+func testAPIKeyTenantGetAndListIsolated(t *testing.T, repo usecase.APIKeyRepository) {
+	// Arrange
+	acme, globex := tenantContext(t, "acme"), tenantContext(t, "globex")
+	own := makeTenantAPIKey(t, "acme", "hash-1", at(10), nil)
+	foreign := makeTenantAPIKey(t, "globex", "hash-2", at(10), nil)
+	require.NoError(t, repo.Add(acme, own))
+	require.NoError(t, repo.Add(globex, foreign))
+
+	// Act
+	_, errForeign := repo.Get(acme, foreign.ID())
+	keys, errList := repo.List(acme)
+
+	// Assert
+	assert.ErrorIs(t, errForeign, usecase.ErrNotFound)
+	require.NoError(t, errList)
+	require.Len(t, keys, 1)
+	assert.Equal(t, own.ID(), keys[0].ID())
+}
+
+func testAPIKeyTenantGetByHashFindsAnyTenant(t *testing.T, repo usecase.APIKeyRepository) {
+	// Arrange
+	key := makeTenantAPIKey(t, "acme", "hash", at(10), nil)
+	require.NoError(t, repo.Add(tenantContext(t, "acme"), key))
+
+	// Act
+	got, err := repo.GetByHash(t.Context(), "hash")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "acme", got.TenantID())
+}
@@ -0,0 +1,246 @@
+package contract
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+)
+
+// IdempotencyRepositoryFactory returns an empty repository.
+// It is called once per test case.
+type IdempotencyRepositoryFactory func(t *testing.T) usecase.IdempotencyRepository
+
+func RunIdempotencyRepositoryTests(t *testing.T, newRepo IdempotencyRepositoryFactory) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo usecase.IdempotencyRepository)
+	}{
+		{"Reserve", testIdempotencyReserve},
+		{"Reserve_Existing", testIdempotencyReserveExisting},
+		{"Reserve_ExpiredReplaced", testIdempotencyReserveExpiredReplaced},
+		{"Reserve_OwnersIsolated", testIdempotencyReserveOwnersIsolated},
+		{"Complete", testIdempotencyComplete},
+		{"Complete_NotFound", testIdempotencyCompleteNotFound},
+		{"Complete_Twice", testIdempotencyCompleteTwice},
+		{"Release", testIdempotencyRelease},
+		{"Release_KeepsCompleted", testIdempotencyReleaseKeepsCompleted},
+		{"DeleteExpired", testIdempotencyDeleteExpired},
+		{"Tenant_Isolated", testIdempotencyTenantIsolated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepo(t))
+		})
+	}
+}
+
+func makeIdempotencyRecord(owner, key, hash string, createdAt time.Time) dto.IdempotencyRecord {
+	return dto.IdempotencyRecord{
+		Owner:       owner,
+		Key:         key,
+		RequestHash: hash,
+		CreatedAt:   createdAt,
+		ExpiresAt:   createdAt.Add(time.Hour),
+	}
+}
+
+func assertSameIdempotencyRecord(t *testing.T, expected, actual dto.IdempotencyRecord) {
+	t.Helper()
+
+	assert.Equal(t, expected.Owner, actual.Owner)
+	assert.Equal(t, expected.Key, actual.Key)
+	assert.Equal(t, expected.RequestHash, actual.RequestHash)
+	assert.Equal(t, expected.Response, actual.Response)
+	assert.True(t, expected.CreatedAt.Equal(actual.CreatedAt), "expected %v, got %v", expected.CreatedAt, actual.CreatedAt)
+	assert.True(t, expected.ExpiresAt.Equal(actual.ExpiresAt), "expected %v, got %v", expected.ExpiresAt, actual.ExpiresAt)
+}
+
+func testIdempotencyReserve(t *testing.T, repo usecase.IdempotencyRepository) {
+	// Arrange
+	record := makeIdempotencyRecord("user", "key", "hash", at(10))
+
+	// Act
+	got, reserved, err := repo.Reserve(t.Context(), record)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, reserved)
+	assertSameIdempotencyRecord(t, record, got)
+}
+
+func testIdempotencyReserveExisting(t *testing.T, repo usecase.IdempotencyRepository) {
+	// Arrange
+	first := makeIdempotencyRecord("user", "key", "hash-1", at(10))
+	_, _, err := repo.Reserve(t.Context(), first)
+	require.NoError(t, err)
+
+	// Act
+	got, reserved, err := repo.Reserve(t.Context(), makeIdempotencyRecord("user", "key", "hash-2", at(10).Add(time.Minute)))
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assertSameIdempotencyRecord(t, first, got)
+}
+
+func testIdempotencyReserveExpiredReplaced(t *testing.T, repo usecase.IdempotencyRepository) {
+	// Arrange
+	_, _, err := repo.Reserve(t.Context(), makeIdempotencyRecord("user", "key", "hash-1", at(10)))
+	require.NoError(t, err)
+	require.NoError(t, repo.Complete(t.Context(), "user", "key", dto.IdempotentResponse{StatusCode: 201}, at(11)))
+	second := makeIdempotencyRecord("user", "key", "hash-2", at(11))
+
+	// Act
+	got, reserved, err := repo.Reserve(t.Context(), second)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, reserved)
+	assertSameIdempotencyRecord(t, second, got)
+}
+
+func testIdempotencyReserveOwnersIsolated(t *testing.T, repo usecase.IdempotencyRepository) {
+	// Arrange
+	_, _, err := repo.Reserve(t.Context(), makeIdempotencyRecord("alice", "key", "hash", at(10)))
+	require.NoError(t, err)
+
+	// Act
+	_, reserved, err := repo.Reserve(t.Context(), makeIdempotencyRecord("bob", "key", "hash", at(10)))
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, reserved)
+}
+
+func testIdempotencyComplete(t *testing.T, repo usecase.IdempotencyRepository) {
+	// Arrange
+	record := makeIdempotencyRecord("user", "key", "hash", at(10))
+	_, _, err := repo.Reserve(t.Context(), record)
+	require.NoError(t, err)
+	response := dto.IdempotentResponse{
+		StatusCode:  201,
+		ContentType: "application/json; charset=utf-8",
+		Body:        []byte(`{"id":"123e4567-e89b-12d3-a456-426614174000"}`),
+	}
+
+	// Act
+	err = repo.Complete(t.Context(), "user", "key", response, at(12))
+	require.NoError(t, err)
+	// Past the expiry of the reservation, but not of the response.
+	got, reserved, err := repo.Reserve(t.Context(), makeIdempotencyRecord("user", "key", "hash", at(11).Add(time.Minute)))
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	record.Response = &response
+	record.ExpiresAt = at(12)
+	assertSameIdempotencyRecord(t, record, got)
+}
+
+func testIdempotencyCompleteNotFound(t *testing.T, repo usecase.IdempotencyRepository) {
+	// Act
+	err := repo.Complete(t.Context(), "user", "key", dto.IdempotentResponse{StatusCode: 201}, at(11))
+
+	// Assert
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+}
+
+func testIdempotencyCompleteTwice(t *testing.T, repo usecase.IdempotencyRepository) {
+	// Arrange
+	_, _, err := repo.Reserve(t.Context(), makeIdempotencyRecord("user", "key", "hash", at(10)))
+	require.NoError(t, err)
+	require.NoError(t, repo.Complete(t.Context(), "user", "key", dto.IdempotentResponse{StatusCode: 201}, at(11)))
+
+	// Act
+	err = repo.Complete(t.Context(), "user", "key", dto.IdempotentResponse{StatusCode: 200}, at(11))
+
+	// Assert
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+}
+
+func testIdempotencyRelease(t *testing.T, repo usecase.IdempotencyRepository) {
+	// Arrange
+	_, _, err := repo.Reserve(t.Context(), makeIdempotencyRecord("user", "key", "hash-1", at(10)))
+	require.NoError(t, err)
+
+	// Act
+	err = repo.Release(t.Context(), "user", "key")
+	require.NoError(t, err)
+	_, reserved, err := repo.Reserve(t.Context(), makeIdempotencyRecord("user", "key", "hash-2", at(10)))
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, reserved)
+}
+
+func testIdempotencyReleaseKeepsCompleted(t *testing.T, repo usecase.IdempotencyRepository) {
+	// Arrange
+	_, _, err := repo.Reserve(t.Context(), makeIdempotencyRecord("user", "key", "hash", at(10)))
+	require.NoError(t, err)
+	require.NoError(t, repo.Complete(t.Context(), "user", "key", dto.IdempotentResponse{StatusCode: 201}, at(11)))
+
+	// Act
+	err = repo.Release(t.Context(), "user", "key")
+	require.NoError(t, err)
+	got, reserved, err := repo.Reserve(t.Context(), makeIdempotencyRecord("user", "key", "hash", at(10)))
+
+	// Assert
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assert.NotNil(t, got.Response)
+}
+
+func testIdempotencyDeleteExpired(t *testing.T, repo usecase.IdempotencyRepository) {
+	// Arrange
+	acme, globex := tenantContext(t, "acme"), tenantContext(t, "globex")
+	for _, record := range []struct {
+		tenant    string
+		key       string
+		createdAt time.Time
+	}{
+		{"acme", "expired", at(8)},
+		{"globex", "expired", at(9)},
+		{"acme", "alive", at(10)},
+	} {
+		_, _, err := repo.Reserve(tenantContext(t, record.tenant), makeIdempotencyRecord("user", record.key, "hash", record.createdAt))
+		require.NoError(t, err)
+	}
+
+	// Act
+	deleted, err := repo.DeleteExpired(t.Context(), at(10))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+	_, reserved, err := repo.Reserve(acme, makeIdempotencyRecord("user", "alive", "hash", at(10)))
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	_, reserved, err = repo.Reserve(globex, makeIdempotencyRecord("user", "expired", "hash", at(8)))
+	require.NoError(t, err)
+	assert.True(t, reserved)
+}
+
+func testIdempotencyTenantIsolated(t *testing.T, repo usecase.IdempotencyRepository) {
+	// Arrange
+	acme, globex := tenantContext(t, "acme"), tenantContext(t, "globex")
+	_, _, err := repo.Reserve(acme, makeIdempotencyRecord("user", "key", "hash", at(10)))
+	require.NoError(t, err)
+
+	// Act
+	_, reserved, err := repo.Reserve(globex, makeIdempotencyRecord("user", "key", "hash", at(10)))
+	require.NoError(t, err)
+	err = repo.Complete(globex, "user", "key", dto.IdempotentResponse{StatusCode: 201}, at(11))
+	require.NoError(t, err)
+	got, _, err := repo.Reserve(acme, makeIdempotencyRecord("user", "key", "hash", at(10)))
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, reserved)
+	assert.Nil(t, got.Response)
+}
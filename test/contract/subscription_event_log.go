@@ -0,0 +1,127 @@
+package contract
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+)
+
+// SubscriptionEventLogFactory returns an empty log keeping the latest capacity events.
+// It is called once per test case.
+type SubscriptionEventLogFactory func(t *testing.T, capacity int) usecase.SubscriptionEventLog
+
+func RunSubscriptionEventLogTests(t *testing.T, newLog SubscriptionEventLogFactory) {
+	tests := []struct {
+		name     string
+		capacity int
+		run      func(t *testing.T, log usecase.SubscriptionEventLog)
+	}{
+		{"Append_AssignsIDs", 10, testEventLogAppendAssignsIDs},
+		{"After", 10, testEventLogAfter},
+		{"After_Latest", 10, testEventLogAfterLatest},
+		{"After_Discarded", 3, testEventLogAfterDiscarded},
+		{"Changed_ClosedByAppend", 10, testEventLogChanged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newLog(t, tt.capacity))
+		})
+	}
+}
+
+func appendEvents(t *testing.T, log usecase.SubscriptionEventLog, n int) []dto.SubscriptionEventDTO {
+	t.Helper()
+
+	events := make([]dto.SubscriptionEventDTO, n)
+	for i := range events {
+		event, err := log.Append(context.Background(), dto.SubscriptionEventDTO{
+			Type:         dto.SubscriptionCreated,
+			TenantID:     usecase.DefaultTenantID,
+			Subscription: dto.SubscriptionDTO{ID: uuid.New(), ServiceName: "Netflix"},
+		})
+		require.NoError(t, err)
+		events[i] = event
+	}
+	return events
+}
+
+func eventIDs(events []dto.SubscriptionEventDTO) []uint64 {
+	ids := make([]uint64, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
+	return ids
+}
+
+func testEventLogAppendAssignsIDs(t *testing.T, log usecase.SubscriptionEventLog) {
+	lastID, err := log.LastID(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, lastID)
+
+	events := appendEvents(t, log, 3)
+
+	assert.Equal(t, []uint64{1, 2, 3}, eventIDs(events))
+	lastID, err = log.LastID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), lastID)
+}
+
+func testEventLogAfter(t *testing.T, log usecase.SubscriptionEventLog) {
+	events := appendEvents(t, log, 5)
+
+	found, complete, err := log.After(context.Background(), 2)
+
+	require.NoError(t, err)
+	assert.True(t, complete)
+	assert.Equal(t, []uint64{3, 4, 5}, eventIDs(found))
+	assert.Equal(t, events[2].Subscription.ID, found[0].Subscription.ID)
+}
+
+func testEventLogAfterLatest(t *testing.T, log usecase.SubscriptionEventLog) {
+	appendEvents(t, log, 2)
+
+	found, complete, err := log.After(context.Background(), 2)
+
+	require.NoError(t, err)
+	assert.True(t, complete)
+	assert.Empty(t, found)
+}
+
+func testEventLogAfterDiscarded(t *testing.T, log usecase.SubscriptionEventLog) {
+	appendEvents(t, log, 5)
+
+	found, complete, err := log.After(context.Background(), 1)
+	require.NoError(t, err)
+	assert.False(t, complete)
+	assert.Equal(t, []uint64{3, 4, 5}, eventIDs(found))
+
+	found, complete, err = log.After(context.Background(), 2)
+	require.NoError(t, err)
+	assert.True(t, complete)
+	assert.Equal(t, []uint64{3, 4, 5}, eventIDs(found))
+}
+
+func testEventLogChanged(t *testing.T, log usecase.SubscriptionEventLog) {
+	changed := log.Changed()
+
+	select {
+	case <-changed:
+		t.Fatal("changed before append")
+	default:
+	}
+
+	appendEvents(t, log, 1)
+
+	select {
+	case <-changed:
+	default:
+		t.Fatal("not changed after append")
+	}
+}
@@ -0,0 +1,1411 @@
+// Package contract contains behaviour every usecase.SubscriptionRepository
+// implementation must share. Backends run the suite from their own tests,
+// so they cannot drift apart.
+package contract
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	"github.com/MDx3R/ef-test/test/testutil"
+)
+
+// SubscriptionRepositoryFactory returns an empty repository.
+// It is called once per test case.
+type SubscriptionRepositoryFactory func(t *testing.T) usecase.SubscriptionRepository
+
+func RunSubscriptionRepositoryTests(t *testing.T, newRepo SubscriptionRepositoryFactory) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo usecase.SubscriptionRepository)
+	}{
+		{"AddAndGet", testAddAndGet},
+		{"Add_Duplicate", testAddDuplicate},
+		{"Add_TruncatesDates", testAddTruncatesDates},
+		{"Get_NotFound", testGetNotFound},
+		{"Update", testUpdate},
+		{"Update_ClearsEndDate", testUpdateClearsEndDate},
+		{"Delete", testDelete},
+		{"Delete_NotFound", testDeleteNotFound},
+		{"List", testList},
+		{"List_EmptyResult", testListEmptyResult},
+		{"List_FilterByUserID", testListFilterByUserID},
+		{"List_FilterByServiceName", testListFilterByServiceName},
+		{"List_FilterDates", testListFilterDates},
+		{"List_CombinedFilters", testListCombinedFilters},
+		{"List_FilterEndDateWithOtherFilters", testListFilterEndDateWithOtherFilters},
+		{"List_FilterByServiceNames", testListFilterByServiceNames},
+		{"List_FilterByServiceNamePrefix", testListFilterByServiceNamePrefix},
+		{"List_FilterByPrice", testListFilterByPrice},
+		{"List_FilterActiveAt", testListFilterActiveAt},
+		{"List_FilterActiveAtDays", testListFilterActiveAtDays},
+		{"List_FilterExpiringBefore", testListFilterExpiringBefore},
+		{"List_FilterOpenEnded", testListFilterOpenEnded},
+		{"List_RichFiltersCompose", testListRichFiltersCompose},
+		{"List_Pagination", testListPagination},
+		{"List_PaginationEdgeCases", testListPaginationEdgeCases},
+		{"List_Order", testListOrder},
+		{"List_Sort", testListSort},
+		{"List_SortEndDateNullsLast", testListSortEndDateNullsLast},
+		{"List_SortPagination", testListSortPagination},
+		{"List_Total", testListTotal},
+		{"List_Cursor", testListCursor},
+		{"List_CursorIgnoresPage", testListCursorIgnoresPage},
+		{"List_CursorWithFilters", testListCursorWithFilters},
+		{"CalculateTotalCost", testCalculateTotalCost},
+		{"CalculateTotalCost_PeriodBoundaries", testCalculateTotalCostPeriodBoundaries},
+		{"CalculateTotalCost_NoMatches", testCalculateTotalCostNoMatches},
+		{"Notes", testNotes},
+		{"Search_ServiceName", testSearchServiceName},
+		{"Search_Notes", testSearchNotes},
+		{"Search_RanksServiceNameAboveNotes", testSearchRanksServiceNameAboveNotes},
+		{"Search_FilterByUserID", testSearchFilterByUserID},
+		{"Search_Limit", testSearchLimit},
+		{"Search_NoMatches", testSearchNoMatches},
+		{"ListByUser", testListByUser},
+		{"Merge", testMerge},
+		{"Merge_SourceNotFound", testMergeSourceNotFound},
+		{"Merge_TargetNotFound", testMergeTargetNotFound},
+		{"Tenant_GetIsolated", testTenantGetIsolated},
+		{"Tenant_ListIsolated", testTenantListIsolated},
+		{"Tenant_CalculateTotalCostIsolated", testTenantCalculateTotalCostIsolated},
+		{"Tenant_SearchIsolated", testTenantSearchIsolated},
+		{"Tenant_UpdateForeignFails", testTenantUpdateForeignFails},
+		{"Tenant_DeleteForeignIgnored", testTenantDeleteForeignIgnored},
+		{"Tenant_DefaultWithoutContext", testTenantDefaultWithoutContext},
+		{"Tenant_ListByUserIsolated", testTenantListByUserIsolated},
+		{"Tenant_MergeForeignFails", testTenantMergeForeignFails},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepo(t))
+		})
+	}
+}
+
+func makeSubscriptionWithNotes(t *testing.T, serviceName, notes string, userID uuid.UUID) *entity.Subscription {
+	sub := makeSubscription(t, serviceName, userID, 100, date(2025, 1, 1), nil)
+	sub.SetNotes(notes)
+	return sub
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func datePtr(year int, month time.Month, day int) *time.Time {
+	d := date(year, month, day)
+	return &d
+}
+
+func makeSubscription(
+	t *testing.T,
+	serviceName string,
+	userID uuid.UUID,
+	price int,
+	startDate time.Time,
+	endDate *time.Time,
+) *entity.Subscription {
+	sub, err := entity.NewSubscriptionWithID(uuid.New(), serviceName, userID, price, startDate, endDate)
+	require.NoError(t, err)
+	return sub
+}
+
+func addAll(t *testing.T, repo usecase.SubscriptionRepository, subs ...*entity.Subscription) {
+	for _, sub := range subs {
+		require.NoError(t, repo.Add(t.Context(), sub))
+	}
+}
+
+func getIDs(subs []*entity.Subscription) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(subs))
+	for _, s := range subs {
+		ids = append(ids, s.ID())
+	}
+	return ids
+}
+
+func intersect(a, b []uuid.UUID) []uuid.UUID {
+	var result []uuid.UUID
+	for _, id := range a {
+		if slices.Contains(b, id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func assertSameSubscription(t *testing.T, expected, actual *entity.Subscription) {
+	t.Helper()
+
+	assert.Equal(t, expected.ID(), actual.ID())
+	assert.Equal(t, expected.UserID(), actual.UserID())
+	assert.Equal(t, expected.ServiceName(), actual.ServiceName())
+	assert.Equal(t, expected.Price(), actual.Price())
+	assert.Equal(t, expected.Notes(), actual.Notes())
+	assert.True(t, expected.StartDate().Equal(actual.StartDate()),
+		"start date: expected %v, got %v", expected.StartDate(), actual.StartDate())
+
+	if expected.EndDate() == nil {
+		assert.Nil(t, actual.EndDate())
+		return
+	}
+	if assert.NotNil(t, actual.EndDate()) {
+		assert.True(t, expected.EndDate().Equal(*actual.EndDate()),
+			"end date: expected %v, got %v", *expected.EndDate(), *actual.EndDate())
+	}
+}
+
+func testAddAndGet(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	sub := makeSubscription(t, "test_service", uuid.New(), 100, date(2025, 8, 1), datePtr(2025, 12, 1))
+
+	// Act
+	errAdd := repo.Add(t.Context(), sub)
+	got, errGet := repo.Get(t.Context(), sub.ID())
+
+	// Assert
+	assert.NoError(t, errAdd)
+	require.NoError(t, errGet)
+	assertSameSubscription(t, sub, got)
+}
+
+func testAddDuplicate(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	sub := testutil.MakeTestSubscription(t)
+	require.NoError(t, repo.Add(t.Context(), sub))
+
+	// Act
+	err := repo.Add(t.Context(), sub)
+
+	// Assert
+	assert.ErrorIs(t, err, usecase.ErrRepository)
+}
+
+func testAddTruncatesDates(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	startDate := time.Date(2025, 8, 15, 13, 45, 0, 0, time.UTC)
+	endDate := time.Date(2025, 9, 20, 23, 59, 59, 0, time.UTC)
+	sub := makeSubscription(t, "test_service", uuid.New(), 100, startDate, &endDate)
+
+	// Act
+	require.NoError(t, repo.Add(t.Context(), sub))
+	got, err := repo.Get(t.Context(), sub.ID())
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, got.StartDate().Equal(date(2025, 8, 15)), "got %v", got.StartDate())
+	require.NotNil(t, got.EndDate())
+	assert.True(t, got.EndDate().Equal(date(2025, 9, 20)), "got %v", *got.EndDate())
+}
+
+func testGetNotFound(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Act
+	_, err := repo.Get(t.Context(), uuid.New())
+
+	// Assert
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+}
+
+func testUpdate(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	sub := testutil.MakeTestSubscription(t)
+	require.NoError(t, repo.Add(t.Context(), sub))
+
+	sub.SetServiceName("updated_service")
+	sub.SetPrice(200)
+	require.NoError(t, sub.SetStartEndDate(date(2025, 9, 1), datePtr(2025, 10, 1)))
+
+	// Act
+	errUpdate := repo.Update(t.Context(), sub)
+	got, errGet := repo.Get(t.Context(), sub.ID())
+
+	// Assert
+	assert.NoError(t, errUpdate)
+	require.NoError(t, errGet)
+	assertSameSubscription(t, sub, got)
+}
+
+func testUpdateClearsEndDate(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	sub := makeSubscription(t, "test_service", uuid.New(), 100, date(2025, 8, 1), datePtr(2025, 10, 1))
+	require.NoError(t, repo.Add(t.Context(), sub))
+	require.NoError(t, sub.SetEndDate(nil))
+
+	// Act
+	errUpdate := repo.Update(t.Context(), sub)
+	got, errGet := repo.Get(t.Context(), sub.ID())
+
+	// Assert
+	assert.NoError(t, errUpdate)
+	require.NoError(t, errGet)
+	assert.Nil(t, got.EndDate())
+}
+
+func testDelete(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	sub := testutil.MakeTestSubscription(t)
+	other := testutil.MakeTestSubscription(t)
+	addAll(t, repo, sub, other)
+
+	// Act
+	errDelete := repo.Delete(t.Context(), sub.ID())
+	_, errGet := repo.Get(t.Context(), sub.ID())
+	_, errOther := repo.Get(t.Context(), other.ID())
+
+	// Assert
+	assert.NoError(t, errDelete)
+	assert.ErrorIs(t, errGet, usecase.ErrNotFound)
+	assert.NoError(t, errOther)
+}
+
+func testDeleteNotFound(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Act
+	err := repo.Delete(t.Context(), uuid.New())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func testList(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	sub1 := testutil.MakeTestSubscription(t)
+	sub2 := testutil.MakeTestSubscription(t)
+	addAll(t, repo, sub1, sub2)
+
+	// Act
+	page, err := repo.List(t.Context(), dto.SubscriptionFilter{Page: 1, PageSize: 10})
+	list := page.Subscriptions
+
+	// Assert
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{sub1.ID(), sub2.ID()}, getIDs(list))
+}
+
+func testListEmptyResult(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	addAll(t, repo, testutil.MakeTestSubscription(t))
+
+	serviceName := "non-existent-service"
+	filter := dto.SubscriptionFilter{
+		ServiceName: &serviceName,
+		Page:        1,
+		PageSize:    10,
+	}
+
+	// Act
+	page, err := repo.List(t.Context(), filter)
+	list := page.Subscriptions
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, list, 0)
+}
+
+func testListFilterByUserID(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	userID1 := uuid.New()
+	userID2 := uuid.New()
+
+	sub1 := makeSubscription(t, "service1", userID1, 50, date(2025, 8, 1), nil)
+	sub2 := makeSubscription(t, "service2", userID2, 100, date(2025, 8, 1), nil)
+	addAll(t, repo, sub1, sub2)
+
+	filter := dto.SubscriptionFilter{
+		UserID:   &userID1,
+		Page:     1,
+		PageSize: 10,
+	}
+
+	// Act
+	page, err := repo.List(t.Context(), filter)
+	list := page.Subscriptions
+
+	// Assert
+	assert.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, userID1, list[0].UserID())
+}
+
+func testListFilterByServiceName(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	sub1 := makeSubscription(t, "serviceA", uuid.New(), 50, date(2025, 8, 1), nil)
+	sub2 := makeSubscription(t, "serviceB", uuid.New(), 100, date(2025, 8, 1), nil)
+	addAll(t, repo, sub1, sub2)
+
+	serviceName := "serviceB"
+	filter := dto.SubscriptionFilter{
+		ServiceName: &serviceName,
+		Page:        1,
+		PageSize:    10,
+	}
+
+	// Act
+	page, err := repo.List(t.Context(), filter)
+	list := page.Subscriptions
+
+	// Assert
+	assert.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "serviceB", list[0].ServiceName())
+}
+
+func testListFilterDates(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	startDate := date(2025, 8, 1)
+	endDate := time.Date(2025, 8, 31, 23, 59, 59, 0, time.UTC)
+
+	// 1. start_date: 2025-07-01, end_date: NULL
+	sub1 := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 7, 1), nil)
+	// 2. start_date: 2025-08-05, end_date: 2025-08-20
+	sub2 := makeSubscription(t, "serviceA", uuid.New(), 150, date(2025, 8, 5), datePtr(2025, 8, 20))
+	// 3. start_date: 2025-08-15, end_date: 2025-09-01
+	sub3 := makeSubscription(t, "serviceB", uuid.New(), 200, date(2025, 8, 15), datePtr(2025, 9, 1))
+	addAll(t, repo, sub1, sub2, sub3)
+
+	// Act & Assert
+	t.Run("StartDate set, EndDate nil", func(t *testing.T) {
+		filter := dto.SubscriptionFilter{
+			StartDate: &startDate,
+			Page:      1,
+			PageSize:  10,
+		}
+		page, err := repo.List(t.Context(), filter)
+		subs := page.Subscriptions
+		require.NoError(t, err)
+
+		// start_date >= 2025-08-01 => sub2 and sub3
+		assert.ElementsMatch(t, []uuid.UUID{sub2.ID(), sub3.ID()}, getIDs(subs))
+	})
+
+	t.Run("EndDate set, StartDate nil", func(t *testing.T) {
+		filter := dto.SubscriptionFilter{
+			EndDate:  &endDate,
+			Page:     1,
+			PageSize: 10,
+		}
+		page, err := repo.List(t.Context(), filter)
+		subs := page.Subscriptions
+		require.NoError(t, err)
+
+		// end_date <= 2025-08-31 OR end_date IS NULL => sub1 and sub2
+		assert.ElementsMatch(t, []uuid.UUID{sub1.ID(), sub2.ID()}, getIDs(subs))
+	})
+
+	t.Run("StartDate and EndDate set", func(t *testing.T) {
+		filter := dto.SubscriptionFilter{
+			StartDate: &startDate,
+			EndDate:   &endDate,
+			Page:      1,
+			PageSize:  10,
+		}
+		page, err := repo.List(t.Context(), filter)
+		subs := page.Subscriptions
+		require.NoError(t, err)
+
+		// start_date >= 2025-08-01 AND (end_date <= 2025-08-31 OR end_date IS NULL) => sub2
+		assert.ElementsMatch(t, []uuid.UUID{sub2.ID()}, getIDs(subs))
+	})
+
+	t.Run("Bounds are inclusive", func(t *testing.T) {
+		from := date(2025, 8, 5)
+		to := date(2025, 8, 20)
+		filter := dto.SubscriptionFilter{
+			StartDate: &from,
+			EndDate:   &to,
+			Page:      1,
+			PageSize:  10,
+		}
+		page, err := repo.List(t.Context(), filter)
+		subs := page.Subscriptions
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []uuid.UUID{sub2.ID()}, getIDs(subs))
+	})
+}
+
+func testListCombinedFilters(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	userID := uuid.New()
+	match := makeSubscription(t, "serviceA", userID, 100, date(2025, 8, 1), nil)
+	otherService := makeSubscription(t, "serviceB", userID, 100, date(2025, 8, 1), nil)
+	otherUser := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 8, 1), nil)
+	tooEarly := makeSubscription(t, "serviceA", userID, 100, date(2025, 6, 1), nil)
+	addAll(t, repo, match, otherService, otherUser, tooEarly)
+
+	serviceName := "serviceA"
+	startDate := date(2025, 7, 1)
+	filter := dto.SubscriptionFilter{
+		UserID:      &userID,
+		ServiceName: &serviceName,
+		StartDate:   &startDate,
+		Page:        1,
+		PageSize:    10,
+	}
+
+	// Act
+	page, err := repo.List(t.Context(), filter)
+	list := page.Subscriptions
+
+	// Assert
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []uuid.UUID{match.ID()}, getIDs(list))
+}
+
+func testListFilterEndDateWithOtherFilters(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	userID := uuid.New()
+	ended := makeSubscription(t, "serviceA", userID, 100, date(2025, 1, 1), datePtr(2025, 3, 1))
+	otherUserOpenEnded := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 1, 1), nil)
+	addAll(t, repo, ended, otherUserOpenEnded)
+
+	// Act
+	page, err := repo.List(t.Context(), dto.SubscriptionFilter{UserID: &userID, EndDate: datePtr(2025, 6, 1), Page: 1, PageSize: 10})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{ended.ID()}, getIDs(page.Subscriptions))
+}
+
+func testListFilterByServiceNames(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	a := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 1, 1), nil)
+	b := makeSubscription(t, "serviceB", uuid.New(), 100, date(2025, 2, 1), nil)
+	c := makeSubscription(t, "serviceC", uuid.New(), 100, date(2025, 3, 1), nil)
+	addAll(t, repo, a, b, c)
+
+	// Act
+	page, err := repo.List(t.Context(), dto.SubscriptionFilter{ServiceNames: []string{"serviceA", "serviceC", "serviceX"}, Page: 1, PageSize: 10})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{a.ID(), c.ID()}, getIDs(page.Subscriptions))
+}
+
+func testListFilterByServiceNamePrefix(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	netflix := makeSubscription(t, "Netflix", uuid.New(), 100, date(2025, 1, 1), nil)
+	netology := makeSubscription(t, "netology", uuid.New(), 100, date(2025, 2, 1), nil)
+	spotify := makeSubscription(t, "Spotify", uuid.New(), 100, date(2025, 3, 1), nil)
+	wildcard := makeSubscription(t, "50% off", uuid.New(), 100, date(2025, 4, 1), nil)
+	yandex := makeSubscription(t, "Яндекс Плюс", uuid.New(), 100, date(2025, 5, 1), nil)
+	addAll(t, repo, netflix, netology, spotify, wildcard, yandex)
+
+	t.Run("Ignores case", func(t *testing.T) {
+		// Act
+		prefix := "NET"
+		page, err := repo.List(t.Context(), dto.SubscriptionFilter{ServiceNamePrefix: &prefix, Page: 1, PageSize: 10})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{netflix.ID(), netology.ID()}, getIDs(page.Subscriptions))
+	})
+
+	t.Run("Ignores case beyond ASCII", func(t *testing.T) {
+		// Act
+		prefix := "яндекс"
+		page, err := repo.List(t.Context(), dto.SubscriptionFilter{ServiceNamePrefix: &prefix, Page: 1, PageSize: 10})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{yandex.ID()}, getIDs(page.Subscriptions))
+	})
+
+	t.Run("Wildcards are literal", func(t *testing.T) {
+		// Act
+		percent := "%"
+		none, err1 := repo.List(t.Context(), dto.SubscriptionFilter{ServiceNamePrefix: &percent, Page: 1, PageSize: 10})
+		literal := "50%"
+		some, err2 := repo.List(t.Context(), dto.SubscriptionFilter{ServiceNamePrefix: &literal, Page: 1, PageSize: 10})
+
+		// Assert
+		assert.NoError(t, err1)
+		assert.NoError(t, err2)
+		assert.Empty(t, none.Subscriptions)
+		assert.Equal(t, []uuid.UUID{wildcard.ID()}, getIDs(some.Subscriptions))
+	})
+}
+
+func testListFilterByPrice(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	cheap := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 1, 1), nil)
+	medium := makeSubscription(t, "serviceA", uuid.New(), 500, date(2025, 2, 1), nil)
+	pricey := makeSubscription(t, "serviceA", uuid.New(), 1000, date(2025, 3, 1), nil)
+	addAll(t, repo, cheap, medium, pricey)
+
+	minPrice, maxPrice := 500, 1000
+
+	tests := []struct {
+		name     string
+		filter   dto.SubscriptionFilter
+		expected []uuid.UUID
+	}{
+		{"Min is inclusive", dto.SubscriptionFilter{PriceMin: &minPrice}, []uuid.UUID{medium.ID(), pricey.ID()}},
+		{"Max is inclusive", dto.SubscriptionFilter{PriceMax: &minPrice}, []uuid.UUID{cheap.ID(), medium.ID()}},
+		{"Range", dto.SubscriptionFilter{PriceMin: &minPrice, PriceMax: &maxPrice}, []uuid.UUID{medium.ID(), pricey.ID()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			tt.filter.Page, tt.filter.PageSize = 1, 10
+			page, err := repo.List(t.Context(), tt.filter)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, getIDs(page.Subscriptions))
+		})
+	}
+}
+
+func testListFilterActiveAt(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	endedBefore := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 1, 1), datePtr(2025, 4, 1))
+	endsThatMonth := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 2, 1), datePtr(2025, 5, 1))
+	startsThatMonth := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 5, 1), datePtr(2025, 9, 1))
+	openEnded := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 3, 1), nil)
+	startsLater := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 6, 1), nil)
+	addAll(t, repo, endedBefore, endsThatMonth, startsThatMonth, openEnded, startsLater)
+
+	// Act
+	page, err := repo.List(t.Context(), dto.SubscriptionFilter{ActiveAt: datePtr(2025, 5, 1), Page: 1, PageSize: 10})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{endsThatMonth.ID(), openEnded.ID(), startsThatMonth.ID()}, getIDs(page.Subscriptions))
+}
+
+func testListFilterActiveAtDays(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	endsEarly := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 2, 1), datePtr(2025, 5, 5))
+	startsLate := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 5, 20), nil)
+	covering := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 5, 10), datePtr(2025, 5, 15))
+	addAll(t, repo, endsEarly, startsLate, covering)
+
+	tests := []struct {
+		name     string
+		filter   dto.SubscriptionFilter
+		expected []uuid.UUID
+	}{
+		{"Day", dto.SubscriptionFilter{ActiveAt: datePtr(2025, 5, 12)}, []uuid.UUID{covering.ID()}},
+		{"Before start", dto.SubscriptionFilter{ActiveAt: datePtr(2025, 5, 8)}, []uuid.UUID{}},
+		{"Month", dto.SubscriptionFilter{ActiveAt: datePtr(2025, 5, 1), ActiveUntil: datePtr(2025, 5, 31)},
+			[]uuid.UUID{endsEarly.ID(), covering.ID(), startsLate.ID()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			tt.filter.Page, tt.filter.PageSize = 1, 10
+			page, err := repo.List(t.Context(), tt.filter)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, getIDs(page.Subscriptions))
+		})
+	}
+}
+
+func testListFilterExpiringBefore(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	expiring := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 1, 1), datePtr(2025, 4, 1))
+	onTheBound := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 2, 1), datePtr(2025, 5, 1))
+	openEnded := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 3, 1), nil)
+	addAll(t, repo, expiring, onTheBound, openEnded)
+
+	// Act
+	page, err := repo.List(t.Context(), dto.SubscriptionFilter{ExpiringBefore: datePtr(2025, 5, 1), Page: 1, PageSize: 10})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{expiring.ID()}, getIDs(page.Subscriptions))
+}
+
+func testListFilterOpenEnded(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	ended := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 1, 1), datePtr(2025, 4, 1))
+	openEnded := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 2, 1), nil)
+	addAll(t, repo, ended, openEnded)
+
+	for _, tt := range []struct {
+		openEnded bool
+		expected  []uuid.UUID
+	}{
+		{true, []uuid.UUID{openEnded.ID()}},
+		{false, []uuid.UUID{ended.ID()}},
+	} {
+		t.Run(fmt.Sprintf("open_ended=%t", tt.openEnded), func(t *testing.T) {
+			// Act
+			page, err := repo.List(t.Context(), dto.SubscriptionFilter{OpenEnded: &tt.openEnded, Page: 1, PageSize: 10})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, getIDs(page.Subscriptions))
+		})
+	}
+}
+
+func testListRichFiltersCompose(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	userID := uuid.New()
+	match := makeSubscription(t, "Netflix", userID, 500, date(2025, 1, 1), nil)
+	tooCheap := makeSubscription(t, "Netflix", userID, 100, date(2025, 1, 1), nil)
+	ended := makeSubscription(t, "Netflix", userID, 500, date(2025, 1, 1), datePtr(2025, 3, 1))
+	otherService := makeSubscription(t, "Spotify", userID, 500, date(2025, 1, 1), nil)
+	otherUser := makeSubscription(t, "Netflix", uuid.New(), 500, date(2025, 1, 1), nil)
+	addAll(t, repo, match, tooCheap, ended, otherService, otherUser)
+
+	prefix := "net"
+	minPrice := 200
+	openEnded := true
+
+	// Act
+	page, err := repo.List(t.Context(), dto.SubscriptionFilter{
+		UserID:            &userID,
+		ServiceNamePrefix: &prefix,
+		PriceMin:          &minPrice,
+		ActiveAt:          datePtr(2025, 6, 1),
+		OpenEnded:         &openEnded,
+		Page:              1,
+		PageSize:          10,
+		IncludeTotal:      true,
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{match.ID()}, getIDs(page.Subscriptions))
+	require.NotNil(t, page.Total)
+	assert.Equal(t, 1, *page.Total)
+}
+
+func testListPagination(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	for range 15 {
+		addAll(t, repo, testutil.MakeTestSubscription(t))
+	}
+
+	// Act
+	page1, err1 := repo.List(t.Context(), dto.SubscriptionFilter{Page: 1, PageSize: 10})
+	page2, err2 := repo.List(t.Context(), dto.SubscriptionFilter{Page: 2, PageSize: 10})
+
+	// Assert
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.Len(t, page1.Subscriptions, 10)
+	assert.True(t, page1.HasMore)
+	assert.Len(t, page2.Subscriptions, 5)
+	assert.False(t, page2.HasMore)
+	assert.Empty(t, intersect(getIDs(page1.Subscriptions), getIDs(page2.Subscriptions)))
+}
+
+func testListPaginationEdgeCases(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	for range 5 {
+		addAll(t, repo, testutil.MakeTestSubscription(t))
+	}
+
+	// Act & Assert
+	t.Run("Page size larger than total", func(t *testing.T) {
+		page, err := repo.List(t.Context(), dto.SubscriptionFilter{Page: 1, PageSize: 100})
+		require.NoError(t, err)
+		assert.Len(t, page.Subscriptions, 5)
+		assert.False(t, page.HasMore)
+	})
+
+	t.Run("Page size equal to total", func(t *testing.T) {
+		page, err := repo.List(t.Context(), dto.SubscriptionFilter{Page: 1, PageSize: 5})
+		require.NoError(t, err)
+		assert.Len(t, page.Subscriptions, 5)
+		assert.False(t, page.HasMore)
+	})
+
+	t.Run("Page past the end", func(t *testing.T) {
+		page, err := repo.List(t.Context(), dto.SubscriptionFilter{Page: 3, PageSize: 5})
+		require.NoError(t, err)
+		assert.Len(t, page.Subscriptions, 0)
+		assert.False(t, page.HasMore)
+	})
+
+	t.Run("Last partial page", func(t *testing.T) {
+		page, err := repo.List(t.Context(), dto.SubscriptionFilter{Page: 2, PageSize: 3})
+		require.NoError(t, err)
+		assert.Len(t, page.Subscriptions, 2)
+		assert.False(t, page.HasMore)
+	})
+
+	t.Run("Page size of one", func(t *testing.T) {
+		page, err := repo.List(t.Context(), dto.SubscriptionFilter{Page: 4, PageSize: 1})
+		require.NoError(t, err)
+		assert.Len(t, page.Subscriptions, 1)
+		assert.True(t, page.HasMore)
+	})
+}
+
+func testListOrder(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	sameDay1 := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 8, 1), nil)
+	sameDay2 := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 8, 1), nil)
+	later := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 9, 1), nil)
+	earlier := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 7, 1), nil)
+	addAll(t, repo, later, sameDay1, earlier, sameDay2)
+
+	// Ties on start_date are broken by id in its canonical text form.
+	first, second := sameDay1, sameDay2
+	if first.ID().String() > second.ID().String() {
+		first, second = second, first
+	}
+
+	// Act
+	page, err := repo.List(t.Context(), dto.SubscriptionFilter{Page: 1, PageSize: 10})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{earlier.ID(), first.ID(), second.ID(), later.ID()}, getIDs(page.Subscriptions))
+}
+
+func testListSort(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	cheapOld := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 1, 1), nil)
+	cheapNew := makeSubscription(t, "serviceB", uuid.New(), 100, date(2025, 5, 1), nil)
+	pricey := makeSubscription(t, "serviceC", uuid.New(), 500, date(2025, 3, 1), nil)
+	addAll(t, repo, cheapOld, pricey, cheapNew)
+
+	t.Run("price, -start_date", func(t *testing.T) {
+		// Act
+		page, err := repo.List(t.Context(), dto.SubscriptionFilter{
+			Sort:     []dto.SortOrder{{Field: dto.SortByPrice}, {Field: dto.SortByStartDate, Desc: true}},
+			Page:     1,
+			PageSize: 10,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{cheapNew.ID(), cheapOld.ID(), pricey.ID()}, getIDs(page.Subscriptions))
+	})
+
+	t.Run("-service_name", func(t *testing.T) {
+		// Act
+		page, err := repo.List(t.Context(), dto.SubscriptionFilter{
+			Sort:     []dto.SortOrder{{Field: dto.SortByServiceName, Desc: true}},
+			Page:     1,
+			PageSize: 10,
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{pricey.ID(), cheapNew.ID(), cheapOld.ID()}, getIDs(page.Subscriptions))
+	})
+}
+
+func testListSortEndDateNullsLast(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	openEnded := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 1, 1), nil)
+	endsSoon := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 1, 1), datePtr(2025, 3, 1))
+	endsLate := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 1, 1), datePtr(2025, 9, 1))
+	addAll(t, repo, openEnded, endsLate, endsSoon)
+
+	for _, tt := range []struct {
+		name     string
+		desc     bool
+		expected []uuid.UUID
+	}{
+		{"ascending", false, []uuid.UUID{endsSoon.ID(), endsLate.ID(), openEnded.ID()}},
+		{"descending", true, []uuid.UUID{endsLate.ID(), endsSoon.ID(), openEnded.ID()}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			page, err := repo.List(t.Context(), dto.SubscriptionFilter{
+				Sort:     []dto.SortOrder{{Field: dto.SortByEndDate, Desc: tt.desc}},
+				Page:     1,
+				PageSize: 10,
+			})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, getIDs(page.Subscriptions))
+		})
+	}
+}
+
+func testListSortPagination(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	var all []*entity.Subscription
+	for i := range 5 {
+		all = append(all, makeSubscription(t, "serviceA", uuid.New(), 100*(5-i), date(2025, 1, 1), nil))
+	}
+	addAll(t, repo, all...)
+
+	sort := []dto.SortOrder{{Field: dto.SortByPrice}}
+
+	// Act
+	first, err1 := repo.List(t.Context(), dto.SubscriptionFilter{Sort: sort, Page: 1, PageSize: 3})
+	second, err2 := repo.List(t.Context(), dto.SubscriptionFilter{Sort: sort, Page: 2, PageSize: 3})
+
+	// Assert
+	require.NoError(t, err1)
+	require.NoError(t, err2)
+	assert.True(t, first.HasMore)
+	assert.False(t, second.HasMore)
+	assert.Equal(t, []uuid.UUID{all[4].ID(), all[3].ID(), all[2].ID()}, getIDs(first.Subscriptions))
+	assert.Equal(t, []uuid.UUID{all[1].ID(), all[0].ID()}, getIDs(second.Subscriptions))
+}
+
+func testListTotal(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	userID := uuid.New()
+	for i := range 4 {
+		addAll(t, repo, makeSubscription(t, "serviceA", userID, 100, date(2025, time.Month(1+i), 1), nil))
+	}
+	addAll(t, repo, makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 1, 1), nil))
+
+	t.Run("Counts every match regardless of page", func(t *testing.T) {
+		// Act
+		page, err := repo.List(t.Context(), dto.SubscriptionFilter{UserID: &userID, Page: 2, PageSize: 3, IncludeTotal: true})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, page.Subscriptions, 1)
+		require.NotNil(t, page.Total)
+		assert.Equal(t, 4, *page.Total)
+	})
+
+	t.Run("Counts every match regardless of cursor", func(t *testing.T) {
+		// Arrange
+		first, err := repo.List(t.Context(), dto.SubscriptionFilter{UserID: &userID, PageSize: 1})
+		require.NoError(t, err)
+		after := dto.Cursor{StartDate: first.Subscriptions[0].StartDate(), ID: first.Subscriptions[0].ID()}
+
+		// Act
+		page, err := repo.List(t.Context(), dto.SubscriptionFilter{UserID: &userID, After: &after, PageSize: 10, IncludeTotal: true})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, page.Subscriptions, 3)
+		require.NotNil(t, page.Total)
+		assert.Equal(t, 4, *page.Total)
+	})
+
+	t.Run("Skipped unless requested", func(t *testing.T) {
+		// Act
+		page, err := repo.List(t.Context(), dto.SubscriptionFilter{UserID: &userID, Page: 1, PageSize: 10})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Nil(t, page.Total)
+	})
+}
+
+func testListCursor(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	var all []*entity.Subscription
+	for i := range 7 {
+		// Pairs of subscriptions share a start date, so the id breaks ties.
+		sub := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, time.Month(1+i/2), 1), nil)
+		all = append(all, sub)
+	}
+	addAll(t, repo, all...)
+
+	expected, err := repo.List(t.Context(), dto.SubscriptionFilter{Page: 1, PageSize: 100})
+	require.NoError(t, err)
+
+	// Act
+	var visited []uuid.UUID
+	var after *dto.Cursor
+	pages := 0
+	for {
+		page, err := repo.List(t.Context(), dto.SubscriptionFilter{After: after, PageSize: 3})
+		require.NoError(t, err)
+		pages++
+
+		visited = append(visited, getIDs(page.Subscriptions)...)
+		if !page.HasMore {
+			break
+		}
+
+		last := page.Subscriptions[len(page.Subscriptions)-1]
+		after = &dto.Cursor{StartDate: last.StartDate(), ID: last.ID()}
+	}
+
+	// Assert
+	assert.Equal(t, 3, pages)
+	assert.Equal(t, getIDs(expected.Subscriptions), visited)
+}
+
+func testListCursorIgnoresPage(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	sub1 := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 7, 1), nil)
+	sub2 := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 8, 1), nil)
+	addAll(t, repo, sub1, sub2)
+
+	filter := dto.SubscriptionFilter{
+		After:    &dto.Cursor{StartDate: sub1.StartDate(), ID: sub1.ID()},
+		Page:     5,
+		PageSize: 10,
+	}
+
+	// Act
+	page, err := repo.List(t.Context(), filter)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{sub2.ID()}, getIDs(page.Subscriptions))
+	assert.False(t, page.HasMore)
+}
+
+func testListCursorWithFilters(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	userID := uuid.New()
+	sub1 := makeSubscription(t, "serviceA", userID, 100, date(2025, 7, 1), nil)
+	other := makeSubscription(t, "serviceA", uuid.New(), 100, date(2025, 8, 1), nil)
+	sub2 := makeSubscription(t, "serviceA", userID, 100, date(2025, 9, 1), nil)
+	addAll(t, repo, sub1, other, sub2)
+
+	filter := dto.SubscriptionFilter{
+		UserID:   &userID,
+		After:    &dto.Cursor{StartDate: sub1.StartDate(), ID: sub1.ID()},
+		PageSize: 10,
+	}
+
+	// Act
+	page, err := repo.List(t.Context(), filter)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{sub2.ID()}, getIDs(page.Subscriptions))
+}
+
+func testCalculateTotalCost(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	userID := uuid.New()
+	sub1 := makeSubscription(t, "serviceA", userID, 100, date(2025, 7, 1), nil)
+	sub2 := makeSubscription(t, "serviceA", userID, 150, date(2025, 8, 1), datePtr(2025, 8, 15))
+	sub3 := makeSubscription(t, "serviceB", userID, 200, date(2025, 8, 1), nil)
+	sub4 := makeSubscription(t, "serviceA", uuid.New(), 400, date(2025, 8, 1), nil)
+	sub5 := makeSubscription(t, "serviceA", userID, 800, date(2025, 9, 1), nil)
+	addAll(t, repo, sub1, sub2, sub3, sub4, sub5)
+
+	filter := dto.TotalCostFilter{
+		UserID:      userID,
+		ServiceName: "serviceA",
+		PeriodStart: date(2025, 7, 1),
+		PeriodEnd:   time.Date(2025, 8, 31, 23, 59, 59, 0, time.UTC),
+	}
+
+	// Act
+	total, err := repo.CalculateTotalCost(t.Context(), filter)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 250, total) // sub1 + sub2
+}
+
+func testCalculateTotalCostPeriodBoundaries(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	userID := uuid.New()
+	before := makeSubscription(t, "serviceA", userID, 1, date(2025, 6, 30), nil)
+	first := makeSubscription(t, "serviceA", userID, 10, date(2025, 7, 1), nil)
+	last := makeSubscription(t, "serviceA", userID, 100, date(2025, 9, 1), nil)
+	after := makeSubscription(t, "serviceA", userID, 1000, date(2025, 9, 2), nil)
+	addAll(t, repo, before, first, last, after)
+
+	filter := dto.TotalCostFilter{
+		UserID:      userID,
+		ServiceName: "serviceA",
+		PeriodStart: date(2025, 7, 1),
+		PeriodEnd:   date(2025, 9, 1),
+	}
+
+	// Act
+	total, err := repo.CalculateTotalCost(t.Context(), filter)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 110, total) // first + last
+}
+
+func testCalculateTotalCostNoMatches(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	addAll(t, repo, testutil.MakeTestSubscription(t))
+
+	filter := dto.TotalCostFilter{
+		UserID:      uuid.New(),
+		ServiceName: "test_service",
+		PeriodStart: date(2025, 1, 1),
+		PeriodEnd:   date(2025, 12, 1),
+	}
+
+	// Act
+	total, err := repo.CalculateTotalCost(t.Context(), filter)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, total)
+}
+
+func testNotes(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	sub := makeSubscriptionWithNotes(t, "serviceA", "family plan", uuid.New())
+	addAll(t, repo, sub)
+
+	// Act
+	sub.SetNotes("")
+	require.NoError(t, repo.Update(t.Context(), sub))
+	got, err := repo.Get(t.Context(), sub.ID())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, got.Notes())
+}
+
+func testSearchServiceName(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	netflix := makeSubscriptionWithNotes(t, "Netflix", "", uuid.New())
+	spotify := makeSubscriptionWithNotes(t, "Spotify", "", uuid.New())
+	addAll(t, repo, netflix, spotify)
+
+	// Act
+	subs, err := repo.Search(t.Context(), dto.SubscriptionSearchFilter{Query: "netflix", Limit: 10})
+
+	// Assert
+	assert.NoError(t, err)
+	require.Len(t, subs, 1)
+	assertSameSubscription(t, netflix, subs[0])
+}
+
+func testSearchNotes(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	shared := makeSubscriptionWithNotes(t, "Netflix", "Family plan shared with parents", uuid.New())
+	personal := makeSubscriptionWithNotes(t, "Spotify", "Personal account", uuid.New())
+	addAll(t, repo, shared, personal)
+
+	// Act
+	subs, err := repo.Search(t.Context(), dto.SubscriptionSearchFilter{Query: "family", Limit: 10})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{shared.ID()}, getIDs(subs))
+}
+
+func testSearchRanksServiceNameAboveNotes(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	inNotes := makeSubscriptionWithNotes(t, "Spotify", "music streaming", uuid.New())
+	inServiceName := makeSubscriptionWithNotes(t, "Music Plus", "", uuid.New())
+	addAll(t, repo, inNotes, inServiceName)
+
+	// Act
+	subs, err := repo.Search(t.Context(), dto.SubscriptionSearchFilter{Query: "music", Limit: 10})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{inServiceName.ID(), inNotes.ID()}, getIDs(subs))
+}
+
+func testSearchFilterByUserID(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	userID := uuid.New()
+	own := makeSubscriptionWithNotes(t, "Netflix", "", userID)
+	foreign := makeSubscriptionWithNotes(t, "Netflix", "", uuid.New())
+	addAll(t, repo, own, foreign)
+
+	// Act
+	subs, err := repo.Search(t.Context(), dto.SubscriptionSearchFilter{Query: "netflix", UserID: &userID, Limit: 10})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{own.ID()}, getIDs(subs))
+}
+
+func testSearchLimit(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	for range 5 {
+		addAll(t, repo, makeSubscriptionWithNotes(t, "Netflix", "", uuid.New()))
+	}
+
+	// Act
+	subs, err := repo.Search(t.Context(), dto.SubscriptionSearchFilter{Query: "netflix", Limit: 3})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, subs, 3)
+}
+
+func testSearchNoMatches(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	addAll(t, repo, makeSubscriptionWithNotes(t, "Netflix", "Family plan", uuid.New()))
+
+	// Act
+	subs, err := repo.Search(t.Context(), dto.SubscriptionSearchFilter{Query: "youtube", Limit: 10})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, subs)
+}
+
+func testListByUser(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	userID := uuid.New()
+	later := makeSubscription(t, "Netflix", userID, 100, date(2025, 3, 1), nil)
+	earlier := makeSubscription(t, "Spotify", userID, 200, date(2025, 1, 1), datePtr(2025, 6, 1))
+	other := makeSubscription(t, "Netflix", uuid.New(), 300, date(2025, 2, 1), nil)
+	addAll(t, repo, later, earlier, other)
+
+	// Act
+	subs, err := repo.ListByUser(t.Context(), userID)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, subs, 2)
+	assertSameSubscription(t, earlier, subs[0])
+	assertSameSubscription(t, later, subs[1])
+}
+
+func testMerge(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	userID := uuid.New()
+	target := makeSubscription(t, "Netflix", userID, 100, date(2025, 3, 1), datePtr(2025, 6, 1))
+	source := makeSubscription(t, "netflix", userID, 200, date(2025, 1, 1), nil)
+	addAll(t, repo, target, source)
+
+	merged, err := repo.Get(t.Context(), target.ID())
+	require.NoError(t, err)
+	require.NoError(t, merged.Absorb(source))
+
+	// Act
+	err = repo.Merge(t.Context(), dto.SubscriptionMerge{
+		Target:   merged,
+		Sources:  []*entity.Subscription{source},
+		MergedBy: "alice",
+		MergedAt: time.Now().UTC(),
+	})
+
+	// Assert
+	require.NoError(t, err)
+	got, err := repo.Get(t.Context(), target.ID())
+	require.NoError(t, err)
+	assertSameSubscription(t, merged, got)
+	_, err = repo.Get(t.Context(), source.ID())
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+}
+
+func testMergeSourceNotFound(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	userID := uuid.New()
+	target := makeSubscription(t, "Netflix", userID, 100, date(2025, 3, 1), nil)
+	source := makeSubscription(t, "Netflix", userID, 200, date(2025, 1, 1), nil)
+	missing := makeSubscription(t, "Netflix", userID, 300, date(2025, 2, 1), nil)
+	addAll(t, repo, target, source)
+
+	merged, err := repo.Get(t.Context(), target.ID())
+	require.NoError(t, err)
+	merged.SetPrice(999)
+
+	// Act
+	err = repo.Merge(t.Context(), dto.SubscriptionMerge{
+		Target:   merged,
+		Sources:  []*entity.Subscription{source, missing},
+		MergedBy: "alice",
+		MergedAt: time.Now().UTC(),
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+	got, errTarget := repo.Get(t.Context(), target.ID())
+	require.NoError(t, errTarget)
+	assertSameSubscription(t, target, got)
+	_, errSource := repo.Get(t.Context(), source.ID())
+	assert.NoError(t, errSource)
+}
+
+func testMergeTargetNotFound(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	userID := uuid.New()
+	target := makeSubscription(t, "Netflix", userID, 100, date(2025, 3, 1), nil)
+	source := makeSubscription(t, "Netflix", userID, 200, date(2025, 1, 1), nil)
+	addAll(t, repo, source)
+
+	// Act
+	err := repo.Merge(t.Context(), dto.SubscriptionMerge{
+		Target:   target,
+		Sources:  []*entity.Subscription{source},
+		MergedBy: "alice",
+		MergedAt: time.Now().UTC(),
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+	_, errSource := repo.Get(t.Context(), source.ID())
+	assert.NoError(t, errSource)
+}
+
+func tenantContext(t *testing.T, tenantID string) context.Context {
+	return usecase.WithTenant(t.Context(), usecase.Tenant{ID: tenantID})
+}
+
+func testTenantGetIsolated(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	acme, globex := tenantContext(t, "acme"), tenantContext(t, "globex")
+	sub := testutil.MakeTestSubscription(t)
+	require.NoError(t, repo.Add(acme, sub))
+
+	// Act
+	_, errForeign := repo.Get(globex, sub.ID())
+	got, errOwn := repo.Get(acme, sub.ID())
+
+	// Assert
+	assert.ErrorIs(t, errForeign, usecase.ErrNotFound)
+	require.NoError(t, errOwn)
+	assertSameSubscription(t, sub, got)
+}
+
+func testTenantListIsolated(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	acme, globex := tenantContext(t, "acme"), tenantContext(t, "globex")
+	userID := uuid.New()
+	own := makeSubscription(t, "Netflix", userID, 100, date(2025, 1, 1), nil)
+	foreign := makeSubscription(t, "Netflix", userID, 200, date(2025, 1, 1), nil)
+	require.NoError(t, repo.Add(acme, own))
+	require.NoError(t, repo.Add(globex, foreign))
+
+	// Act
+	page, err := repo.List(acme, dto.SubscriptionFilter{UserID: &userID, Page: 1, PageSize: 10, IncludeTotal: true})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{own.ID()}, getIDs(page.Subscriptions))
+	require.NotNil(t, page.Total)
+	assert.Equal(t, 1, *page.Total)
+}
+
+func testTenantCalculateTotalCostIsolated(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	acme, globex := tenantContext(t, "acme"), tenantContext(t, "globex")
+	userID := uuid.New()
+	require.NoError(t, repo.Add(acme, makeSubscription(t, "Netflix", userID, 100, date(2025, 1, 1), nil)))
+	require.NoError(t, repo.Add(globex, makeSubscription(t, "Netflix", userID, 200, date(2025, 1, 1), nil)))
+
+	// Act
+	total, err := repo.CalculateTotalCost(acme, dto.TotalCostFilter{
+		UserID:      userID,
+		ServiceName: "Netflix",
+		PeriodStart: date(2025, 1, 1),
+		PeriodEnd:   date(2025, 12, 1),
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 100, total)
+}
+
+func testTenantSearchIsolated(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	acme, globex := tenantContext(t, "acme"), tenantContext(t, "globex")
+	own := makeSubscriptionWithNotes(t, "Netflix", "", uuid.New())
+	require.NoError(t, repo.Add(acme, own))
+	require.NoError(t, repo.Add(globex, makeSubscriptionWithNotes(t, "Netflix", "", uuid.New())))
+
+	// Act
+	subs, err := repo.Search(acme, dto.SubscriptionSearchFilter{Query: "netflix", Limit: 10})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{own.ID()}, getIDs(subs))
+}
+
+func testTenantUpdateForeignFails(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	acme, globex := tenantContext(t, "acme"), tenantContext(t, "globex")
+	sub := testutil.MakeTestSubscription(t)
+	require.NoError(t, repo.Add(acme, sub))
+
+	changed, err := repo.Get(acme, sub.ID())
+	require.NoError(t, err)
+	changed.SetPrice(999)
+
+	// Act
+	errUpdate := repo.Update(globex, changed)
+	got, errGet := repo.Get(acme, sub.ID())
+
+	// Assert
+	assert.ErrorIs(t, errUpdate, usecase.ErrRepository)
+	require.NoError(t, errGet)
+	assertSameSubscription(t, sub, got)
+}
+
+func testTenantDeleteForeignIgnored(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	acme, globex := tenantContext(t, "acme"), tenantContext(t, "globex")
+	sub := testutil.MakeTestSubscription(t)
+	require.NoError(t, repo.Add(acme, sub))
+
+	// Act
+	errDelete := repo.Delete(globex, sub.ID())
+	_, errGet := repo.Get(acme, sub.ID())
+
+	// Assert
+	assert.NoError(t, errDelete)
+	assert.NoError(t, errGet)
+}
+
+func testTenantDefaultWithoutContext(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	sub := testutil.MakeTestSubscription(t)
+	require.NoError(t, repo.Add(t.Context(), sub))
+
+	// Act
+	_, errOther := repo.Get(tenantContext(t, "acme"), sub.ID())
+	_, errDefault := repo.Get(tenantContext(t, usecase.DefaultTenantID), sub.ID())
+
+	// Assert
+	assert.ErrorIs(t, errOther, usecase.ErrNotFound)
+	assert.NoError(t, errDefault)
+}
+
+func testTenantListByUserIsolated(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	acme, globex := tenantContext(t, "acme"), tenantContext(t, "globex")
+	userID := uuid.New()
+	own := makeSubscription(t, "Netflix", userID, 100, date(2025, 1, 1), nil)
+	require.NoError(t, repo.Add(acme, own))
+	require.NoError(t, repo.Add(globex, makeSubscription(t, "Netflix", userID, 200, date(2025, 1, 1), nil)))
+
+	// Act
+	subs, err := repo.ListByUser(acme, userID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{own.ID()}, getIDs(subs))
+}
+
+func testTenantMergeForeignFails(t *testing.T, repo usecase.SubscriptionRepository) {
+	// Arrange
+	acme, globex := tenantContext(t, "acme"), tenantContext(t, "globex")
+	userID := uuid.New()
+	target := makeSubscription(t, "Netflix", userID, 100, date(2025, 3, 1), nil)
+	foreign := makeSubscription(t, "Netflix", userID, 200, date(2025, 1, 1), nil)
+	require.NoError(t, repo.Add(acme, target))
+	require.NoError(t, repo.Add(globex, foreign))
+
+	// Act
+	err := repo.Merge(acme, dto.SubscriptionMerge{
+		Target:   target,
+		Sources:  []*entity.Subscription{foreign},
+		MergedBy: "alice",
+		MergedAt: time.Now().UTC(),
+	})
+
+	// Assert
+	assert.ErrorIs(t, err, usecase.ErrNotFound)
+	_, errForeign := repo.Get(globex, foreign.ID())
+	assert.NoError(t, errForeign)
+}
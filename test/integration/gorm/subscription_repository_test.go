@@ -7,9 +7,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/google/uuid"
-	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 
 	"github.com/MDx3R/ef-test/internal/config"
@@ -17,6 +14,10 @@ import (
 	gormdb "github.com/MDx3R/ef-test/internal/infra/database/gorm"
 	"github.com/MDx3R/ef-test/internal/usecase"
 	"github.com/MDx3R/ef-test/internal/usecase/dto"
+	"github.com/MDx3R/ef-test/test/contract"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
@@ -83,6 +84,11 @@ func TestMain(m *testing.M) {
 	testDB = gormDB.GetDB()
 	repo = gormdb.NewGormSubscriptionRepository(testDB)
 
+	// AutoMigrate does not create extensions the search relies on.
+	if err := testDB.Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		log.Fatalf("Failed to create pg_trgm extension: %v", err)
+	}
+
 	code := m.Run()
 
 	if err := gormDB.Dispose(); err != nil {
@@ -97,329 +103,49 @@ func TestMain(m *testing.M) {
 }
 
 func clearTable(t *testing.T) {
-	err := testDB.Exec("TRUNCATE TABLE subscriptions RESTART IDENTITY CASCADE").Error
+	err := testDB.Exec("TRUNCATE TABLE subscriptions, subscription_merges RESTART IDENTITY CASCADE").Error
 	if err != nil {
 		t.Fatalf("Failed to clear table: %v", err)
 	}
 }
 
-func makeTestSubscription(t *testing.T) *entity.Subscription {
-	id := uuid.New()
-	sub, _ := entity.NewSubscriptionWithID(
-		id,
-		"test_service",
-		uuid.New(),
-		100,
-		time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
-		nil,
-	)
-	return sub
-}
-
-func timePtr(t time.Time) *time.Time {
-	return &t
-}
-
-func getIDs(subs []*entity.Subscription) []uuid.UUID {
-	ids := make([]uuid.UUID, 0, len(subs))
-	for _, s := range subs {
-		ids = append(ids, s.ID())
-	}
-	return ids
-}
-
-func TestGormSubscriptionRepository_AddAndGet(t *testing.T) {
-	clearTable(t)
-
-	// Arrange
-	sub := makeTestSubscription(t)
-
-	// Act
-	errAdd := repo.Add(sub)
-	got, errGet := repo.Get(sub.ID())
-
-	// Assert
-	assert.NoError(t, errAdd)
-	assert.NoError(t, errGet)
-	assert.Equal(t, sub.ID(), got.ID())
-	assert.Equal(t, sub.UserID(), got.UserID())
-	assert.Equal(t, sub.ServiceName(), got.ServiceName())
-	assert.Equal(t, sub.Price(), got.Price())
-}
-
-func TestGormSubscriptionRepository_Get_NotFound(t *testing.T) {
-	clearTable(t)
-
-	// Arrange
-	randomID := uuid.New()
-
-	// Act
-	_, err := repo.Get(randomID)
-
-	// Assert
-	assert.ErrorIs(t, err, usecase.ErrNotFound)
-}
-
-func TestGormSubscriptionRepository_List(t *testing.T) {
-	clearTable(t)
-
-	// Arrange
-	sub1 := makeTestSubscription(t)
-	sub2 := makeTestSubscription(t)
-	err := repo.Add(sub1)
-	assert.NoError(t, err)
-	err = repo.Add(sub2)
-	assert.NoError(t, err)
-
-	filter := dto.SubscriptionFilter{
-		Page:     1,
-		PageSize: 10,
-	}
-
-	// Act
-	list, err := repo.List(filter)
-
-	// Assert
-	assert.NoError(t, err)
-	assert.GreaterOrEqual(t, len(list), 2)
-}
-
-func TestGormSubscriptionRepository_List_EmptyResult(t *testing.T) {
-	clearTable(t)
-
-	// Arrange
-	serviceName := "non-existent-service"
-	filter := dto.SubscriptionFilter{
-		Page:        1,
-		PageSize:    10,
-		ServiceName: &serviceName,
-	}
-
-	// Act
-	list, err := repo.List(filter)
-
-	// Assert
-	assert.NoError(t, err)
-	assert.Len(t, list, 0)
-}
-
-func TestGormSubscriptionRepository_List_Pagination(t *testing.T) {
-	clearTable(t)
-
-	// Arrange
-	for range 15 {
-		sub := makeTestSubscription(t)
-		err := repo.Add(sub)
-		assert.NoError(t, err)
-	}
-
-	filterPage1 := dto.SubscriptionFilter{
-		Page:     1,
-		PageSize: 10,
-	}
-	filterPage2 := dto.SubscriptionFilter{
-		Page:     2,
-		PageSize: 10,
-	}
-
-	// Act
-	listPage1, err1 := repo.List(filterPage1)
-	listPage2, err2 := repo.List(filterPage2)
-
-	// Assert
-	assert.NoError(t, err1)
-	assert.NoError(t, err2)
-	assert.Len(t, listPage1, 10)
-	assert.Len(t, listPage2, 5)
-}
-
-func TestGormSubscriptionRepository_List_FilterByUserID(t *testing.T) {
-	clearTable(t)
-
-	// Arrange
-	userID1 := uuid.New()
-	userID2 := uuid.New()
-
-	sub1, _ := entity.NewSubscriptionWithID(uuid.New(), "service1", userID1, 50, time.Now(), nil)
-	sub2, _ := entity.NewSubscriptionWithID(uuid.New(), "service2", userID2, 100, time.Now(), nil)
-
-	assert.NoError(t, repo.Add(sub1))
-	assert.NoError(t, repo.Add(sub2))
-
-	filter := dto.SubscriptionFilter{
-		UserID:   &userID1,
-		Page:     1,
-		PageSize: 10,
-	}
-
-	// Act
-	list, err := repo.List(filter)
-
-	// Assert
-	assert.NoError(t, err)
-	assert.Len(t, list, 1)
-	assert.Equal(t, userID1, list[0].UserID())
-}
-
-func TestGormSubscriptionRepository_List_FilterByServiceName(t *testing.T) {
-	clearTable(t)
-
-	// Arrange
-	sub1, _ := entity.NewSubscriptionWithID(uuid.New(), "serviceA", uuid.New(), 50, time.Now(), nil)
-	sub2, _ := entity.NewSubscriptionWithID(uuid.New(), "serviceB", uuid.New(), 100, time.Now(), nil)
-
-	assert.NoError(t, repo.Add(sub1))
-	assert.NoError(t, repo.Add(sub2))
-
-	serviceName := "serviceB"
-	filter := dto.SubscriptionFilter{
-		ServiceName: &serviceName,
-		Page:        1,
-		PageSize:    10,
-	}
-
-	// Act
-	list, err := repo.List(filter)
-
-	// Assert
-	assert.NoError(t, err)
-	assert.Len(t, list, 1)
-	assert.Equal(t, "serviceB", list[0].ServiceName())
-}
-
-func TestSubscriptionRepository_List_FilterDates(t *testing.T) {
-	clearTable(t)
-
-	// Arrange
-	startDate := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
-	endDate := time.Date(2025, 8, 31, 23, 59, 59, 0, time.UTC)
-
-	// 1. start_date: 2025-07-01, end_date: NULL
-	sub1, _ := entity.NewSubscriptionWithID(uuid.New(), "serviceA", uuid.New(), 100, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), nil)
-
-	// 2. start_date: 2025-08-05, end_date: 2025-08-20
-	sub2, _ := entity.NewSubscriptionWithID(uuid.New(), "serviceA", uuid.New(), 150, time.Date(2025, 8, 5, 0, 0, 0, 0, time.UTC), timePtr(time.Date(2025, 8, 20, 0, 0, 0, 0, time.UTC)))
-
-	// 3. start_date: 2025-08-15, end_date: 2025-09-01
-	sub3, _ := entity.NewSubscriptionWithID(uuid.New(), "serviceB", uuid.New(), 200, time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC), timePtr(time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)))
-
-	for _, s := range []*entity.Subscription{sub1, sub2, sub3} {
-		assert.NoError(t, repo.Add(s))
-	}
-
-	// Act & Assert
-	t.Run("StartDate set, EndDate nil", func(t *testing.T) {
-		filter := dto.SubscriptionFilter{
-			StartDate: &startDate,
-			Page:      1,
-			PageSize:  10,
-		}
-		subs, err := repo.List(filter)
-		require.NoError(t, err)
-
-		// start_date >= 2025-08-01 => sub2 и sub3
-		assert.ElementsMatch(t, []uuid.UUID{sub2.ID(), sub3.ID()}, getIDs(subs))
+func TestGormSubscriptionRepository_Contract(t *testing.T) {
+	contract.RunSubscriptionRepositoryTests(t, func(t *testing.T) usecase.SubscriptionRepository {
+		clearTable(t)
+		return repo
 	})
+}
 
-	t.Run("EndDate set, StartDate nil", func(t *testing.T) {
-		filter := dto.SubscriptionFilter{
-			EndDate:  &endDate,
-			Page:     1,
-			PageSize: 10,
+func TestGormAPIKeyRepository_Contract(t *testing.T) {
+	contract.RunAPIKeyRepositoryTests(t, func(t *testing.T) usecase.APIKeyRepository {
+		if err := testDB.Exec("TRUNCATE TABLE api_keys").Error; err != nil {
+			t.Fatalf("Failed to clear table: %v", err)
 		}
-		subs, err := repo.List(filter)
-		require.NoError(t, err)
-
-		// end_date <= 2025-08-31 OR end_date IS NULL
-		// sub1: end_date=NUL
-		// sub2: end_date=2025-08-20 <= endDate
-		// sub3: end_date=2025-09-01 > endDate -> exclude
-		assert.ElementsMatch(t, []uuid.UUID{sub1.ID(), sub2.ID()}, getIDs(subs))
+		return gormdb.NewGormAPIKeyRepository(testDB)
 	})
+}
 
-	t.Run("StartDate и EndDate установлены", func(t *testing.T) {
-		filter := dto.SubscriptionFilter{
-			StartDate: &startDate,
-			EndDate:   &endDate,
-			Page:      1,
-			PageSize:  10,
+func TestGormIdempotencyRepository_Contract(t *testing.T) {
+	contract.RunIdempotencyRepositoryTests(t, func(t *testing.T) usecase.IdempotencyRepository {
+		if err := testDB.Exec("TRUNCATE TABLE idempotency_keys").Error; err != nil {
+			t.Fatalf("Failed to clear table: %v", err)
 		}
-		subs, err := repo.List(filter)
-		require.NoError(t, err)
-
-		// start_date >= 2025-08-01 AND (end_date <= 2025-08-31 OR end_date IS NULL)
-		// sub1: start_date=2025-07-01 < startDate -> exclude
-		// sub2: start_date=2025-08-05 >= startDate AND end_date=2025-08-20 <= endDate
-		// sub3: start_date=2025-08-15 >= startDate AND end_date=2025-09-01 > endDate - exclude
-		assert.ElementsMatch(t, []uuid.UUID{sub2.ID()}, getIDs(subs))
+		return gormdb.NewGormIdempotencyRepository(testDB)
 	})
 }
 
-func TestGormSubscriptionRepository_Update(t *testing.T) {
-	clearTable(t)
-
-	// Arrange
-	sub := makeTestSubscription(t)
-	err := repo.Add(sub)
-	assert.NoError(t, err)
-
-	sub.SetServiceName("updated_service")
-	sub.SetPrice(200)
-
-	// Act
-	errUpdate := repo.Update(sub)
-	got, errGet := repo.Get(sub.ID())
-
-	// Assert
-	assert.NoError(t, errUpdate)
-	assert.NoError(t, errGet)
-	assert.Equal(t, "updated_service", got.ServiceName())
-	assert.Equal(t, 200, got.Price())
-}
-
-func TestGormSubscriptionRepository_Delete(t *testing.T) {
-	clearTable(t)
-
+func TestGormSubscriptionRepository_Search_Misspelled(t *testing.T) {
 	// Arrange
-	sub := makeTestSubscription(t)
-	err := repo.Add(sub)
-	assert.NoError(t, err)
-
-	// Act
-	errDelete := repo.Delete(sub.ID())
-	_, errGet := repo.Get(sub.ID())
-
-	// Assert
-	assert.NoError(t, errDelete)
-	assert.ErrorIs(t, errGet, usecase.ErrNotFound)
-}
-
-func TestGormSubscriptionRepository_CalculateTotalCost(t *testing.T) {
 	clearTable(t)
-
-	// Arrange
-	userID := uuid.New()
-	sub1, _ := entity.NewSubscriptionWithID(uuid.New(), "serviceA", userID, 100, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), nil)
-	sub2, _ := entity.NewSubscriptionWithID(uuid.New(), "serviceA", userID, 150, time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), nil)
-	sub3, _ := entity.NewSubscriptionWithID(uuid.New(), "serviceB", userID, 200, time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC), nil)
-
-	assert.NoError(t, repo.Add(sub1))
-	assert.NoError(t, repo.Add(sub2))
-	assert.NoError(t, repo.Add(sub3))
-
-	startDate := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
-	endDate := time.Date(2025, 8, 31, 23, 59, 59, 0, time.UTC)
-	filter := dto.TotalCostFilter{
-		UserID:      userID,
-		ServiceName: "serviceA",
-		PeriodStart: startDate,
-		PeriodEnd:   endDate,
-	}
+	netflix, err := entity.NewSubscription("Netflix", uuid.New(), 100, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.Add(t.Context(), netflix))
 
 	// Act
-	total, err := repo.CalculateTotalCost(filter)
+	subs, err := repo.Search(t.Context(), dto.SubscriptionSearchFilter{Query: "netflex", Limit: 10})
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, 250, total) // sub1 + sub2
+	require.Len(t, subs, 1)
+	assert.Equal(t, netflix.ID(), subs[0].ID())
 }
@@ -0,0 +1,96 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gormdb "github.com/MDx3R/ef-test/internal/infra/database/gorm"
+)
+
+// setSchemaVersion records the version the way golang-migrate does.
+func setSchemaVersion(t *testing.T, db *gormdb.GormDatabase, version uint, dirty bool) {
+	gdb := db.GetDB()
+	require.NoError(t, gdb.Exec("CREATE TABLE IF NOT EXISTS schema_migrations (version uint64, dirty bool)").Error)
+	require.NoError(t, gdb.Exec("DELETE FROM schema_migrations").Error)
+	require.NoError(t, gdb.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (?, ?)", version, dirty).Error)
+}
+
+func TestSQLiteDatabaseHealthCheck(t *testing.T) {
+	// Arrange
+	db := newDatabase(t)
+	check := gormdb.NewDatabaseHealthCheck(db)
+
+	// Act
+	err := check.Check(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "database", check.Name())
+}
+
+func TestSQLiteMigrationHealthCheck_UpToDate(t *testing.T) {
+	// Arrange
+	db := newDatabase(t)
+	setSchemaVersion(t, db, 7, false)
+	check := gormdb.NewMigrationHealthCheck(db, 7)
+
+	// Act
+	err := check.Check(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestSQLiteMigrationHealthCheck_NotApplied(t *testing.T) {
+	// Arrange
+	db := newDatabase(t)
+	check := gormdb.NewMigrationHealthCheck(db, 7)
+
+	// Act
+	err := check.Check(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "no migrations applied")
+}
+
+func TestSQLiteMigrationHealthCheck_Behind(t *testing.T) {
+	// Arrange
+	db := newDatabase(t)
+	setSchemaVersion(t, db, 6, false)
+	check := gormdb.NewMigrationHealthCheck(db, 7)
+
+	// Act
+	err := check.Check(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "schema version is 6, want 7")
+}
+
+func TestSQLiteMigrationHealthCheck_Ahead(t *testing.T) {
+	// Arrange
+	db := newDatabase(t)
+	setSchemaVersion(t, db, 8, false)
+	check := gormdb.NewMigrationHealthCheck(db, 7)
+
+	// Act
+	err := check.Check(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestSQLiteMigrationHealthCheck_Dirty(t *testing.T) {
+	// Arrange
+	db := newDatabase(t)
+	setSchemaVersion(t, db, 7, true)
+	check := gormdb.NewMigrationHealthCheck(db, 7)
+
+	// Act
+	err := check.Check(context.Background())
+
+	// Assert
+	assert.EqualError(t, err, "migration 7 failed, schema is dirty")
+}
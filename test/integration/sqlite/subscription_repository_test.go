@@ -0,0 +1,49 @@
+package sqlite_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/MDx3R/ef-test/internal/config"
+	gormdb "github.com/MDx3R/ef-test/internal/infra/database/gorm"
+	"github.com/MDx3R/ef-test/internal/usecase"
+	"github.com/MDx3R/ef-test/test/contract"
+)
+
+func newDatabase(t *testing.T) *gormdb.GormDatabase {
+	cfg := config.DatabaseConfig{
+		Driver:   "sqlite",
+		Database: ":memory:",
+	}
+
+	// Every in-memory database is private to its connection,
+	// so each test gets a fresh schema.
+	gormDB, err := gormdb.NewGormDatabase(&cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, gormDB.Dispose())
+	})
+
+	require.NoError(t, gormDB.Migrate())
+
+	return gormDB
+}
+
+func TestSQLiteSubscriptionRepository_Contract(t *testing.T) {
+	contract.RunSubscriptionRepositoryTests(t, func(t *testing.T) usecase.SubscriptionRepository {
+		return gormdb.NewGormSubscriptionRepository(newDatabase(t).GetDB())
+	})
+}
+
+func TestSQLiteAPIKeyRepository_Contract(t *testing.T) {
+	contract.RunAPIKeyRepositoryTests(t, func(t *testing.T) usecase.APIKeyRepository {
+		return gormdb.NewGormAPIKeyRepository(newDatabase(t).GetDB())
+	})
+}
+
+func TestSQLiteIdempotencyRepository_Contract(t *testing.T) {
+	contract.RunIdempotencyRepositoryTests(t, func(t *testing.T) usecase.IdempotencyRepository {
+		return gormdb.NewGormIdempotencyRepository(newDatabase(t).GetDB())
+	})
+}
@@ -0,0 +1,27 @@
+// Package testutil holds the fixtures shared by tests of several packages.
+package testutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MDx3R/ef-test/internal/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// MakeTestSubscription returns an open-ended subscription of a new user,
+// started in August 2025.
+func MakeTestSubscription(t *testing.T) *entity.Subscription {
+	t.Helper()
+	sub, err := entity.NewSubscriptionWithID(
+		uuid.New(),
+		"test_service",
+		uuid.New(),
+		100,
+		time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+		nil,
+	)
+	require.NoError(t, err)
+	return sub
+}